@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/nakiner/guestcovider/configs"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/nakiner/guestcovider/internal/edgeRepository"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/nakiner/guestcovider/internal/server"
+	"github.com/nakiner/guestcovider/pkg/health"
+	"github.com/nakiner/guestcovider/pkg/operator"
+	"github.com/nakiner/guestcovider/pkg/user"
+	"github.com/nakiner/guestcovider/tools/tracing"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// runEdge serves the door of one event from a local SQLite copy synced with
+// the central instance over its gRPC services. Door operators log in at the
+// edge against its own operator table, so they keep working while the
+// central instance is out of reach, and their changes reach the central
+// audit under their login.
+func runEdge(ctx context.Context, cfg *configs.Config, logger log.Logger) error {
+	db, err := edgeRepository.Open(cfg.Edge.DBPath)
+	if err != nil {
+		return err
+	}
+	pool, err := db.DB()
+	if err != nil {
+		return err
+	}
+	repo := edgeRepository.NewEdgeDBRepository(db)
+	operatorRepo := operatorRepository.NewOperatorDBRepository(&database.Connection{Master: db})
+
+	if created, err := operator.EnsureAdmin(ctx, operatorRepo, cfg.Auth.AdminLogin, cfg.Auth.AdminPassword); err != nil {
+		level.Error(logger).Log("msg", "create admin operator", "err", err)
+	} else if created {
+		level.Info(logger).Log("msg", "created admin operator", "login", cfg.Auth.AdminLogin)
+	}
+
+	// the dial does not wait for the central instance, the sync keeps
+	// retrying while it is out of reach
+	conn, err := grpc.Dial(cfg.Edge.Central, grpc.WithInsecure())
+	if err != nil {
+		pool.Close()
+		return errors.Wrap(err, "dial central instance")
+	}
+	tracer := tracing.FromContext(ctx)
+	operators := operator.NewGRPCClient(conn, tracer, logger)
+	login := func(ctx context.Context) (string, error) {
+		resp, err := operators.Login(ctx, &operator.LoginRequest{Login: cfg.Edge.Login, Password: cfg.Edge.Password})
+		if err != nil {
+			return "", err
+		}
+		return resp.Token, nil
+	}
+	edgeSync := user.NewEdgeSync(user.NewGRPCClient(conn, tracer, logger), repo, cfg.Edge.EventID, login,
+		time.Duration(cfg.Edge.RetrySec)*time.Second)
+
+	syncCtx, stopSync := context.WithCancel(ctx)
+	go edgeSync.Run(syncCtx, func(err error) {
+		level.Error(logger).Log("msg", "sync with the central instance", "err", err)
+	})
+
+	// the link to the central instance is left out, the door works without it
+	checks := health.NewRegistry(time.Duration(cfg.Health.TimeoutSec) * time.Second)
+	checks.Register("sqlite", health.CheckerFunc(pool.PingContext))
+	healthService := initHealthService(ctx, cfg, checks)
+	userService := user.NewEdgeService(repo, cfg.Edge.EventID, cfg.Edge.Name, edgeSync.Notify)
+	if cfg.Auth.Enabled {
+		userService = user.NewPolicyService(userService)
+	}
+	userService = decorateUserService(ctx, cfg, userService)
+	operatorService := initOperatorService(ctx, cfg, operatorRepo)
+
+	healthHandler := health.MakeHTTPHandler(ctx, healthService)
+	userHandler := user.MakeHTTPHandler(ctx, userService)
+	operatorHandler := operator.MakeHTTPHandler(ctx, operatorService)
+	edgeHandler := user.MakeEdgeHTTPHandler(edgeSync)
+
+	options := []server.Option{
+		server.SetConfig(cfg),
+		server.SetLogger(logger),
+		server.OnShutdown(checks.Drain),
+		server.OnClose("central sync", func() error {
+			stopSync()
+			return conn.Close()
+		}),
+		server.OnClose("sqlite", pool.Close),
+	}
+	if cfg.Auth.Enabled {
+		authenticator := auth.NewAuthenticator(operatorRepo)
+		authenticate := auth.HTTPMiddleware(authenticator, "/operator/login")
+		userHandler = authenticate(userHandler)
+		operatorHandler = authenticate(operatorHandler)
+		edgeHandler = authenticate(edgeHandler)
+
+		// health checks stay open for probes
+		public := []string{
+			"/guestcoviderpb.HealthService/Liveness",
+			"/guestcoviderpb.HealthService/Readiness",
+			"/guestcoviderpb.HealthService/Version",
+			"/guestcoviderpb.OperatorService/Login",
+		}
+		options = append(options, server.SetGRPCInterceptors(
+			auth.UnaryServerInterceptor(authenticator, public...),
+			auth.StreamServerInterceptor(authenticator, public...),
+		))
+	}
+
+	options = append(options, server.SetHandler(
+		map[string]http.Handler{
+			// health routes sit at the root, as the API spec has them
+			"liveness":  healthHandler,
+			"readiness": healthHandler,
+			"version":   healthHandler,
+			"operator":  operatorHandler,
+			"user":      userHandler,
+			"edge":      edgeHandler,
+		}),
+	)
+	options = append(options, initWebApp(cfg, logger)...)
+
+	s, err := server.NewServer(append(options,
+		server.SetGRPC(
+			health.JoinGRPC(ctx, healthService),
+			user.JoinGRPC(ctx, userService),
+			operator.JoinGRPC(ctx, operatorService),
+		),
+	)...)
+	if err != nil {
+		return errors.Wrap(err, "init server")
+	}
+	defer s.Close()
+
+	if err := s.AddHTTP(); err != nil {
+		return err
+	}
+	if err := s.AddGRPC(); err != nil {
+		return err
+	}
+	if err := s.AddMetrics(); err != nil {
+		return err
+	}
+
+	level.Info(logger).Log("msg", "running as an edge", "event", cfg.Edge.EventID, "central", cfg.Edge.Central)
+	s.AddSignalHandler()
+	s.Run()
+	return nil
+}
@@ -3,14 +3,24 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/covidcert"
 	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/nakiner/guestcovider/internal/migrations"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
 	"github.com/nakiner/guestcovider/internal/userRepository"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/nakiner/guestcovider/pkg/event"
 	"github.com/nakiner/guestcovider/pkg/health"
+	"github.com/nakiner/guestcovider/pkg/operator"
 	"github.com/nakiner/guestcovider/pkg/user"
 
+	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/nakiner/guestcovider/configs"
 	"github.com/nakiner/guestcovider/internal/server"
@@ -18,6 +28,8 @@ import (
 	"github.com/nakiner/guestcovider/tools/metrics"
 	"github.com/nakiner/guestcovider/tools/sentry"
 	"github.com/nakiner/guestcovider/tools/tracing"
+	"github.com/nakiner/guestcovider/web"
+	"github.com/spf13/pflag"
 )
 
 func main() {
@@ -65,34 +77,113 @@ func main() {
 		ctx = metrics.WithContext(ctx)
 	}
 
-	dbConn, err := database.Connect(ctx, cfg.Postgres)
-	if err != nil {
-		level.Error(logger).Log("msg", "db connect error", "err", err)
+	if cfg.Edge.Enabled {
+		if err := runEdge(ctx, cfg, logger); err != nil {
+			level.Error(logger).Log("msg", "edge", "err", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	defer dbConn.Close()
+	if args := pflag.Args(); len(args) > 0 && args[0] == "migrate" {
+		dbConn, err := database.Connect(ctx, cfg.Postgres)
+		if err != nil {
+			level.Error(logger).Log("msg", "db connect error", "err", err)
+			os.Exit(1)
+		}
+		err = runMigrate(ctx, dbConn, args[1:])
+		dbConn.Close()
+		if err != nil {
+			level.Error(logger).Log("msg", "migrate", "err", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	userRepo := userRepository.NewUserDBRepository(dbConn)
-	if cfg.Tracer.Enabled {
-		userRepo = userRepository.NewTracingRepository(ctx, userRepo)
+	store, err := openStorage(ctx, cfg, logger)
+	if err != nil {
+		level.Error(logger).Log("msg", "open storage", "err", err)
+		os.Exit(1)
+	}
+	userRepo, eventRepo, operatorRepo := store.users, store.events, store.operators
+
+	if created, err := operator.EnsureAdmin(ctx, operatorRepo, cfg.Auth.AdminLogin, cfg.Auth.AdminPassword); err != nil {
+		level.Error(logger).Log("msg", "create admin operator", "err", err)
+	} else if created {
+		level.Info(logger).Log("msg", "created admin operator", "login", cfg.Auth.AdminLogin)
 	}
 
-	healthService := initHealthService(ctx, cfg)
-	userService := initUserService(ctx, cfg, userRepo)
+	// every replica listens for changes committed by any of them
+	feed := userRepository.NewFeed(userRepo)
+	go feed.Run(ctx, func(err error) {
+		level.Error(logger).Log("msg", "read guest changes", "err", err)
+	})
+	go store.listen(ctx, feed.Notify)
 
-	s, err := server.NewServer(
+	checks := initHealthChecks(cfg, store.db, logger)
+	healthService := initHealthService(ctx, cfg, checks)
+	userService := initUserService(ctx, cfg, userRepo, eventRepo, initVerifier(cfg, logger), feed)
+	operatorService := initOperatorService(ctx, cfg, operatorRepo)
+	eventService := initEventService(ctx, cfg, eventRepo)
+
+	healthHandler := health.MakeHTTPHandler(ctx, healthService)
+	userHandler := user.MakeHTTPHandler(ctx, userService)
+	operatorHandler := operator.MakeHTTPHandler(ctx, operatorService)
+	eventHandler := event.MakeHTTPHandler(ctx, eventService)
+	options := []server.Option{
 		server.SetConfig(cfg),
 		server.SetLogger(logger),
-		server.SetHandler(
-			map[string]http.Handler{
-				"health": health.MakeHTTPHandler(ctx, healthService),
-				"user":   user.MakeHTTPHandler(ctx, userService),
-			}),
+		server.OnShutdown(checks.Drain),
+		server.OnDrain(feed.Close),
+		server.OnClose("guest changes listener", func() error {
+			cancel()
+			return nil
+		}),
+	}
+	if store.db != nil {
+		options = append(options, server.OnClose("postgres", store.db.Close))
+	}
+	if cfg.Auth.Enabled {
+		authenticator := auth.NewAuthenticator(operatorRepo, splitList(cfg.Auth.SyncLogins)...)
+		authenticate := auth.HTTPMiddleware(authenticator, "/operator/login")
+		userHandler = authenticate(userHandler)
+		operatorHandler = authenticate(operatorHandler)
+		eventHandler = authenticate(eventHandler)
+
+		// health checks stay open for probes
+		public := []string{
+			"/guestcoviderpb.HealthService/Liveness",
+			"/guestcoviderpb.HealthService/Readiness",
+			"/guestcoviderpb.HealthService/Version",
+			"/guestcoviderpb.OperatorService/Login",
+		}
+		options = append(options, server.SetGRPCInterceptors(
+			auth.UnaryServerInterceptor(authenticator, public...),
+			auth.StreamServerInterceptor(authenticator, public...),
+		))
+	}
+
+	options = append(options, server.SetHandler(
+		map[string]http.Handler{
+			// health routes sit at the root, as the API spec has them
+			"liveness":  healthHandler,
+			"readiness": healthHandler,
+			"version":   healthHandler,
+			"operator":  operatorHandler,
+			"event":     eventHandler,
+			"user":      userHandler,
+		}),
+	)
+	options = append(options, initWebApp(cfg, logger)...)
+
+	s, err := server.NewServer(append(options,
 		server.SetGRPC(
 			health.JoinGRPC(ctx, healthService),
 			user.JoinGRPC(ctx, userService),
+			operator.JoinGRPC(ctx, operatorService),
+			event.JoinGRPC(ctx, eventService),
 		),
-	)
+	)...)
 	if err != nil {
 		level.Error(logger).Log("init", "server", "err", err)
 		os.Exit(1)
@@ -118,8 +209,42 @@ func main() {
 	s.Run()
 }
 
-func initHealthService(ctx context.Context, cfg *configs.Config) health.Service {
-	healthService := health.NewHealthService()
+// initHealthChecks registers readiness checks, dbConn is nil for the memory
+// storage.
+func initHealthChecks(cfg *configs.Config, dbConn *database.Connection, logger log.Logger) *health.Registry {
+	timeout := time.Duration(cfg.Health.TimeoutSec) * time.Second
+	checks := health.NewRegistry(timeout)
+	if dbConn != nil {
+		checks.Register("postgres", health.CheckerFunc(dbConn.Ping))
+		checks.Register("migrations", health.CheckerFunc(func(ctx context.Context) error {
+			pending, err := migrations.Pending(ctx, dbConn)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending, next is %s", len(pending), pending[0])
+			}
+			return nil
+		}))
+	} else {
+		// the memory storage is up as long as the process is
+		checks.Register("memory", health.CheckerFunc(func(context.Context) error { return nil }))
+	}
+
+	client := &http.Client{Timeout: timeout}
+	for _, d := range splitList(cfg.Health.Downstreams) {
+		parts := strings.SplitN(d, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			level.Error(logger).Log("msg", "health downstream must be name=url", "downstream", d)
+			continue
+		}
+		checks.Register(parts[0], health.HTTPChecker(client, parts[1]))
+	}
+	return checks
+}
+
+func initHealthService(ctx context.Context, cfg *configs.Config, checks *health.Registry) health.Service {
+	healthService := health.NewHealthService(checks)
 	if cfg.Metrics.Enabled {
 		healthService = health.NewMetricsService(ctx, healthService)
 	}
@@ -133,8 +258,34 @@ func initHealthService(ctx context.Context, cfg *configs.Config) health.Service
 	return healthService
 }
 
-func initUserService(ctx context.Context, cfg *configs.Config, repo userRepository.Repository) user.Service {
-	userService := user.NewUserService(repo)
+func initVerifier(cfg *configs.Config, logger log.Logger) *covidcert.Verifier {
+	trust := &covidcert.TrustList{}
+	if cfg.Covidcert.TrustList != "" {
+		var err error
+		if trust, err = covidcert.LoadTrustList(cfg.Covidcert.TrustList); err != nil {
+			level.Error(logger).Log("msg", "load covid certificate trust list", "err", err)
+			trust = &covidcert.TrustList{}
+		}
+	}
+	// Gosuslugi links are checked online only, left to door staff without a service
+	var gosuslugi *covidcert.Gosuslugi
+	if cfg.Covidcert.GosuslugiURL != "" {
+		gosuslugi = covidcert.NewGosuslugi(cfg.Covidcert.GosuslugiURL, time.Duration(cfg.Covidcert.TimeoutSec)*time.Second)
+	}
+	return covidcert.NewVerifier(trust, gosuslugi)
+}
+
+func initUserService(ctx context.Context, cfg *configs.Config, repo userRepository.Repository, events eventRepository.Repository, verifier *covidcert.Verifier, feed *userRepository.Feed) user.Service {
+	userService := user.NewUserService(repo, events, verifier, feed)
+	if cfg.Auth.Enabled {
+		userService = user.NewPolicyService(userService)
+	}
+	return decorateUserService(ctx, cfg, userService)
+}
+
+// decorateUserService adds metrics, logging, tracing and sentry as the
+// config enables them.
+func decorateUserService(ctx context.Context, cfg *configs.Config, userService user.Service) user.Service {
 	if cfg.Metrics.Enabled {
 		userService = user.NewMetricsService(ctx, userService)
 	}
@@ -147,3 +298,70 @@ func initUserService(ctx context.Context, cfg *configs.Config, repo userReposito
 	}
 	return userService
 }
+
+// initWebApp returns the option serving the web app when it is enabled and
+// built into the binary.
+func initWebApp(cfg *configs.Config, logger log.Logger) []server.Option {
+	if !cfg.Web.Enabled {
+		return nil
+	}
+	app := web.FS()
+	if app == nil {
+		level.Error(logger).Log("msg", "web app is not built into the binary, build it with the webui tag")
+		return nil
+	}
+	handler, err := server.NewWebApp(app, server.WebConfig{APIURL: cfg.Web.APIURL})
+	if err != nil {
+		level.Error(logger).Log("msg", "serve web app", "err", err)
+		return nil
+	}
+	return []server.Option{server.SetWebApp(handler)}
+}
+
+func initOperatorService(ctx context.Context, cfg *configs.Config, repo operatorRepository.Repository) operator.Service {
+	ttl := time.Duration(cfg.Auth.SessionTTLHours) * time.Hour
+	operatorService := operator.NewOperatorService(repo, ttl)
+	if cfg.Auth.Enabled {
+		operatorService = operator.NewPolicyService(operatorService)
+	}
+	if cfg.Metrics.Enabled {
+		operatorService = operator.NewMetricsService(ctx, operatorService)
+	}
+	operatorService = operator.NewLoggingService(ctx, operatorService)
+	if cfg.Tracer.Enabled {
+		operatorService = operator.NewTracingService(ctx, operatorService)
+	}
+	if cfg.Sentry.Enabled {
+		operatorService = operator.NewSentryService(operatorService)
+	}
+	return operatorService
+}
+
+func initEventService(ctx context.Context, cfg *configs.Config, repo eventRepository.Repository) event.Service {
+	eventService := event.NewEventService(repo)
+	if cfg.Auth.Enabled {
+		eventService = event.NewPolicyService(eventService)
+	}
+	if cfg.Metrics.Enabled {
+		eventService = event.NewMetricsService(ctx, eventService)
+	}
+	eventService = event.NewLoggingService(ctx, eventService)
+	if cfg.Tracer.Enabled {
+		eventService = event.NewTracingService(ctx, eventService)
+	}
+	if cfg.Sentry.Enabled {
+		eventService = event.NewSentryService(eventService)
+	}
+	return eventService
+}
+
+// splitList returns the non-empty items of a comma separated config value.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/nakiner/guestcovider/internal/migrations"
+	"github.com/pkg/errors"
+)
+
+const migrateUsage = `usage: app migrate <command> [id]
+
+commands:
+  up [id]     apply pending migrations, up to id when given
+  down [id]   roll back the last migration, or every migration after id
+  status      list migrations and whether they are applied`
+
+// runMigrate handles the migrate subcommand: app migrate up|down|status.
+func runMigrate(ctx context.Context, conn *database.Connection, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	var id string
+	if len(args) > 1 {
+		id = args[1]
+	}
+
+	switch args[0] {
+	case "up":
+		return migrations.Up(ctx, conn, id)
+	case "down":
+		return migrations.Down(ctx, conn, id)
+	case "status":
+		states, err := migrations.Status(ctx, conn)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSTATUS")
+		for _, s := range states {
+			status := "pending"
+			if s.Applied {
+				status = "applied"
+			}
+			fmt.Fprintf(w, "%s\t%s\n", s.ID, status)
+		}
+		return w.Flush()
+	default:
+		return errors.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+}
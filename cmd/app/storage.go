@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/nakiner/guestcovider/configs"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/nakiner/guestcovider/internal/migrations"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/nakiner/guestcovider/pkg/user"
+	"github.com/pkg/errors"
+)
+
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+// storage keeps guests, events and operators.
+type storage struct {
+	users     userRepository.Repository
+	events    eventRepository.Repository
+	operators operatorRepository.Repository
+	// listen calls notify on changes committed by any replica until ctx
+	// is done
+	listen func(ctx context.Context, notify func())
+	// db is nil for the memory storage
+	db *database.Connection
+}
+
+// openStorage returns the storage picked by storage.driver.
+func openStorage(ctx context.Context, cfg *configs.Config, logger log.Logger) (*storage, error) {
+	var s *storage
+	var err error
+	switch cfg.Storage.Driver {
+	case storagePostgres:
+		s, err = openPostgres(ctx, cfg, logger)
+	case storageMemory:
+		s, err = openMemory(ctx, cfg.Storage.Seed)
+	default:
+		return nil, errors.Errorf("unknown storage driver %q, use %s or %s", cfg.Storage.Driver, storagePostgres, storageMemory)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.SearchCache.Enabled {
+		s.users = withSearchCache(ctx, cfg, logger, s)
+	}
+	if cfg.Tracer.Enabled {
+		s.users = userRepository.NewTracingRepository(ctx, s.users)
+		s.events = eventRepository.NewTracingRepository(ctx, s.events)
+		s.operators = operatorRepository.NewTracingRepository(ctx, s.operators)
+	}
+	return s, nil
+}
+
+func openPostgres(ctx context.Context, cfg *configs.Config, logger log.Logger) (*storage, error) {
+	dbConn, err := database.Connect(ctx, cfg.Postgres)
+	if err != nil {
+		return nil, errors.Wrap(err, "db connect error")
+	}
+
+	go dbConn.MonitorReplicas(ctx,
+		time.Duration(cfg.Postgres.ReplicaCheckSec)*time.Second,
+		time.Duration(cfg.Postgres.ReplicaMaxLagSec)*time.Second,
+		func(err error) {
+			level.Error(logger).Log("msg", "postgres replica is down", "err", err)
+		},
+	)
+	if cfg.Metrics.Enabled {
+		if err := dbConn.RegisterMetrics(); err != nil {
+			level.Error(logger).Log("msg", "postgres pool metrics", "err", err)
+		}
+	}
+
+	if cfg.Migrations.Auto {
+		if err := migrations.Up(ctx, dbConn, ""); err != nil {
+			dbConn.Close()
+			return nil, errors.Wrap(err, "db migration error")
+		}
+	}
+
+	return &storage{
+		users:     userRepository.NewUserDBRepository(dbConn),
+		events:    eventRepository.NewEventDBRepository(dbConn),
+		operators: operatorRepository.NewOperatorDBRepository(dbConn),
+		listen: func(ctx context.Context, notify func()) {
+			database.NewListener(cfg.Postgres, userRepository.ChangesChannel).Run(ctx,
+				func(string) { notify() },
+				func(err error) {
+					level.Error(logger).Log("msg", "listen for guest changes", "err", err)
+				},
+			)
+		},
+		db: dbConn,
+	}, nil
+}
+
+// withSearchCache wraps the guests of s with the search cache. With
+// Postgres every replica announces its writes on CacheChannel and drops
+// the results the others announce.
+func withSearchCache(ctx context.Context, cfg *configs.Config, logger log.Logger, s *storage) userRepository.Repository {
+	cache := userRepository.NewSearchCache(cfg.SearchCache.Size, time.Duration(cfg.SearchCache.TTLSec)*time.Second)
+	if s.db == nil {
+		return userRepository.NewCachingRepository(s.users, cache, nil)
+	}
+
+	go database.NewListener(cfg.Postgres, userRepository.CacheChannel).Run(ctx,
+		func(payload string) {
+			if err := cache.HandleInvalidation(payload); err != nil {
+				level.Error(logger).Log("msg", "search cache invalidation", "err", err)
+			}
+		},
+		func(err error) {
+			level.Error(logger).Log("msg", "listen for search cache invalidations", "err", err)
+		},
+	)
+	return userRepository.NewCachingRepository(s.users, cache, func(ctx context.Context, payload string) {
+		if err := s.db.Notify(ctx, userRepository.CacheChannel, payload); err != nil {
+			level.Error(logger).Log("msg", "publish search cache invalidation", "err", err)
+		}
+	})
+}
+
+// openMemory returns a storage kept in memory, filled from the seed file
+// when one is given.
+func openMemory(ctx context.Context, seedFile string) (*storage, error) {
+	users := userRepository.NewUserMemoryRepository()
+	changes := userRepository.MemoryChanges(users)
+	s := &storage{
+		users:     users,
+		events:    eventRepository.NewEventMemoryRepository(),
+		operators: operatorRepository.NewOperatorMemoryRepository(),
+		listen: func(ctx context.Context, notify func()) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-changes:
+					notify()
+				}
+			}
+		},
+	}
+	if seedFile == "" {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(seedFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read storage seed")
+	}
+	var data seed
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, errors.Wrapf(err, "parse storage seed %s", seedFile)
+	}
+	if err := data.load(ctx, s); err != nil {
+		return nil, errors.Wrapf(err, "load storage seed %s", seedFile)
+	}
+	return s, nil
+}
+
+// seed is the content the memory storage starts with. Events with the id
+// of an existing one replace it, guests are created the way the API does.
+type seed struct {
+	Events []struct {
+		ID             uint64    `json:"id"`
+		Name           string    `json:"name"`
+		Venue          string    `json:"venue"`
+		StartsAt       time.Time `json:"startsAt"`
+		EndsAt         time.Time `json:"endsAt"`
+		CovidPassTypes []string  `json:"covidPassTypes"`
+	} `json:"events"`
+	Operators []struct {
+		Login    string `json:"login"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	} `json:"operators"`
+	Users []user.User `json:"users"`
+}
+
+func (d *seed) load(ctx context.Context, s *storage) error {
+	for _, e := range d.Events {
+		event := &eventRepository.Event{
+			ID:             e.ID,
+			Name:           e.Name,
+			Venue:          e.Venue,
+			StartsAt:       e.StartsAt,
+			EndsAt:         e.EndsAt,
+			CovidPassTypes: e.CovidPassTypes,
+		}
+		err := s.events.UpdateEvent(ctx, event)
+		if errors.Is(err, eventRepository.ErrNotFound) {
+			err = s.events.CreateEvent(ctx, event)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "event %q", e.Name)
+		}
+	}
+
+	for _, o := range d.Operators {
+		hash, err := auth.HashPassword(o.Password)
+		if err != nil {
+			return err
+		}
+		if err := s.operators.CreateOperator(ctx, &operatorRepository.Operator{
+			Login:        o.Login,
+			Name:         o.Name,
+			PasswordHash: hash,
+			Role:         o.Role,
+		}); err != nil {
+			return errors.Wrapf(err, "operator %q", o.Login)
+		}
+	}
+
+	users := user.NewUserService(s.users, s.events, nil, nil)
+	for i := range d.Users {
+		u := &d.Users[i]
+		if _, err := users.CreateUser(ctx, &user.CreateUserRequest{EventId: u.EventId, Data: u}); err != nil {
+			return errors.Wrapf(err, "guest %s %s", u.Surname, u.Name)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/nakiner/guestcovider/internal/covidcert"
 	"github.com/nakiner/guestcovider/internal/database"
 	"os"
 	"strings"
@@ -20,8 +21,21 @@ var options = []option{
 
 	{"server.http.port", "int", 8080, "server http port"},
 	{"server.http.timeout_sec", "int", 86400, "server http connection timeout"},
+	{"server.http.cors_origins", "string", "", "Comma separated origins browsers may call the API from, the web app served by the service itself needs none"},
 	{"server.grpc.port", "int", 9194, "server grpc port"},
 	{"server.grpc.timeout_sec", "int", 86400, "server grpc connection timeout"},
+	{"server.shutdown_delay_sec", "int", 5, "Time readiness fails on shutdown before the servers stop taking requests, so that load balancers stop routing here"},
+	{"server.shutdown_timeout_sec", "int", 30, "Time in-flight requests are given to finish on shutdown"},
+
+	{"web.enabled", "bool", false, "Serve the web app, the binary has to be built with the webui tag"},
+	{"web.api_url", "string", "/", "Base URL the web app calls the API at"},
+
+	{"storage.driver", "string", "postgres", "Storage of guests, events and operators: postgres, or memory for tests and demos, which loses everything on restart"},
+	{"storage.seed", "string", "", "JSON file with events, operators and guests the memory storage starts with"},
+
+	{"search_cache.enabled", "bool", true, "Cache guest search results, writes drop the results they change on every replica"},
+	{"search_cache.size", "int", 1000, "Maximum number of cached search results"},
+	{"search_cache.ttl_sec", "int", 5, "How long a search result is cached, bounds how stale a result read from a lagging replica may be"},
 
 	{"postgres.host", "string", "localhost", "postgres master host"},
 	{"postgres.port", "int", 5432, "postgres master port"},
@@ -29,6 +43,38 @@ var options = []option{
 	{"postgres.password", "string", "guestcovider", "postgres master password"},
 	{"postgres.database_name", "string", "guestcovider", "postgres master database name"},
 	{"postgres.secure", "string", "disable", "postgres master SSL support"},
+	{"postgres.replicas", "string", "", "Comma separated host[:port] list of postgres streaming replicas searches and exports read from, they share the master credentials"},
+	{"postgres.replica_max_lag_sec", "int", 5, "How far behind the master a postgres replica may be to take reads"},
+	{"postgres.replica_check_sec", "int", 5, "How often postgres replicas are checked"},
+	{"postgres.max_open_conns", "int", 20, "Maximum number of open connections per postgres pool"},
+	{"postgres.max_idle_conns", "int", 10, "Maximum number of idle connections per postgres pool"},
+	{"postgres.conn_max_lifetime_sec", "int", 1800, "How long a postgres connection is reused before it is closed"},
+	{"postgres.conn_max_idle_time_sec", "int", 300, "How long a postgres connection may stay idle before it is closed"},
+	{"postgres.connect_timeout_sec", "int", 60, "How long the start waits for postgres to come up"},
+
+	{"migrations.auto", "bool", false, "Apply pending schema migrations on start"},
+
+	{"auth.enabled", "bool", true, "Require an operator session for guest and operator calls"},
+	{"auth.session_ttl_hours", "int", 12, "Lifetime of an operator session"},
+	{"auth.admin_login", "string", "admin", "Login of the admin created on start when there are no operators"},
+	{"auth.admin_password", "string", "", "Password of the admin created on start, no admin is created when empty"},
+	{"auth.sync_logins", "string", "", "Comma separated logins of the operators edges sync as, only they may send changes made for door operators"},
+
+	{"covidcert.trust_list", "string", "", "PEM file with the EU DCC document signer certificates, DCC certificates are rejected without one"},
+	{"covidcert.gosuslugi_url", "string", "", "Gosuslugi certificate status service, the certificate id is appended. Checks need network access to it, e.g. " + covidcert.DefaultGosuslugiURL + "; Gosuslugi links are checked by hand with the qr pass type when empty or unreachable"},
+	{"covidcert.timeout_sec", "int", 5, "Timeout of a Gosuslugi certificate status request"},
+
+	{"health.timeout_sec", "int", 2, "Timeout of each readiness check"},
+	{"health.downstreams", "string", "", "Comma separated name=url list of HTTP services readiness depends on, a server error means the service is down"},
+
+	{"edge.enabled", "bool", false, "Run as an edge: serve the door of one event from a local SQLite copy synced with the central instance, postgres is not used. Needs a binary built with the edge tag"},
+	{"edge.name", "string", "edge", "Name of the edge, stands for the operator of door changes when auth is disabled"},
+	{"edge.event_id", "int", 1, "Event the edge keeps"},
+	{"edge.db_path", "string", "edge.db", "SQLite file of the edge copy"},
+	{"edge.central", "string", "localhost:9194", "gRPC address of the central instance"},
+	{"edge.login", "string", "", "Login the edge syncs as, listed in auth.sync_logins of the central instance"},
+	{"edge.password", "string", "", "Password of the account the edge syncs as"},
+	{"edge.retry_sec", "int", 5, "How often queued door changes are sent and a lost link to the central instance is retried"},
 
 	{"logger.level", "string", "emerg", "Level of logging. A string that correspond to the following levels: emerg, alert, crit, err, warning, notice, info, debug"},
 	{"logger.time_format", "string", "2006-01-02T15:04:05.999999999", "Date format in logs"},
@@ -56,9 +102,12 @@ type Config struct {
 			TimeoutSec int `mapstructure:"timeout_sec"`
 		}
 		HTTP struct {
-			Port       int
-			TimeoutSec int `mapstructure:"timeout_sec"`
+			Port        int
+			TimeoutSec  int    `mapstructure:"timeout_sec"`
+			CORSOrigins string `mapstructure:"cors_origins"`
 		}
+		ShutdownDelaySec   int `mapstructure:"shutdown_delay_sec"`
+		ShutdownTimeoutSec int `mapstructure:"shutdown_timeout_sec"`
 	}
 	Logger struct {
 		Level      string
@@ -83,7 +132,49 @@ type Config struct {
 		Enabled bool
 		Limit   float64
 	}
-	Postgres database.Config
+	Storage struct {
+		Driver string
+		Seed   string
+	}
+	SearchCache struct {
+		Enabled bool
+		Size    int
+		TTLSec  int `mapstructure:"ttl_sec"`
+	}
+	Postgres   database.Config
+	Migrations struct {
+		Auto bool
+	}
+	Auth struct {
+		Enabled         bool
+		SessionTTLHours int    `mapstructure:"session_ttl_hours"`
+		AdminLogin      string `mapstructure:"admin_login"`
+		AdminPassword   string `mapstructure:"admin_password"`
+		SyncLogins      string `mapstructure:"sync_logins"`
+	}
+	Covidcert struct {
+		TrustList    string `mapstructure:"trust_list"`
+		GosuslugiURL string `mapstructure:"gosuslugi_url"`
+		TimeoutSec   int    `mapstructure:"timeout_sec"`
+	}
+	Web struct {
+		Enabled bool
+		APIURL  string `mapstructure:"api_url"`
+	}
+	Health struct {
+		TimeoutSec  int `mapstructure:"timeout_sec"`
+		Downstreams string
+	}
+	Edge struct {
+		Enabled  bool
+		Name     string
+		EventID  uint64 `mapstructure:"event_id"`
+		DBPath   string `mapstructure:"db_path"`
+		Central  string
+		Login    string
+		Password string
+		RetrySec int `mapstructure:"retry_sec"`
+	}
 }
 
 type option struct {
@@ -193,6 +284,8 @@ func (c *Config) GenerateFromTask() error {
 
 func (c Config) Print() error {
 	c.Postgres.Password = "******"
+	c.Auth.AdminPassword = "******"
+	c.Edge.Password = "******"
 
 	b, err := json.Marshal(c)
 	if err != nil {
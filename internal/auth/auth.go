@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+)
+
+// ErrUnauthenticated is returned for a missing, unknown or expired session token.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrActingDenied is returned when an operator other than a sync account
+// names the operator a call is made for.
+var ErrActingDenied = errors.New("only edge sync accounts may act for another operator")
+
+// Operator is the identity of the logged in operator carried in the context.
+type Operator struct {
+	ID    uint64
+	Login string
+	Name  string
+	Role  string
+	// Sync is set for the accounts edges sync as, only they may name the
+	// operator a call is made for.
+	Sync bool
+}
+
+// roleRank orders roles, every role may do what the lower ones can.
+var roleRank = map[string]int{
+	operatorRepository.RoleDoor:        1,
+	operatorRepository.RoleCoordinator: 2,
+	operatorRepository.RoleAdmin:       3,
+}
+
+// HasRole reports whether the operator has role or a higher one.
+func (o Operator) HasRole(role string) bool {
+	return roleRank[o.Role] > 0 && roleRank[o.Role] >= roleRank[role]
+}
+
+type operatorKey struct{}
+
+type tokenKey struct{}
+
+type actingKey struct{}
+
+// WithOperator stores the authenticated operator.
+func WithOperator(ctx context.Context, op Operator) context.Context {
+	return context.WithValue(ctx, operatorKey{}, op)
+}
+
+// OperatorFromContext returns the operator set by WithOperator.
+func OperatorFromContext(ctx context.Context) (Operator, bool) {
+	op, ok := ctx.Value(operatorKey{}).(Operator)
+	return op, ok
+}
+
+// WithActing stores the login of the operator a call is made for. Edges
+// send changes made at the door under their own session, servers record
+// the acting operator next to the one logged in and refuse it from
+// operators other than sync accounts.
+func WithActing(ctx context.Context, login string) context.Context {
+	return context.WithValue(ctx, actingKey{}, login)
+}
+
+// ActingFromContext returns the login set by WithActing.
+func ActingFromContext(ctx context.Context) string {
+	login, _ := ctx.Value(actingKey{}).(string)
+	return login
+}
+
+// WithToken stores the session token, servers use it to close the session
+// and clients send it along with requests.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext returns the token set by WithToken.
+func TokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+// Authenticator resolves session tokens into operators.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (Operator, error)
+}
+
+type sessionAuthenticator struct {
+	repo operatorRepository.Repository
+	sync map[string]bool
+}
+
+// NewAuthenticator checks tokens against the sessions stored in the
+// repository. Operators with one of syncLogins are the sync accounts of edges.
+func NewAuthenticator(repo operatorRepository.Repository, syncLogins ...string) Authenticator {
+	sync := make(map[string]bool, len(syncLogins))
+	for _, login := range syncLogins {
+		sync[strings.ToLower(login)] = true
+	}
+	return &sessionAuthenticator{repo: repo, sync: sync}
+}
+
+func (a *sessionAuthenticator) Authenticate(ctx context.Context, token string) (Operator, error) {
+	if token == "" {
+		return Operator{}, errors.Wrap(ErrUnauthenticated, "no session token")
+	}
+
+	_, op, err := a.repo.GetSession(ctx, HashToken(token))
+	if errors.Is(err, operatorRepository.ErrNotFound) {
+		return Operator{}, errors.Wrap(ErrUnauthenticated, "unknown or expired session")
+	}
+	if err != nil {
+		return Operator{}, err
+	}
+
+	return Operator{
+		ID:    op.ID,
+		Login: op.Login,
+		Name:  op.Name,
+		Role:  op.Role,
+		Sync:  a.sync[strings.ToLower(op.Login)],
+	}, nil
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" value.
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+// publicSet indexes the paths or methods served without a session.
+func publicSet(names []string) map[string]bool {
+	open := make(map[string]bool, len(names))
+	for _, m := range names {
+		open[m] = true
+	}
+	return open
+}
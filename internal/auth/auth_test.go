@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type staticAuthenticator map[string]Operator
+
+func (a staticAuthenticator) Authenticate(_ context.Context, token string) (Operator, error) {
+	if op, ok := a[token]; ok {
+		return op, nil
+	}
+	return Operator{}, errors.Wrap(ErrUnauthenticated, "unknown token")
+}
+
+var door = staticAuthenticator{
+	"secret": {ID: 7, Login: "door1", Role: "door"},
+	"edge":   {ID: 8, Login: "edge1", Role: "coordinator", Sync: true},
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	require.NoError(t, err)
+
+	assert.True(t, CheckPassword(hash, "correct horse"))
+	assert.False(t, CheckPassword(hash, "correct horse "))
+	assert.False(t, CheckPassword("plain", "plain"))
+
+	other, err := HashPassword("correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salt")
+}
+
+func TestToken(t *testing.T) {
+	token, hash, err := NewToken()
+	require.NoError(t, err)
+	assert.Equal(t, HashToken(token), hash)
+	assert.NotEqual(t, token, hash)
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	h := HTTPMiddleware(door, "/operator/login")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, _ := OperatorFromContext(r.Context())
+		w.Write([]byte(op.Login))
+	}))
+
+	serve := func(method, path, header string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if header != "" {
+			r.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("GET", "/user/search", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/user/search", "Bearer wrong").Code)
+
+	w = serve("GET", "/user/search", "bearer secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "door1", w.Body.String())
+
+	assert.Equal(t, http.StatusOK, serve("POST", "/operator/login", "").Code)
+
+	// only sync accounts act for door operators
+	r := httptest.NewRequest("POST", "/user/checkin", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("X-Acting-Operator", "anna")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	r.Header.Set("Authorization", "Bearer edge")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusOK, serve("OPTIONS", "/user/search", "").Code)
+
+	// only event streams take the token from the query
+	assert.Equal(t, http.StatusUnauthorized, serve("GET", "/user/search?access_token=secret", "").Code)
+	r = httptest.NewRequest("GET", "/user/events?access_token=secret", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, "door1", w.Body.String())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor(door, "/guestcoviderpb.OperatorService/Login")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		op, _ := OperatorFromContext(ctx)
+		return op.Login, nil
+	}
+	call := func(method string, md metadata.MD) (interface{}, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	_, err := call("/guestcoviderpb.UserService/SearchUser", metadata.MD{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := call("/guestcoviderpb.UserService/SearchUser", metadata.Pairs("authorization", "Bearer secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "door1", resp)
+
+	_, err = call("/guestcoviderpb.OperatorService/Login", metadata.MD{})
+	assert.NoError(t, err)
+
+	// an edge sends changes for the door operator under its own session
+	actingCall := func(token string) (interface{}, error) {
+		md := metadata.MD{}
+		ContextToGRPC()(WithActing(WithToken(context.Background(), token), "anna"), &md)
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/guestcoviderpb.UserService/CheckinByToken"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return ActingFromContext(ctx), nil
+			})
+	}
+	acting, err := actingCall("edge")
+	require.NoError(t, err)
+	assert.Equal(t, "anna", acting)
+
+	// door staff may not act for anyone
+	_, err = actingCall("secret")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthenticatorSync(t *testing.T) {
+	ctx := context.Background()
+	repo := operatorRepository.NewOperatorMemoryRepository()
+	a := NewAuthenticator(repo, "Edge1")
+	for _, login := range []string{"edge1", "door1"} {
+		op := &operatorRepository.Operator{Login: login, PasswordHash: "hash", Role: operatorRepository.RoleCoordinator}
+		require.NoError(t, repo.CreateOperator(ctx, op))
+		require.NoError(t, repo.CreateSession(ctx, &operatorRepository.Session{TokenHash: HashToken(login), OperatorID: op.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+	}
+
+	op, err := a.Authenticate(ctx, "edge1")
+	require.NoError(t, err)
+	assert.True(t, op.Sync)
+	op, err = a.Authenticate(ctx, "door1")
+	require.NoError(t, err)
+	assert.False(t, op.Sync)
+}
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationKey is the metadata key carrying "Bearer <token>".
+const authorizationKey = "authorization"
+
+// actingMetadataKey is the metadata key carrying the login set by WithActing.
+const actingMetadataKey = "x-acting-operator"
+
+// UnaryServerInterceptor rejects calls without a valid session with
+// Unauthenticated, and with PermissionDenied when an operator other than a
+// sync account sends the acting operator, and puts the operator into the
+// call context. Full method names listed in public, e.g.
+// "/guestcoviderpb.OperatorService/Login", are passed through.
+func UnaryServerInterceptor(a Authenticator, public ...string) grpc.UnaryServerInterceptor {
+	open := publicSet(public)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if open[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticateGRPC(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming calls.
+func StreamServerInterceptor(a Authenticator, public ...string) grpc.StreamServerInterceptor {
+	open := publicSet(public)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if open[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticateGRPC(ss.Context(), a)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// ContextToGRPC sends the token stored by WithToken and the operator stored
+// by WithActing in the call metadata.
+func ContextToGRPC() grpctransport.ClientRequestFunc {
+	return func(ctx context.Context, md *metadata.MD) context.Context {
+		if token := TokenFromContext(ctx); token != "" {
+			(*md)[authorizationKey] = []string{"Bearer " + token}
+		}
+		if acting := ActingFromContext(ctx); acting != "" {
+			(*md)[actingMetadataKey] = []string{acting}
+		}
+		return ctx
+	}
+}
+
+// OutgoingContext adds the token stored by WithToken to the outgoing metadata,
+// for streaming calls made without go-kit client options.
+func OutgoingContext(ctx context.Context) context.Context {
+	if token := TokenFromContext(ctx); token != "" {
+		return metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+	}
+	return ctx
+}
+
+func authenticateGRPC(ctx context.Context, a Authenticator) (context.Context, error) {
+	var token, acting string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			token = bearerToken(values[0])
+		}
+		if values := md.Get(actingMetadataKey); len(values) > 0 {
+			acting = values[0]
+		}
+	}
+
+	op, err := a.Authenticate(ctx, token)
+	if errors.Is(err, ErrUnauthenticated) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if acting != "" && !op.Sync {
+		return nil, status.Error(codes.PermissionDenied, ErrActingDenied.Error())
+	}
+
+	ctx = WithToken(WithOperator(ctx, op), token)
+	if acting != "" {
+		ctx = WithActing(ctx, acting)
+	}
+	return ctx, nil
+}
+
+// serverStream overrides the context of a wrapped stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
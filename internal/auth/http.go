@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/pkg/errors"
+)
+
+// eventStream is the Accept header of server-sent event requests, which may
+// carry the token in the access_token parameter.
+const eventStream = "text/event-stream"
+
+// actingHeader carries the login set by WithActing.
+const actingHeader = "X-Acting-Operator"
+
+// HTTPMiddleware rejects requests without a valid session with 401, and
+// with 403 when an operator other than a sync account sends the acting
+// operator, and puts the operator into the request context. Paths listed in
+// public and CORS preflight requests are passed through untouched.
+func HTTPMiddleware(a Authenticator, public ...string) func(http.Handler) http.Handler {
+	open := publicSet(public)
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions || open[r.URL.Path] {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			token := bearerToken(r.Header.Get("Authorization"))
+			if token == "" && r.Header.Get("Accept") == eventStream {
+				// EventSource cannot set headers
+				token = r.URL.Query().Get("access_token")
+			}
+			op, err := a.Authenticate(r.Context(), token)
+			acting := r.Header.Get(actingHeader)
+			if err == nil && acting != "" && !op.Sync {
+				err = ErrActingDenied
+			}
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, ErrUnauthenticated) {
+					status = http.StatusUnauthorized
+					w.Header().Set("WWW-Authenticate", `Bearer realm="guestcovider"`)
+				} else if errors.Is(err, ErrActingDenied) {
+					status = http.StatusForbidden
+				}
+				w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": err.Error(),
+				})
+				return
+			}
+
+			ctx := WithToken(WithOperator(r.Context(), op), token)
+			if acting != "" {
+				ctx = WithActing(ctx, acting)
+			}
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ContextToHTTP sends the token stored by WithToken as a bearer token and
+// the operator stored by WithActing.
+func ContextToHTTP() httptransport.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		if token := TokenFromContext(ctx); token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if acting := ActingFromContext(ctx); acting != "" {
+			r.Header.Set(actingHeader, acting)
+		}
+		return ctx
+	}
+}
@@ -0,0 +1,62 @@
+package covidcert
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// base45Decode decodes RFC 9285 base45, the text encoding of EU DCC QR codes.
+func base45Decode(s string) ([]byte, error) {
+	out := make([]byte, 0, len(s)*2/3+1)
+	for i := 0; i < len(s); i += 3 {
+		n := len(s) - i
+		if n > 3 {
+			n = 3
+		}
+		if n == 1 {
+			return nil, errors.Wrap(ErrMalformed, "base45: dangling character")
+		}
+
+		v := 0
+		for j := n - 1; j >= 0; j-- {
+			c := strings.IndexByte(base45Alphabet, s[i+j])
+			if c < 0 {
+				return nil, errors.Wrapf(ErrMalformed, "base45: invalid character %q", s[i+j])
+			}
+			v = v*45 + c
+		}
+
+		if n == 3 {
+			if v > 0xffff {
+				return nil, errors.Wrap(ErrMalformed, "base45: group out of range")
+			}
+			out = append(out, byte(v>>8), byte(v))
+		} else {
+			if v > 0xff {
+				return nil, errors.Wrap(ErrMalformed, "base45: group out of range")
+			}
+			out = append(out, byte(v))
+		}
+	}
+	return out, nil
+}
+
+// base45Encode is the inverse of base45Decode.
+func base45Encode(b []byte) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(b); i += 2 {
+		v := int(b[i])<<8 | int(b[i+1])
+		sb.WriteByte(base45Alphabet[v%45])
+		sb.WriteByte(base45Alphabet[v/45%45])
+		sb.WriteByte(base45Alphabet[v/45/45])
+	}
+	if len(b)%2 == 1 {
+		v := int(b[len(b)-1])
+		sb.WriteByte(base45Alphabet[v%45])
+		sb.WriteByte(base45Alphabet[v/45])
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package covidcert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/pkg/errors"
+)
+
+// COSE algorithms (RFC 8152) used by DCC signers.
+const (
+	coseES256 = -7
+	cosePS256 = -37
+)
+
+// coseHeaders are the COSE header parameters a DCC needs, others are ignored.
+type coseHeaders struct {
+	Alg int64  `cbor:"1,keyasint,omitempty"`
+	Kid []byte `cbor:"4,keyasint,omitempty"`
+}
+
+// sign1 is a COSE_Sign1 message, the tag 18 in front of it is optional.
+type sign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected coseHeaders
+	Payload     []byte
+	Signature   []byte
+
+	alg int64
+	kid []byte
+}
+
+func parseSign1(b []byte) (*sign1, error) {
+	var msg sign1
+	if err := cbor.Unmarshal(b, &msg); err != nil {
+		return nil, errors.Wrapf(ErrMalformed, "COSE_Sign1: %s", err)
+	}
+	if msg.Payload == nil {
+		return nil, errors.Wrap(ErrMalformed, "COSE_Sign1 without payload")
+	}
+
+	msg.alg, msg.kid = msg.Unprotected.Alg, msg.Unprotected.Kid
+	if len(msg.Protected) > 0 {
+		var protected coseHeaders
+		if err := cbor.Unmarshal(msg.Protected, &protected); err != nil {
+			return nil, errors.Wrapf(ErrMalformed, "COSE protected header: %s", err)
+		}
+		// protected headers win over unprotected ones
+		if protected.Alg != 0 {
+			msg.alg = protected.Alg
+		}
+		if protected.Kid != nil {
+			msg.kid = protected.Kid
+		}
+	}
+	return &msg, nil
+}
+
+// verify checks the signature with the signer of the message key id.
+func (m *sign1) verify(trust *TrustList, now time.Time) error {
+	s, ok := trust.lookup(m.kid)
+	if !ok {
+		return errors.New("signer is not in the trust list")
+	}
+	if !s.notBefore.IsZero() && now.Before(s.notBefore) || !s.notAfter.IsZero() && now.After(s.notAfter) {
+		return errors.New("signer certificate is not valid now")
+	}
+
+	sigStructure, err := cbor.Marshal([]interface{}{"Signature1", m.Protected, []byte{}, m.Payload})
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(sigStructure)
+
+	switch m.alg {
+	case coseES256:
+		key, ok := s.key.(*ecdsa.PublicKey)
+		if !ok || len(m.Signature) != 64 {
+			return errors.New("signature does not match the signer key")
+		}
+		r := new(big.Int).SetBytes(m.Signature[:32])
+		sig := new(big.Int).SetBytes(m.Signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, sig) {
+			return errors.New("invalid signature")
+		}
+	case cosePS256:
+		key, ok := s.key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("signature does not match the signer key")
+		}
+		if err := rsa.VerifyPSS(key, crypto.SHA256, digest[:], m.Signature, nil); err != nil {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.Errorf("unsupported signature algorithm %d", m.alg)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package covidcert verifies the COVID certificate QR codes guests show at
+// the door: EU Digital COVID Certificates ("HC1:" payloads) are checked
+// offline against a trust list of document signers. Gosuslugi links carry
+// no signed data and can only be checked online with the Gosuslugi status
+// service or a mirror of it; without one door staff check them by hand.
+package covidcert
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/translit"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnsupported is returned for QR codes that are not a known certificate.
+	ErrUnsupported = errors.New("not a covid certificate")
+	// ErrMalformed is returned for certificates that cannot be decoded.
+	ErrMalformed = errors.New("malformed covid certificate")
+	// ErrManualCheck is returned for Gosuslugi links when no status service
+	// is configured or it cannot be reached: door staff open the link on the
+	// phone of the guest and set the qr pass type by hand.
+	ErrManualCheck = errors.New("check the certificate by hand and set the qr pass type")
+)
+
+// Certificate sources.
+const (
+	SourceDCC       = "dcc"
+	SourceGosuslugi = "gosuslugi"
+)
+
+// Certificate kinds.
+const (
+	KindVaccination = "vaccination"
+	KindRecovery    = "recovery"
+	KindTest        = "test"
+)
+
+// Certificate is what a certificate says about its holder. Names are as
+// printed, Gosuslugi masks all but the first letters.
+type Certificate struct {
+	Source          string
+	ID              string
+	Kind            string
+	FamilyName      string
+	GivenName       string
+	FamilyNameLatin string
+	GivenNameLatin  string
+	BirthDate       string
+	// PassType is the covid pass type the certificate stands for, one of
+	// the userRepository Pass types.
+	PassType  string
+	TestedAt  *time.Time
+	IssuedAt  *time.Time
+	ExpiresAt *time.Time
+}
+
+// Holder returns the name of the holder as printed on the certificate.
+func (c *Certificate) Holder() string {
+	return strings.TrimSpace(c.FamilyName + " " + c.GivenName)
+}
+
+// Result is the outcome of a verification. An invalid certificate has a
+// reason and, when it could be decoded, its content.
+type Result struct {
+	Certificate *Certificate
+	Valid       bool
+	Reason      string
+}
+
+func invalid(c *Certificate, reason string) *Result {
+	return &Result{Certificate: c, Reason: reason}
+}
+
+// Verifier verifies scanned certificate QR codes.
+type Verifier struct {
+	trust     *TrustList
+	gosuslugi *Gosuslugi
+	now       func() time.Time
+}
+
+// NewVerifier returns a Verifier, DCC certificates are rejected with an
+// empty trust list and Gosuslugi links are left to a manual check when
+// gosuslugi is nil.
+func NewVerifier(trust *TrustList, gosuslugi *Gosuslugi) *Verifier {
+	return &Verifier{trust: trust, gosuslugi: gosuslugi, now: time.Now}
+}
+
+// Verify checks the decoded content of a certificate QR code. Errors are
+// returned for payloads that are not certificates or cannot be checked,
+// certificates that fail the check give an invalid Result.
+func (v *Verifier) Verify(ctx context.Context, payload string) (*Result, error) {
+	payload = strings.TrimSpace(payload)
+
+	if strings.HasPrefix(payload, dccPrefix) {
+		return verifyDCC(payload, v.trust, v.now())
+	}
+	if id, ck, ok := ParseGosuslugiURL(payload); ok {
+		if v.gosuslugi == nil {
+			return nil, errors.Wrap(ErrManualCheck, "gosuslugi checks are not configured")
+		}
+		return v.gosuslugi.Check(ctx, id, ck, v.now())
+	}
+	return nil, ErrUnsupported
+}
+
+// MatchHolder reports whether the certificate belongs to the guest: the
+// surname and, when the guest has one, the first name must match in
+// Cyrillic or transliterated. Masked names only compare first letters.
+func MatchHolder(c *Certificate, u *userRepository.User) bool {
+	if !matchName(c.FamilyName, c.FamilyNameLatin, u.Surname) {
+		return false
+	}
+	if strings.TrimSpace(u.Name) == "" {
+		return true
+	}
+	return matchName(firstWord(c.GivenName), firstWord(c.GivenNameLatin), firstWord(u.Name))
+}
+
+func matchName(name, latin, want string) bool {
+	want = foldName(want)
+	if want == "" {
+		return false
+	}
+	variants := []string{want, foldName(translit.ToLatin(want)), foldName(translit.ToICAO(want))}
+
+	for _, got := range []string{name, latin} {
+		masked := strings.Contains(got, "*")
+		got = foldName(got)
+		if got == "" {
+			continue
+		}
+		for _, v := range variants {
+			if masked && strings.HasPrefix(v, got) || got == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// foldName lower cases a name and keeps letters only, so that hyphens,
+// spaces and the "<" fillers of ICAO names do not matter.
+func foldName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == 'ё':
+			b.WriteRune('е')
+		case r >= 'a' && r <= 'z', r >= 'а' && r <= 'я':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func firstWord(s string) string {
+	s = strings.ReplaceAll(s, "<", " ")
+	if f := strings.Fields(s); len(f) > 0 {
+		return f[0]
+	}
+	return ""
+}
@@ -0,0 +1,267 @@
+package covidcert
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBase45(t *testing.T) {
+	// RFC 9285 examples
+	for plain, encoded := range map[string]string{
+		"AB":      "BB8",
+		"Hello!!": "%69 VD92EX0",
+		"ietf!":   "QED8WEX0",
+	} {
+		assert.Equal(t, encoded, base45Encode([]byte(plain)))
+		got, err := base45Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, plain, string(got))
+	}
+
+	for _, bad := range []string{"GGW", "A", "ab"} {
+		_, err := base45Decode(bad)
+		assert.Equal(t, ErrMalformed, errors.Cause(err), bad)
+	}
+}
+
+func TestParseSign1(t *testing.T) {
+	protected, err := cbor.Marshal(coseHeaders{Alg: coseES256, Kid: []byte("protected")})
+	require.NoError(t, err)
+	msg, err := cbor.Marshal(cbor.Tag{Number: 18, Content: []interface{}{
+		protected, coseHeaders{Alg: cosePS256, Kid: []byte("unprotected")}, []byte("payload"), []byte("sig"),
+	}})
+	require.NoError(t, err)
+
+	m, err := parseSign1(msg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(coseES256), m.alg)
+	assert.Equal(t, []byte("protected"), m.kid)
+	assert.Equal(t, []byte("payload"), m.Payload)
+
+	for _, bad := range []string{"", "\x82\x01", "\x84\x40\xa0\xf6\x40", "\x84\x41\x01\xa0\x40\x40"} {
+		_, err := parseSign1([]byte(bad))
+		assert.Equal(t, ErrMalformed, errors.Cause(err), "%x", bad)
+	}
+}
+
+type testSigner struct {
+	key  *ecdsa.PrivateKey
+	kid  []byte
+	pem  []byte
+	cert *x509.Certificate
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "DSC test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testSigner{
+		key:  key,
+		kid:  certificateKid(der),
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		cert: cert,
+	}
+}
+
+// sign builds an HC1 payload the way issuers do.
+func (s *testSigner) sign(t *testing.T, dcc map[string]interface{}, iat, exp time.Time) string {
+	claims := map[interface{}]interface{}{
+		int64(1):    "RU",
+		int64(4):    exp.Unix(),
+		int64(6):    iat.Unix(),
+		int64(-260): map[interface{}]interface{}{int64(1): dcc},
+	}
+	protected, err := cbor.Marshal(coseHeaders{Alg: coseES256, Kid: s.kid})
+	require.NoError(t, err)
+	payload, err := cbor.Marshal(claims)
+	require.NoError(t, err)
+	sigStructure, err := cbor.Marshal([]interface{}{"Signature1", protected, []byte{}, payload})
+	require.NoError(t, err)
+	digest := sha256.Sum256(sigStructure)
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	ss.FillBytes(sig[32:])
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	msg, err := cbor.Marshal([]interface{}{protected, map[interface{}]interface{}{}, payload, sig})
+	require.NoError(t, err)
+	zw.Write(msg)
+	zw.Close()
+	return dccPrefix + base45Encode(buf.Bytes())
+}
+
+func vaccination(dn int64) map[string]interface{} {
+	return map[string]interface{}{
+		"ver": "1.3.0",
+		"nam": map[string]interface{}{"fn": "Щукина", "gn": "Юлия", "fnt": "SHCHUKINA", "gnt": "IULIIA"},
+		"dob": "1985-03-21",
+		"v": []interface{}{map[string]interface{}{
+			"tg": "840539006", "dn": dn, "sd": int64(2), "dt": "2021-06-01", "ci": "URN:UVCI:01:RU:TEST#1",
+		}},
+	}
+}
+
+func TestVerifyDCC(t *testing.T) {
+	signer := newTestSigner(t)
+	trust, err := ParseTrustList(signer.pem)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trust.Len())
+
+	now := time.Now()
+	v := NewVerifier(trust, nil)
+	v.now = func() time.Time { return now }
+
+	res, err := v.Verify(context.Background(), signer.sign(t, vaccination(2), now.Add(-time.Hour), now.Add(24*time.Hour)))
+	require.NoError(t, err)
+	assert.True(t, res.Valid, res.Reason)
+	assert.Equal(t, "URN:UVCI:01:RU:TEST#1", res.Certificate.ID)
+	assert.Equal(t, KindVaccination, res.Certificate.Kind)
+	assert.Equal(t, userRepository.PassQR, res.Certificate.PassType)
+	assert.Equal(t, "Щукина Юлия", res.Certificate.Holder())
+
+	res, err = v.Verify(context.Background(), signer.sign(t, vaccination(1), now.Add(-time.Hour), now.Add(24*time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "vaccination course is not complete", res.Reason)
+
+	res, err = v.Verify(context.Background(), signer.sign(t, vaccination(2), now.Add(-48*time.Hour), now.Add(-time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "certificate has expired", res.Reason)
+
+	other := newTestSigner(t)
+	res, err = v.Verify(context.Background(), other.sign(t, vaccination(2), now.Add(-time.Hour), now.Add(24*time.Hour)))
+	require.NoError(t, err)
+	assert.False(t, res.Valid)
+	assert.Equal(t, "signer is not in the trust list", res.Reason)
+
+	// a signer key swapped into the trust list under a known kid
+	forged := &TrustList{}
+	forged.Add(other.kid, &signer.key.PublicKey, time.Time{}, time.Time{})
+	v.trust = forged
+	res, err = v.Verify(context.Background(), other.sign(t, vaccination(2), now.Add(-time.Hour), now.Add(24*time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, "invalid signature", res.Reason)
+
+	test := map[string]interface{}{
+		"nam": map[string]interface{}{"fn": "Ivanov", "gn": "Ivan"},
+		"t": []interface{}{map[string]interface{}{
+			"tg": "840539006", "tt": testNAAT, "sc": now.Add(-24 * time.Hour).Format(time.RFC3339), "tr": testNotDetected, "ci": "URN:UVCI:01:DE:T#2",
+		}},
+	}
+	v.trust = trust
+	res, err = v.Verify(context.Background(), signer.sign(t, test, now.Add(-time.Hour), now.Add(24*time.Hour)))
+	require.NoError(t, err)
+	assert.True(t, res.Valid, res.Reason)
+	assert.Equal(t, userRepository.PassPCR, res.Certificate.PassType)
+	assert.NotNil(t, res.Certificate.TestedAt)
+
+	_, err = v.Verify(context.Background(), "HC1:NOT BASE45!")
+	assert.Equal(t, ErrMalformed, errors.Cause(err))
+	_, err = v.Verify(context.Background(), "https://example.com/")
+	assert.Equal(t, ErrUnsupported, errors.Cause(err))
+}
+
+func TestParseGosuslugiURL(t *testing.T) {
+	id, ck, ok := ParseGosuslugiURL("https://www.gosuslugi.ru/covid-cert/verify/9160000012345678?lang=ru&ck=abc123")
+	assert.True(t, ok)
+	assert.Equal(t, "9160000012345678", id)
+	assert.Equal(t, "abc123", ck)
+
+	id, _, ok = ParseGosuslugiURL("https://gosuslugi.ru/covid-cert/status/5f1e1b1a-0000-4000-8000-000000000001?lang=ru")
+	assert.True(t, ok)
+	assert.Equal(t, "5f1e1b1a-0000-4000-8000-000000000001", id)
+
+	for _, bad := range []string{
+		"http://www.gosuslugi.ru/covid-cert/verify/1",
+		"https://gosuslugi.ru.evil.com/covid-cert/verify/1",
+		"https://www.gosuslugi.ru/covid-cert/verify/",
+		"https://www.gosuslugi.ru/help",
+	} {
+		_, _, ok := ParseGosuslugiURL(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+func TestGosuslugiCheck(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/check/9160000012345678":
+			assert.Equal(t, "abc", r.URL.Query().Get("ck"))
+			w.Write([]byte(`{"items":[{"type":"VACCINE_CERT","unrz":"9160000012345678","status":"1","expiredAt":"01.12.2099",
+				"attrs":[{"type":"fio","value":"Щ*** Ю*** С***","envalue":"S*** I*** S***"},{"type":"birthDate","value":"21.03.1985"}]}]}`))
+		case "/check/9160000000000001":
+			w.Write([]byte(`{"items":[{"type":"VACCINE_CERT","status":"1","expiredAt":"01.12.2020"}]}`))
+		case "/check/9160000000000002":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer stub.Close()
+
+	v := NewVerifier(nil, NewGosuslugi(stub.URL+"/check/", time.Second))
+
+	res, err := v.Verify(context.Background(), "https://www.gosuslugi.ru/covid-cert/verify/9160000012345678?lang=ru&ck=abc")
+	require.NoError(t, err)
+	assert.True(t, res.Valid, res.Reason)
+	assert.Equal(t, SourceGosuslugi, res.Certificate.Source)
+	assert.Equal(t, "Щ***", res.Certificate.FamilyName)
+	assert.True(t, MatchHolder(res.Certificate, &userRepository.User{Surname: "Щукина", Name: "Юлия"}))
+	assert.False(t, MatchHolder(res.Certificate, &userRepository.User{Surname: "Иванова", Name: "Юлия"}))
+
+	res, err = v.Verify(context.Background(), "https://www.gosuslugi.ru/covid-cert/verify/9160000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "certificate has expired", res.Reason)
+
+	res, err = v.Verify(context.Background(), "https://www.gosuslugi.ru/covid-cert/verify/1")
+	require.NoError(t, err)
+	assert.Equal(t, "certificate is not found", res.Reason)
+
+	// the status service is down or not configured, the door checks by hand
+	_, err = v.Verify(context.Background(), "https://www.gosuslugi.ru/covid-cert/verify/9160000000000002")
+	assert.Equal(t, ErrManualCheck, errors.Cause(err))
+	_, err = NewVerifier(nil, nil).Verify(context.Background(), "https://www.gosuslugi.ru/covid-cert/verify/1")
+	assert.Equal(t, ErrManualCheck, errors.Cause(err))
+}
+
+func TestMatchHolder(t *testing.T) {
+	cert := &Certificate{FamilyName: "ЩУКИНА", GivenName: "ЮЛИЯ", FamilyNameLatin: "SHCHUKINA", GivenNameLatin: "IULIIA<SERGEEVNA"}
+	assert.True(t, MatchHolder(cert, &userRepository.User{Surname: "Щукина", Name: "Юлия"}))
+	assert.True(t, MatchHolder(cert, &userRepository.User{Surname: "Shchukina"}))
+	assert.False(t, MatchHolder(cert, &userRepository.User{Surname: "Щукина", Name: "Мария"}))
+
+	latin := &Certificate{FamilyName: "Müller", FamilyNameLatin: "MUELLER", GivenName: "Anna", GivenNameLatin: "ANNA"}
+	assert.True(t, MatchHolder(latin, &userRepository.User{Surname: "Mueller", Name: "Anna"}))
+	assert.True(t, MatchHolder(&Certificate{FamilyName: "Ivanov", GivenName: "Ivan"}, &userRepository.User{Surname: "Иванов", Name: "Иван"}))
+	assert.True(t, MatchHolder(&Certificate{FamilyNameLatin: "PODIEIACHEV", GivenNameLatin: "ANDREI"}, &userRepository.User{Surname: "Подъячев", Name: "Андрей"}))
+}
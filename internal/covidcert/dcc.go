@@ -0,0 +1,210 @@
+package covidcert
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+const dccPrefix = "HC1:"
+
+// maxDCCSize caps the inflated certificate, real ones are well below 4 KiB.
+const maxDCCSize = 64 << 10
+
+// EU DCC value set codes.
+const (
+	testNAAT        = "LP6464-4"
+	testRAT         = "LP217198-3"
+	testNotDetected = "260415000"
+)
+
+// clockSkew tolerates signers with clocks slightly ahead.
+const clockSkew = 5 * time.Minute
+
+// verifyDCC decodes an EU Digital COVID Certificate and checks its
+// signature, expiry and content.
+func verifyDCC(payload string, trust *TrustList, now time.Time) (*Result, error) {
+	raw, err := base45Decode(payload[len(dccPrefix):])
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > 0 && raw[0] == 0x78 {
+		zr, err := zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, errors.Wrap(ErrMalformed, err.Error())
+		}
+		raw, err = ioutil.ReadAll(io.LimitReader(zr, maxDCCSize))
+		if err != nil {
+			return nil, errors.Wrap(ErrMalformed, err.Error())
+		}
+	}
+
+	msg, err := parseSign1(raw)
+	if err != nil {
+		return nil, err
+	}
+	cert, entry, err := parseDCCClaims(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := msg.verify(trust, now); err != nil {
+		return invalid(cert, err.Error()), nil
+	}
+	if cert.IssuedAt != nil && cert.IssuedAt.After(now.Add(clockSkew)) {
+		return invalid(cert, "certificate is issued in the future"), nil
+	}
+	if cert.ExpiresAt != nil && cert.ExpiresAt.Before(now) {
+		return invalid(cert, "certificate has expired"), nil
+	}
+	if reason := entry.check(cert, now); reason != "" {
+		return invalid(cert, reason), nil
+	}
+	return &Result{Certificate: cert, Valid: true}, nil
+}
+
+// cwtClaims are the CWT claims of a certificate, the EU DCC is claim -260.
+type cwtClaims struct {
+	Expires  int64 `cbor:"4,keyasint,omitempty"`
+	IssuedAt int64 `cbor:"6,keyasint,omitempty"`
+	HCert    struct {
+		DCC *dccPayload `cbor:"1,keyasint,omitempty"`
+	} `cbor:"-260,keyasint,omitempty"`
+}
+
+type dccPayload struct {
+	Nam struct {
+		FN  string `cbor:"fn"`
+		GN  string `cbor:"gn"`
+		FNT string `cbor:"fnt"`
+		GNT string `cbor:"gnt"`
+	} `cbor:"nam"`
+	DOB string     `cbor:"dob"`
+	V   []dccEntry `cbor:"v"`
+	T   []dccEntry `cbor:"t"`
+	R   []dccEntry `cbor:"r"`
+}
+
+// dccEntry is the single vaccination, test or recovery entry of a
+// certificate, holding the fields of all three kinds.
+type dccEntry struct {
+	CI string `cbor:"ci"`
+	// vaccination: dose number and total doses
+	DN int64 `cbor:"dn"`
+	SD int64 `cbor:"sd"`
+	// test: type, sample collection time and result
+	TT string `cbor:"tt"`
+	SC string `cbor:"sc"`
+	TR string `cbor:"tr"`
+	// recovery: valid from and until
+	DF string `cbor:"df"`
+	DU string `cbor:"du"`
+}
+
+func parseDCCClaims(payload []byte) (*Certificate, *dccEntry, error) {
+	var claims cwtClaims
+	if err := cbor.Unmarshal(payload, &claims); err != nil {
+		return nil, nil, errors.Wrapf(ErrMalformed, "CWT claims: %s", err)
+	}
+	dcc := claims.HCert.DCC
+	if dcc == nil {
+		return nil, nil, errors.Wrap(ErrMalformed, "no EU DCC in the certificate")
+	}
+
+	cert := &Certificate{Source: SourceDCC}
+	if claims.Expires != 0 {
+		t := time.Unix(claims.Expires, 0)
+		cert.ExpiresAt = &t
+	}
+	if claims.IssuedAt != 0 {
+		t := time.Unix(claims.IssuedAt, 0)
+		cert.IssuedAt = &t
+	}
+
+	cert.FamilyName = dcc.Nam.FN
+	cert.GivenName = dcc.Nam.GN
+	cert.FamilyNameLatin = dcc.Nam.FNT
+	cert.GivenNameLatin = dcc.Nam.GNT
+	cert.BirthDate = dcc.DOB
+
+	var entry *dccEntry
+	for _, kind := range []struct {
+		items []dccEntry
+		kind  string
+	}{
+		{dcc.V, KindVaccination},
+		{dcc.T, KindTest},
+		{dcc.R, KindRecovery},
+	} {
+		if len(kind.items) == 0 {
+			continue
+		}
+		if entry != nil || len(kind.items) > 1 {
+			return nil, nil, errors.Wrap(ErrMalformed, "certificate must hold exactly one entry")
+		}
+		entry = &kind.items[0]
+		cert.Kind = kind.kind
+	}
+	if entry == nil {
+		return nil, nil, errors.Wrap(ErrMalformed, "certificate holds no entry")
+	}
+	cert.ID = entry.CI
+
+	switch cert.Kind {
+	case KindVaccination, KindRecovery:
+		cert.PassType = userRepository.PassQR
+	case KindTest:
+		switch entry.TT {
+		case testNAAT:
+			cert.PassType = userRepository.PassPCR
+		case testRAT:
+			cert.PassType = userRepository.PassExpress
+		}
+		if sc, err := time.Parse(time.RFC3339, entry.SC); err == nil {
+			cert.TestedAt = &sc
+		}
+	}
+	if du, err := time.Parse("2006-01-02", entry.DU); err == nil && cert.Kind == KindRecovery {
+		// valid through the last day
+		du = du.AddDate(0, 0, 1)
+		if cert.ExpiresAt == nil || du.Before(*cert.ExpiresAt) {
+			cert.ExpiresAt = &du
+		}
+	}
+
+	return cert, entry, nil
+}
+
+// check returns why the entry does not make a valid pass, empty when it does.
+func (e *dccEntry) check(cert *Certificate, now time.Time) string {
+	switch cert.Kind {
+	case KindVaccination:
+		if e.DN < e.SD || e.DN == 0 {
+			return "vaccination course is not complete"
+		}
+	case KindTest:
+		if e.TR != testNotDetected {
+			return "test result is not negative"
+		}
+		if cert.PassType == "" {
+			return "unknown test type"
+		}
+		if cert.TestedAt == nil {
+			return "test has no sample collection time"
+		}
+		if cert.TestedAt.After(now.Add(clockSkew)) {
+			return "test is taken in the future"
+		}
+	case KindRecovery:
+		if df, err := time.Parse("2006-01-02", e.DF); err == nil && now.Before(df) {
+			return "recovery certificate is not valid yet"
+		}
+	}
+	return ""
+}
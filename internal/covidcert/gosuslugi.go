@@ -0,0 +1,172 @@
+package covidcert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+// DefaultGosuslugiURL is the public Gosuslugi certificate status service,
+// the certificate id is appended to it.
+const DefaultGosuslugiURL = "https://www.gosuslugi.ru/api/covid-cert/v3/cert/check/"
+
+// gosuslugiHosts are the hosts certificate links may point to, any other
+// link is not a certificate.
+var gosuslugiHosts = map[string]bool{
+	"gosuslugi.ru":     true,
+	"www.gosuslugi.ru": true,
+}
+
+// gosuslugiPaths are the path prefixes of certificate links.
+var gosuslugiPaths = []string{"/covid-cert/verify/", "/covid-cert/status/", "/vaccine/cert/verify/"}
+
+// Gosuslugi checks certificate links with the Gosuslugi status service.
+// Unlike DCC the links carry no signed data, the status service is the
+// only source of truth, so every check needs network access to it.
+type Gosuslugi struct {
+	url    string
+	client *http.Client
+}
+
+// NewGosuslugi returns a checker querying the status service at url.
+func NewGosuslugi(url string, timeout time.Duration) *Gosuslugi {
+	return &Gosuslugi{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+// ParseGosuslugiURL returns the certificate id and check key of a
+// Gosuslugi certificate link.
+func ParseGosuslugiURL(s string) (id, ck string, ok bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "https" || !gosuslugiHosts[strings.ToLower(u.Host)] {
+		return "", "", false
+	}
+	for _, prefix := range gosuslugiPaths {
+		if strings.HasPrefix(u.Path, prefix) {
+			id = strings.Trim(strings.TrimPrefix(u.Path, prefix), "/")
+			if id == "" || strings.Contains(id, "/") {
+				return "", "", false
+			}
+			return id, u.Query().Get("ck"), true
+		}
+	}
+	return "", "", false
+}
+
+type gosuslugiResponse struct {
+	Items []gosuslugiItem `json:"items"`
+}
+
+type gosuslugiItem struct {
+	Type      string `json:"type"`
+	Unrz      string `json:"unrz"`
+	Status    string `json:"status"`
+	ExpiredAt string `json:"expiredAt"`
+	Attrs     []struct {
+		Type    string `json:"type"`
+		Value   string `json:"value"`
+		EnValue string `json:"envalue"`
+	} `json:"attrs"`
+}
+
+// gosuslugiKinds maps certificate types of the status service onto kinds.
+var gosuslugiKinds = map[string]string{
+	"VACCINE_CERT":   KindVaccination,
+	"TEMPORARY_CERT": KindVaccination,
+	"ILLNESS_FACT":   KindRecovery,
+	"COVID_TEST":     KindTest,
+}
+
+// Check asks the status service about a certificate, ErrManualCheck is
+// returned when the service cannot answer.
+func (g *Gosuslugi) Check(ctx context.Context, id, ck string, now time.Time) (*Result, error) {
+	query := url.Values{"lang": {"ru"}}
+	if ck != "" {
+		query.Set("ck", ck)
+	}
+	req, err := http.NewRequest(http.MethodGet, g.url+url.PathEscape(id)+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "gosuslugi")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(ErrManualCheck, "gosuslugi: %s", err)
+	}
+	defer resp.Body.Close()
+
+	cert := &Certificate{Source: SourceGosuslugi, ID: id}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return invalid(cert, "certificate is not found"), nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.Wrapf(ErrManualCheck, "gosuslugi: unexpected status %s", resp.Status)
+	}
+
+	var body gosuslugiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "gosuslugi: decode response")
+	}
+	if len(body.Items) == 0 {
+		return invalid(cert, "certificate is not found"), nil
+	}
+
+	item := body.Items[0]
+	if item.Unrz != "" {
+		cert.ID = item.Unrz
+	}
+	cert.Kind = gosuslugiKinds[item.Type]
+	switch cert.Kind {
+	case KindVaccination, KindRecovery:
+		cert.PassType = userRepository.PassQR
+	case KindTest:
+		cert.PassType = userRepository.PassPCR
+	}
+	for _, attr := range item.Attrs {
+		switch attr.Type {
+		case "fio":
+			cert.FamilyName, cert.GivenName = splitFIO(attr.Value)
+			cert.FamilyNameLatin, cert.GivenNameLatin = splitFIO(attr.EnValue)
+		case "enFio":
+			cert.FamilyNameLatin, cert.GivenNameLatin = splitFIO(attr.Value)
+		case "birthDate":
+			cert.BirthDate = attr.Value
+		}
+	}
+	if expiredAt, err := time.ParseInLocation("02.01.2006", item.ExpiredAt, moscow); err == nil {
+		// valid through the last day
+		expiredAt = expiredAt.AddDate(0, 0, 1)
+		cert.ExpiresAt = &expiredAt
+	}
+
+	switch {
+	case item.Status != "1" && !strings.EqualFold(item.Status, "OK"):
+		return invalid(cert, "certificate is not valid"), nil
+	case cert.Kind == "":
+		return invalid(cert, "unknown certificate type "+item.Type), nil
+	case cert.ExpiresAt != nil && cert.ExpiresAt.Before(now):
+		return invalid(cert, "certificate has expired"), nil
+	}
+	return &Result{Certificate: cert, Valid: true}, nil
+}
+
+// moscow is the zone Gosuslugi dates are in.
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+// splitFIO splits "Фамилия Имя Отчество" into the surname and first name.
+func splitFIO(s string) (family, given string) {
+	f := strings.Fields(s)
+	if len(f) > 0 {
+		family = f[0]
+	}
+	if len(f) > 1 {
+		given = f[1]
+	}
+	return family, given
+}
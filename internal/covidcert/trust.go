@@ -0,0 +1,98 @@
+package covidcert
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TrustList holds the document signer keys DCC signatures are checked
+// against, looked up by the COSE key id.
+type TrustList struct {
+	signers map[string]signer
+}
+
+type signer struct {
+	key       crypto.PublicKey
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// LoadTrustList reads a PEM bundle of document signer certificates. The
+// key id of a certificate is the first 8 bytes of the SHA-256 of its DER
+// encoding. Bare public keys are accepted too with the key id given in a
+// base64 "Kid" PEM header.
+func LoadTrustList(path string) (*TrustList, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read trust list")
+	}
+	return ParseTrustList(data)
+}
+
+// ParseTrustList parses a PEM bundle, see LoadTrustList.
+func ParseTrustList(data []byte) (*TrustList, error) {
+	t := &TrustList{signers: make(map[string]signer)}
+	for n := 1; ; n++ {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "trust list entry %d", n)
+			}
+			t.Add(certificateKid(block.Bytes), cert.PublicKey, cert.NotBefore, cert.NotAfter)
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "trust list entry %d", n)
+			}
+			kid, err := base64.StdEncoding.DecodeString(block.Headers["Kid"])
+			if err != nil || len(kid) == 0 {
+				return nil, errors.Errorf("trust list entry %d: public key without a Kid header", n)
+			}
+			t.Add(kid, key, time.Time{}, time.Time{})
+		}
+	}
+	return t, nil
+}
+
+// Add trusts a signer key, zero times leave its validity open.
+func (t *TrustList) Add(kid []byte, key crypto.PublicKey, notBefore, notAfter time.Time) {
+	if t.signers == nil {
+		t.signers = make(map[string]signer)
+	}
+	t.signers[string(kid)] = signer{key: key, notBefore: notBefore, notAfter: notAfter}
+}
+
+// Len returns the number of trusted signers.
+func (t *TrustList) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.signers)
+}
+
+func (t *TrustList) lookup(kid []byte) (signer, bool) {
+	if t == nil {
+		return signer{}, false
+	}
+	s, ok := t.signers[string(kid)]
+	return s, ok
+}
+
+func certificateKid(der []byte) []byte {
+	sum := sha256.Sum256(der)
+	return sum[:8]
+}
@@ -21,10 +21,28 @@ type Config struct {
 	Password     string
 	DatabaseName string `mapstructure:"database_name"`
 	Secure       string
+	// Replicas is a comma separated host[:port] list of streaming replicas
+	Replicas string
+	// ReplicaMaxLagSec is how far behind the master a replica may be to
+	// take reads
+	ReplicaMaxLagSec int `mapstructure:"replica_max_lag_sec"`
+	// ReplicaCheckSec is how often replicas are checked
+	ReplicaCheckSec int `mapstructure:"replica_check_sec"`
+	// pool limits, zero keeps the database/sql default
+	MaxOpenConns       int `mapstructure:"max_open_conns"`
+	MaxIdleConns       int `mapstructure:"max_idle_conns"`
+	ConnMaxLifetimeSec int `mapstructure:"conn_max_lifetime_sec"`
+	ConnMaxIdleTimeSec int `mapstructure:"conn_max_idle_time_sec"`
+	// ConnectTimeoutSec is how long the start waits for the master to come up
+	ConnectTimeoutSec int `mapstructure:"connect_timeout_sec"`
 }
 
 type Connection struct {
-	Master  *gorm.DB
+	Master   *gorm.DB
+	Replicas []*Replica
+
+	// next is the turn of replicas
+	next uint32
 }
 
 func Connect(ctx context.Context, master Config) (*Connection, error) {
@@ -32,15 +50,21 @@ func Connect(ctx context.Context, master Config) (*Connection, error) {
 	var err error
 
 	// connect to master
-	res.Master, err = ConnectPool(ctx, master)
+	res.Master, err = connectWithRetry(ctx, master)
 	if err != nil {
 		return nil, errors.Wrap(err, "Master DB connect")
 	}
 
+	res.Replicas, err = connectReplicas(ctx, master)
+	if err != nil {
+		return nil, errors.Wrap(err, "Replica DB connect")
+	}
+
 	return &res, nil
 }
 
-func ConnectPool(ctx context.Context, db Config) (conn *gorm.DB, err error) {
+// DSN returns the connection URL of the database.
+func (db Config) DSN() string {
 	dsn := url.URL{
 		User:     url.UserPassword(db.User, db.Password),
 		Scheme:   "postgres",
@@ -48,6 +72,15 @@ func ConnectPool(ctx context.Context, db Config) (conn *gorm.DB, err error) {
 		Path:     db.DatabaseName,
 		RawQuery: (&url.Values{"sslmode": []string{db.Secure}}).Encode(),
 	}
+	return dsn.String()
+}
+
+func ConnectPool(ctx context.Context, db Config) (conn *gorm.DB, err error) {
+	return connectPool(ctx, db, false)
+}
+
+// connectPool opens a pool, lazy ones do not connect until used.
+func connectPool(ctx context.Context, db Config, lazy bool) (conn *gorm.DB, err error) {
 
 	dbLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags), // io writer
@@ -59,16 +92,74 @@ func ConnectPool(ctx context.Context, db Config) (conn *gorm.DB, err error) {
 		},
 	)
 
-	conn, err = gorm.Open(postgres.Open(dsn.String()), &gorm.Config{
-		Logger: dbLogger,
+	conn, err = gorm.Open(postgres.Open(db.DSN()), &gorm.Config{
+		Logger:               dbLogger,
+		DisableAutomaticPing: lazy,
 	})
+	if err != nil {
+		// the pool stays open when the first ping fails
+		if conn != nil {
+			if pool, perr := conn.DB(); perr == nil {
+				pool.Close()
+			}
+		}
+		return nil, err
+	}
 
-	return conn, err
+	pool, err := conn.DB()
+	if err != nil {
+		return nil, err
+	}
+	if db.MaxOpenConns > 0 {
+		pool.SetMaxOpenConns(db.MaxOpenConns)
+	}
+	if db.MaxIdleConns > 0 {
+		pool.SetMaxIdleConns(db.MaxIdleConns)
+	}
+	if db.ConnMaxLifetimeSec > 0 {
+		pool.SetConnMaxLifetime(time.Duration(db.ConnMaxLifetimeSec) * time.Second)
+	}
+	if db.ConnMaxIdleTimeSec > 0 {
+		pool.SetConnMaxIdleTime(time.Duration(db.ConnMaxIdleTimeSec) * time.Second)
+	}
+
+	return conn, nil
+}
+
+func (c *Connection) Close() error {
+	pools := []*gorm.DB{c.Master}
+	for _, r := range c.Replicas {
+		pools = append(pools, r.DB)
+	}
+
+	var closeErr error
+	for _, pool := range pools {
+		db, err := pool.DB()
+		if err == nil {
+			err = db.Close()
+		}
+		if err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	return closeErr
 }
 
-func (c *Connection) Close() {
-	db, _ :=c.Master.DB()
-	db.Close()
+// Ping checks that the master accepts connections and answers queries.
+func (c *Connection) Ping(ctx context.Context) error {
+	db, err := c.Master.DB()
+	if err != nil {
+		return err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return errors.Wrap(err, "ping")
+	}
+
+	var one int
+	if err := c.Master.WithContext(ctx).Raw("SELECT 1").Scan(&one).Error; err != nil {
+		return errors.Wrap(err, "query")
+	}
+	return nil
 }
 
 func GetMasterConn(ctx context.Context, conn *Connection) (*gorm.DB, error) {
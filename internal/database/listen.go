@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+)
+
+// listenRetry is the pause before a lost LISTEN connection is reopened.
+const listenRetry = 3 * time.Second
+
+// Listener receives Postgres notifications of a channel on a connection of
+// its own, pooled connections are not kept listening.
+type Listener struct {
+	cfg     Config
+	channel string
+}
+
+func NewListener(cfg Config, channel string) *Listener {
+	return &Listener{cfg: cfg, channel: channel}
+}
+
+// Run calls notify with the payload of every notification until ctx is
+// done. A lost connection is reported to onError and reopened, notify is
+// then called with an empty payload since notifications may have been
+// missed meanwhile.
+func (l *Listener) Run(ctx context.Context, notify func(payload string), onError func(error)) {
+	for {
+		err := l.listen(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		onError(err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetry):
+		}
+	}
+}
+
+// Notify sends payload on channel through the master, the listeners of
+// every replica receive it.
+func (c *Connection) Notify(ctx context.Context, channel, payload string) error {
+	err := c.Master.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, payload).Error
+	return errors.Wrapf(err, "notify %s", channel)
+}
+
+func (l *Listener) listen(ctx context.Context, notify func(payload string)) error {
+	cfg, err := pgconn.ParseConfig(l.cfg.DSN())
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+	cfg.OnNotification = func(_ *pgconn.PgConn, n *pgconn.Notification) {
+		notify(n.Payload)
+	}
+
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+quoteIdentifier(l.channel)).ReadAll(); err != nil {
+		return errors.Wrapf(err, "listen %s", l.channel)
+	}
+	notify("")
+
+	for {
+		if err := conn.WaitForNotification(ctx); err != nil {
+			return errors.Wrapf(err, "listen %s", l.channel)
+		}
+	}
+}
+
+func quoteIdentifier(s string) string {
+	quoted := []byte{'"'}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			quoted = append(quoted, '"')
+		}
+		quoted = append(quoted, s[i])
+	}
+	return string(append(quoted, '"'))
+}
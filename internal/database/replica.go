@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// replicaLagQuery returns how far behind the master a replica is, zero
+// when it replayed everything it received and NULL when it replayed
+// nothing yet. A server out of recovery has no lag.
+const replicaLagQuery = `SELECT CASE
+	WHEN NOT pg_is_in_recovery() THEN 0
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE extract(epoch FROM now() - pg_last_xact_replay_timestamp())
+END`
+
+var (
+	replicaLagOnce sync.Once
+	replicaLag     metrics.Gauge
+)
+
+func replicaLagGauge() metrics.Gauge {
+	replicaLagOnce.Do(func() {
+		replicaLag = kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: "guest_covider",
+			Name:      "postgres_replica_lag_seconds",
+			Help:      "How far a replica is behind the master, -1 while it is down.",
+		}, []string{"replica"})
+	})
+	return replicaLag
+}
+
+// Replica is a streaming replica reads may go to while it is up and close
+// enough to the master.
+type Replica struct {
+	Name string
+	DB   *gorm.DB
+
+	// usable is 1 when the last check found the replica up and within the
+	// allowed lag
+	usable int32
+}
+
+// Usable tells whether reads may go to the replica.
+func (r *Replica) Usable() bool {
+	return atomic.LoadInt32(&r.usable) == 1
+}
+
+func (r *Replica) setUsable(ok bool) {
+	var v int32
+	if ok {
+		v = 1
+	}
+	atomic.StoreInt32(&r.usable, v)
+}
+
+// replicaConfigs returns a config per host[:port] of db.Replicas, replicas
+// share the credentials and database name of the master.
+func replicaConfigs(db Config) ([]Config, error) {
+	var configs []Config
+	for _, addr := range strings.Split(db.Replicas, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		cfg := db
+		cfg.Host = addr
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if cfg.Port, err = strconv.Atoi(port); err != nil {
+				return nil, errors.Errorf("bad replica port in %q", addr)
+			}
+			cfg.Host = host
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// connectReplicas opens a pool per replica without waiting for them, a
+// replica is unused until a check finds it up.
+func connectReplicas(ctx context.Context, db Config) ([]*Replica, error) {
+	configs, err := replicaConfigs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var replicas []*Replica
+	for _, cfg := range configs {
+		conn, err := connectPool(ctx, cfg, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "replica %s:%d", cfg.Host, cfg.Port)
+		}
+		replicas = append(replicas, &Replica{Name: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), DB: conn})
+	}
+	return replicas, nil
+}
+
+// GetReplicaConn returns a replica for reads that may lag behind the
+// master by up to the allowed lag, replicas take turns. The master is
+// returned when no replica is usable.
+func GetReplicaConn(ctx context.Context, conn *Connection) (*gorm.DB, error) {
+	if r := conn.nextReplica(); r != nil {
+		return r.DB.WithContext(ctx), nil
+	}
+	return GetMasterConn(ctx, conn)
+}
+
+func (c *Connection) nextReplica() *Replica {
+	var usable []*Replica
+	for _, r := range c.Replicas {
+		if r.Usable() {
+			usable = append(usable, r)
+		}
+	}
+	if len(usable) == 0 {
+		return nil
+	}
+	return usable[atomic.AddUint32(&c.next, 1)%uint32(len(usable))]
+}
+
+// MonitorReplicas checks the replicas every interval until ctx is done.
+// Replicas that are down or lag more than maxLag are taken out of turn,
+// check errors are passed to onError.
+func (c *Connection) MonitorReplicas(ctx context.Context, interval, maxLag time.Duration, onError func(error)) {
+	if len(c.Replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, r := range c.Replicas {
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			err := checkReplica(checkCtx, r, maxLag)
+			cancel()
+			if err != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkReplica(ctx context.Context, r *Replica, maxLag time.Duration) error {
+	var lag sql.NullFloat64
+	err := r.DB.WithContext(ctx).Raw(replicaLagQuery).Row().Scan(&lag)
+	if err != nil {
+		r.setUsable(false)
+		replicaLagGauge().With("replica", r.Name).Set(-1)
+		return errors.Wrapf(err, "check replica %s", r.Name)
+	}
+
+	seconds := math.Inf(1)
+	if lag.Valid {
+		seconds = lag.Float64
+	}
+	replicaLagGauge().With("replica", r.Name).Set(seconds)
+	r.setUsable(seconds <= maxLag.Seconds())
+	return nil
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReplicaConfigs(t *testing.T) {
+	configs, err := replicaConfigs(Config{Host: "master", Port: 5432, User: "guestcovider", Replicas: "replica1:5433, replica2,"})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "replica1", configs[0].Host)
+	assert.Equal(t, 5433, configs[0].Port)
+	assert.Equal(t, "guestcovider", configs[0].User)
+	assert.Equal(t, "replica2", configs[1].Host)
+	assert.Equal(t, 5432, configs[1].Port)
+
+	_, err = replicaConfigs(Config{Replicas: "replica1:x"})
+	assert.Error(t, err)
+}
+
+func TestNextReplica(t *testing.T) {
+	a, b, c := &Replica{DB: &gorm.DB{}}, &Replica{DB: &gorm.DB{}}, &Replica{DB: &gorm.DB{}}
+	conn := &Connection{Replicas: []*Replica{a, b, c}}
+	assert.Nil(t, conn.nextReplica())
+
+	a.setUsable(true)
+	c.setUsable(true)
+	seen := map[*Replica]int{}
+	for i := 0; i < 6; i++ {
+		seen[conn.nextReplica()]++
+	}
+	assert.Equal(t, map[*Replica]int{a: 3, c: 3}, seen)
+
+	a.setUsable(false)
+	c.setUsable(false)
+	assert.Nil(t, conn.nextReplica())
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"net"
+	"time"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/jackc/pgconn"
+	"github.com/nakiner/guestcovider/tools/logging"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	// retryAttempts is how many times a query is run before its transient
+	// error is returned.
+	retryAttempts = 3
+	// retryBackoff is the pause before the second attempt, it doubles for
+	// each next one.
+	retryBackoff = 100 * time.Millisecond
+	// connectBackoffMax caps the pause between startup connect attempts.
+	connectBackoffMax = 5 * time.Second
+)
+
+// IsTransient tells whether err may go away on its own: the server is
+// unreachable, starting up or shutting down, the connection was lost, or
+// the transaction lost a serialization race or a deadlock.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", // serialization_failure
+			"40P01", // deadlock_detected
+			"53300", // too_many_connections
+			"57P01", // admin_shutdown
+			"57P02", // crash_shutdown
+			"57P03": // cannot_connect_now
+			return true
+		}
+		// connection_exception
+		return len(pgErr.Code) == 5 && pgErr.Code[:2] == "08"
+	}
+
+	var netErr net.Error
+	return pgconn.SafeToRetry(err) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
+
+// safeToRetry tells whether err is transient and leaves nothing behind:
+// the statement never reached the server or its transaction was rolled
+// back. A connection lost in the middle of a commit is not, the commit may
+// have gone through.
+func safeToRetry(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01", "53300", "57P03",
+			"08001", // sqlclient_unable_to_establish_sqlconnection
+			"08004": // sqlserver_rejected_establishment_of_sqlconnection
+			return true
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	return pgconn.SafeToRetry(err) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// Retry runs a read until it succeeds or fails with an error that is not
+// transient, at most retryAttempts times. Reads change nothing, so they
+// are retried whatever the transient error.
+func Retry(ctx context.Context, read func() error) error {
+	return retry(ctx, IsTransient, read)
+}
+
+// Transaction runs fn in a transaction of conn and runs it again when the
+// transaction failed with an error that left nothing behind. fn runs again
+// from the start, so it must not build on what a failed run set.
+func Transaction(ctx context.Context, conn *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return retry(ctx, safeToRetry, func() error {
+		return conn.Transaction(fn)
+	})
+}
+
+func retry(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == retryAttempts || !retryable(err) {
+			return err
+		}
+
+		level.Warn(logging.FromContext(ctx)).Log("msg", "retrying postgres query", "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// connectWithRetry connects to db, waiting for it to come up for at most
+// ConnectTimeoutSec. Errors that will not go away, like a wrong password,
+// are returned at once.
+func connectWithRetry(ctx context.Context, db Config) (*gorm.DB, error) {
+	deadline := time.Now().Add(time.Duration(db.ConnectTimeoutSec) * time.Second)
+	backoff := retryBackoff
+	for {
+		conn, err := ConnectPool(ctx, db)
+		if err == nil {
+			return conn, nil
+		}
+		if !IsTransient(err) || time.Now().Add(backoff).After(deadline) {
+			return nil, err
+		}
+
+		level.Warn(logging.FromContext(ctx)).Log("msg", "postgres is not up yet", "host", db.Host, "retry_in", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > connectBackoffMax {
+			backoff = connectBackoffMax
+		}
+	}
+}
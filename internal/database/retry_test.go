@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	for _, c := range []struct {
+		err       error
+		transient bool
+		safe      bool
+	}{
+		{nil, false, false},
+		{errors.New("boom"), false, false},
+		{&pgconn.PgError{Code: "23505"}, false, false},
+		{&pgconn.PgError{Code: "40001"}, true, true},
+		{errors.Wrap(&pgconn.PgError{Code: "40P01"}, "update"), true, true},
+		{&pgconn.PgError{Code: "57P01"}, true, false},
+		{&pgconn.PgError{Code: "08006"}, true, false},
+		{&pgconn.PgError{Code: "08001"}, true, true},
+		{driver.ErrBadConn, true, true},
+		{io.ErrUnexpectedEOF, true, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true, true},
+		{&net.OpError{Op: "read", Err: errors.New("connection reset")}, true, false},
+	} {
+		assert.Equal(t, c.transient, IsTransient(c.err), "%v", c.err)
+		if c.err != nil {
+			assert.Equal(t, c.safe, safeToRetry(c.err), "%v", c.err)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	err := Retry(ctx, func() error {
+		if calls++; calls < 2 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = Retry(ctx, func() error {
+		calls++
+		return driver.ErrBadConn
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, retryAttempts, calls)
+
+	calls = 0
+	err = Retry(ctx, func() error {
+		calls++
+		return &pgconn.PgError{Code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	calls = 0
+	err = Retry(cancelled, func() error {
+		calls++
+		return driver.ErrBadConn
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// poolCollector exports database/sql statistics of the master and replica
+// pools, labelled by pool.
+type poolCollector struct {
+	conn *Connection
+
+	maxOpen           *stdprometheus.Desc
+	open              *stdprometheus.Desc
+	inUse             *stdprometheus.Desc
+	idle              *stdprometheus.Desc
+	waitCount         *stdprometheus.Desc
+	waitDuration      *stdprometheus.Desc
+	maxIdleClosed     *stdprometheus.Desc
+	maxIdleTimeClosed *stdprometheus.Desc
+	maxLifetimeClosed *stdprometheus.Desc
+}
+
+// RegisterMetrics exports the pool statistics to Prometheus.
+func (c *Connection) RegisterMetrics() error {
+	return stdprometheus.Register(newPoolCollector(c))
+}
+
+func newPoolCollector(conn *Connection) *poolCollector {
+	desc := func(name, help string) *stdprometheus.Desc {
+		return stdprometheus.NewDesc("guest_covider_postgres_pool_"+name, help, []string{"pool"}, nil)
+	}
+	return &poolCollector{
+		conn:              conn,
+		maxOpen:           desc("max_open_connections", "Maximum number of open connections."),
+		open:              desc("open_connections", "Number of open connections, in use and idle."),
+		inUse:             desc("in_use_connections", "Number of connections in use."),
+		idle:              desc("idle_connections", "Number of idle connections."),
+		waitCount:         desc("wait_count_total", "Number of times a query waited for a connection."),
+		waitDuration:      desc("wait_duration_seconds_total", "Time queries waited for a connection."),
+		maxIdleClosed:     desc("max_idle_closed_total", "Number of connections closed because of the idle connection limit."),
+		maxIdleTimeClosed: desc("max_idle_time_closed_total", "Number of connections closed because of the idle time limit."),
+		maxLifetimeClosed: desc("max_lifetime_closed_total", "Number of connections closed because of the lifetime limit."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *stdprometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdleClosed
+	ch <- c.maxIdleTimeClosed
+	ch <- c.maxLifetimeClosed
+}
+
+func (c *poolCollector) Collect(ch chan<- stdprometheus.Metric) {
+	c.collect(ch, "master", c.conn.Master)
+	for _, r := range c.conn.Replicas {
+		c.collect(ch, r.Name, r.DB)
+	}
+}
+
+func (c *poolCollector) collect(ch chan<- stdprometheus.Metric, pool string, conn *gorm.DB) {
+	if conn == nil {
+		return
+	}
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return
+	}
+	s := sqlDB.Stats()
+
+	gauge := func(desc *stdprometheus.Desc, v float64) {
+		ch <- stdprometheus.MustNewConstMetric(desc, stdprometheus.GaugeValue, v, pool)
+	}
+	counter := func(desc *stdprometheus.Desc, v float64) {
+		ch <- stdprometheus.MustNewConstMetric(desc, stdprometheus.CounterValue, v, pool)
+	}
+	gauge(c.maxOpen, float64(s.MaxOpenConnections))
+	gauge(c.open, float64(s.OpenConnections))
+	gauge(c.inUse, float64(s.InUse))
+	gauge(c.idle, float64(s.Idle))
+	counter(c.waitCount, float64(s.WaitCount))
+	counter(c.waitDuration, s.WaitDuration.Seconds())
+	counter(c.maxIdleClosed, float64(s.MaxIdleClosed))
+	counter(c.maxIdleTimeClosed, float64(s.MaxIdleTimeClosed))
+	counter(c.maxLifetimeClosed, float64(s.MaxLifetimeClosed))
+}
@@ -0,0 +1,286 @@
+package edgeRepository
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var ErrNotFound = errors.New("guest not found")
+
+type Repository interface {
+	GetGuest(ctx context.Context, eventID uint64, id uint64) (*Guest, error)
+	GetGuestByToken(ctx context.Context, eventID uint64, token string) (*Guest, error)
+	SearchGuests(ctx context.Context, q userRepository.SearchQuery) (*SearchResult, error)
+	// ChangeGuest runs change on the guest and writes it back along with the
+	// op change returns, nothing is written when change fails.
+	ChangeGuest(ctx context.Context, eventID uint64, id uint64, change func(*Guest) (*Op, error)) error
+	// StoreCentral writes the guest as the central instance has it, door
+	// clients see it once no op of the guest waits to be sent. Versions
+	// older than the stored one are ignored.
+	StoreCentral(ctx context.Context, g *Guest) error
+	DeleteGuest(ctx context.Context, eventID uint64, id uint64) error
+
+	PendingOps(ctx context.Context, eventID uint64, limit int) ([]*Op, error)
+	CountPendingOps(ctx context.Context, eventID uint64) (int64, error)
+	// ResolveOp removes a sent op, records its conflict if there is one and
+	// stores central like StoreCentral. A nil central deletes the guest.
+	ResolveOp(ctx context.Context, op *Op, conflict *Conflict, central *Guest) error
+	// ListConflicts returns conflicts of the event, latest first.
+	ListConflicts(ctx context.Context, eventID uint64) ([]*Conflict, error)
+
+	GetSyncState(ctx context.Context, eventID uint64) (*SyncState, error)
+	SetSyncSeq(ctx context.Context, eventID uint64, seq uint64) error
+}
+
+type SearchResult struct {
+	Guests []*Guest
+	Total  int64
+	// Next is set when there are more guests after this page.
+	Next *userRepository.Cursor
+}
+
+type rankedGuest struct {
+	Guest `gorm:"embedded"`
+	Rank  float32
+}
+
+// edgeDBRepository keeps the copy in SQLite. The pool holds one connection,
+// so transactions never interleave.
+type edgeDBRepository struct {
+	db *gorm.DB
+}
+
+func NewEdgeDBRepository(db *gorm.DB) Repository {
+	return &edgeDBRepository{db: db}
+}
+
+// migrate creates the tables of the edge copy when missing.
+func migrate(conn *gorm.DB) error {
+	if err := conn.AutoMigrate(&Guest{}, &Op{}, &Conflict{}, &SyncState{}); err != nil {
+		return errors.Wrap(err, "create edge tables")
+	}
+	// door operators log in at the edge, so it works without the central
+	// instance, their logins are unique regardless of case as there
+	if err := conn.AutoMigrate(&operatorRepository.Operator{}, &operatorRepository.Session{}); err != nil {
+		return errors.Wrap(err, "create operator tables")
+	}
+	if err := conn.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_operators_login ON operators (lower(login))").Error; err != nil {
+		return errors.Wrap(err, "create operator tables")
+	}
+	return nil
+}
+
+func (r *edgeDBRepository) GetGuest(ctx context.Context, eventID uint64, id uint64) (*Guest, error) {
+	var record Guest
+	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Take(&record, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *edgeDBRepository) GetGuestByToken(ctx context.Context, eventID uint64, token string) (*Guest, error) {
+	var record Guest
+	err := r.db.WithContext(ctx).Where("event_id = ? AND invite_token = ?", eventID, token).Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// SearchGuests matches the terms as substrings of the search text and ranks
+// guests by how many terms they match.
+func (r *edgeDBRepository) SearchGuests(ctx context.Context, q userRepository.SearchQuery) (*SearchResult, error) {
+	if q.Limit <= 0 {
+		return nil, errors.New("search limit must be positive")
+	}
+
+	rank := "0"
+	var args []interface{}
+	if len(q.Terms) > 0 {
+		var matches []string
+		for _, term := range q.Terms {
+			matches = append(matches, "(instr(search_text, ?) > 0)")
+			args = append(args, userRepository.NormalizeSearch(term))
+		}
+		rank = strings.Join(matches, " + ")
+	}
+	ranked := "(SELECT *, " + rank + " AS rank FROM guests WHERE event_id = ?) s"
+	args = append(args, q.EventID)
+	filter := "1 = 1"
+	if len(q.Terms) > 0 {
+		filter = "rank > 0"
+	}
+
+	conn := r.db.WithContext(ctx)
+	result := SearchResult{}
+	if err := conn.Raw("SELECT count(*) FROM "+ranked+" WHERE "+filter, args...).Scan(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	sql := "SELECT * FROM " + ranked + " WHERE " + filter
+	if q.After != nil {
+		sql += " AND (rank < ? OR (rank = ? AND id > ?))"
+		args = append(args, q.After.Rank, q.After.Rank, q.After.ID)
+	}
+	sql += " ORDER BY rank DESC, id LIMIT ?"
+	args = append(args, q.Limit+1)
+
+	var records []rankedGuest
+	if err := conn.Raw(sql, args...).Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	if len(records) > q.Limit {
+		records = records[:q.Limit]
+		last := records[len(records)-1]
+		result.Next = &userRepository.Cursor{Rank: last.Rank, ID: last.ID}
+	}
+	for i := range records {
+		result.Guests = append(result.Guests, &records[i].Guest)
+	}
+	return &result, nil
+}
+
+func (r *edgeDBRepository) ChangeGuest(ctx context.Context, eventID uint64, id uint64, change func(*Guest) (*Op, error)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record Guest
+		err := tx.Where("event_id = ?", eventID).Take(&record, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		op, err := change(&record)
+		if err != nil {
+			return err
+		}
+		record.LocalVersion++
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+		if op == nil {
+			return nil
+		}
+		op.EventID, op.UserID = record.EventID, record.ID
+		return tx.Create(op).Error
+	})
+}
+
+func (r *edgeDBRepository) StoreCentral(ctx context.Context, g *Guest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return storeCentral(tx, g)
+	})
+}
+
+func storeCentral(tx *gorm.DB, g *Guest) error {
+	var record Guest
+	err := tx.Where("event_id = ?", g.EventID).Take(&record, g.ID).Error
+	created := errors.Is(err, gorm.ErrRecordNotFound)
+	if err != nil && !created {
+		return err
+	}
+	if created {
+		record = Guest{ID: g.ID, EventID: g.EventID}
+	}
+
+	changed := created || g.Version > record.Version
+	if changed {
+		record.Version = g.Version
+		record.Central = g.Central
+		record.SearchText = g.SearchText
+	}
+	if g.InviteToken != "" && g.InviteToken != record.InviteToken {
+		record.InviteToken = g.InviteToken
+		changed = true
+	}
+
+	var pending int64
+	if err := tx.Model(&Op{}).Where("user_id = ?", record.ID).Count(&pending).Error; err != nil {
+		return err
+	}
+	if pending == 0 && !bytes.Equal(record.Data, record.Central) {
+		record.Data = record.Central
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	// the local version never goes back, a door client holding an older
+	// one gets a conflict
+	record.LocalVersion++
+	if record.LocalVersion < record.Version {
+		record.LocalVersion = record.Version
+	}
+	return tx.Save(&record).Error
+}
+
+func (r *edgeDBRepository) DeleteGuest(ctx context.Context, eventID uint64, id uint64) error {
+	return r.db.WithContext(ctx).Where("event_id = ?", eventID).Delete(&Guest{}, id).Error
+}
+
+func (r *edgeDBRepository) PendingOps(ctx context.Context, eventID uint64, limit int) ([]*Op, error) {
+	var ops []*Op
+	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Order("id").Limit(limit).Find(&ops).Error
+	return ops, err
+}
+
+func (r *edgeDBRepository) CountPendingOps(ctx context.Context, eventID uint64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Op{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+func (r *edgeDBRepository) ResolveOp(ctx context.Context, op *Op, conflict *Conflict, central *Guest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&Op{}, op.ID).Error; err != nil {
+			return err
+		}
+		if conflict != nil {
+			conflict.EventID, conflict.UserID = op.EventID, op.UserID
+			conflict.Kind, conflict.Request, conflict.Actor, conflict.DoneAt = op.Kind, op.Request, op.Actor, op.CreatedAt
+			if err := tx.Create(conflict).Error; err != nil {
+				return err
+			}
+		}
+		if central == nil {
+			return tx.Where("event_id = ?", op.EventID).Delete(&Guest{}, op.UserID).Error
+		}
+		return storeCentral(tx, central)
+	})
+}
+
+func (r *edgeDBRepository) ListConflicts(ctx context.Context, eventID uint64) ([]*Conflict, error) {
+	var conflicts []*Conflict
+	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Order("id DESC").Find(&conflicts).Error
+	return conflicts, err
+}
+
+func (r *edgeDBRepository) GetSyncState(ctx context.Context, eventID uint64) (*SyncState, error) {
+	state := SyncState{EventID: eventID}
+	err := r.db.WithContext(ctx).Take(&state, eventID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *edgeDBRepository) SetSyncSeq(ctx context.Context, eventID uint64, seq uint64) error {
+	return r.db.WithContext(ctx).Save(&SyncState{EventID: eventID, Seq: seq, SyncedAt: time.Now()}).Error
+}
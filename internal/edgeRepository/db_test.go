@@ -0,0 +1,142 @@
+//go:build edge
+// +build edge
+
+package edgeRepository
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestRepository(t *testing.T) Repository {
+	db, err := Open(filepath.Join(t.TempDir(), "edge.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if pool, err := db.DB(); err == nil {
+			pool.Close()
+		}
+	})
+	return NewEdgeDBRepository(db)
+}
+
+func TestStoreCentral(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	require.NoError(t, repo.StoreCentral(ctx, &Guest{ID: 7, EventID: 1, InviteToken: "t7", Version: 2, Central: []byte(`{"v":2}`)}))
+	g, err := repo.GetGuestByToken(ctx, 1, "t7")
+	require.NoError(t, err)
+	assert.Equal(t, `{"v":2}`, string(g.Data))
+	assert.Equal(t, uint64(2), g.LocalVersion)
+
+	// an older version is ignored and the token is kept
+	require.NoError(t, repo.StoreCentral(ctx, &Guest{ID: 7, EventID: 1, Version: 1, Central: []byte(`{"v":1}`)}))
+	g, err = repo.GetGuest(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, `{"v":2}`, string(g.Central))
+	assert.Equal(t, "t7", g.InviteToken)
+
+	// the door copy is kept while an op waits
+	require.NoError(t, repo.ChangeGuest(ctx, 1, 7, func(g *Guest) (*Op, error) {
+		g.Data = []byte(`{"door":true}`)
+		return &Op{Kind: OpCheckin, Request: []byte(`{}`)}, nil
+	}))
+	require.NoError(t, repo.StoreCentral(ctx, &Guest{ID: 7, EventID: 1, Version: 3, Central: []byte(`{"v":3}`)}))
+	g, err = repo.GetGuest(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, `{"door":true}`, string(g.Data))
+	assert.Equal(t, uint64(3), g.Version)
+	assert.Equal(t, uint64(4), g.LocalVersion)
+
+	ops, err := repo.PendingOps(ctx, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, uint64(7), ops[0].UserID)
+
+	require.NoError(t, repo.ResolveOp(ctx, ops[0], &Conflict{Reason: "checked in first"}, &Guest{ID: 7, EventID: 1, Version: 4, Central: []byte(`{"v":4}`)}))
+	g, err = repo.GetGuest(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, `{"v":4}`, string(g.Data))
+
+	count, err := repo.CountPendingOps(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	conflicts, err := repo.ListConflicts(ctx, 1)
+	require.NoError(t, err)
+	if assert.Len(t, conflicts, 1) {
+		assert.Equal(t, OpCheckin, conflicts[0].Kind)
+		assert.Equal(t, uint64(7), conflicts[0].UserID)
+	}
+
+	_, err = repo.GetGuest(ctx, 2, 7)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSearchGuests(t *testing.T) {
+	ctx := context.Background()
+	repo := openTestRepository(t)
+
+	for id, text := range map[uint64]string{
+		1: "иванов иван acme",
+		2: "петров петр acme",
+		3: "сидоров иван globex",
+	} {
+		require.NoError(t, repo.StoreCentral(ctx, &Guest{ID: id, EventID: 1, SearchText: text, Version: 1, Central: []byte(`{}`)}))
+	}
+
+	result, err := repo.SearchGuests(ctx, userRepository.SearchQuery{EventID: 1, Terms: []string{"Иван", "ACME"}, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	require.Len(t, result.Guests, 1)
+	assert.Equal(t, uint64(1), result.Guests[0].ID)
+	require.NotNil(t, result.Next)
+
+	result, err = repo.SearchGuests(ctx, userRepository.SearchQuery{EventID: 1, Terms: []string{"Иван", "ACME"}, Limit: 5, After: result.Next})
+	require.NoError(t, err)
+	var ids []uint64
+	for _, g := range result.Guests {
+		ids = append(ids, g.ID)
+	}
+	assert.Equal(t, []uint64{2, 3}, ids)
+	assert.Nil(t, result.Next)
+
+	result, err = repo.SearchGuests(ctx, userRepository.SearchQuery{EventID: 1, Limit: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+}
+
+func TestOperators(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(filepath.Join(t.TempDir(), "edge.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if pool, err := db.DB(); err == nil {
+			pool.Close()
+		}
+	})
+	repo := operatorRepository.NewOperatorDBRepository(&database.Connection{Master: db})
+
+	op := &operatorRepository.Operator{Login: "anna", PasswordHash: "hash", Role: operatorRepository.RoleDoor}
+	require.NoError(t, repo.CreateOperator(ctx, op))
+	err = repo.CreateOperator(ctx, &operatorRepository.Operator{Login: "Anna", PasswordHash: "hash", Role: operatorRepository.RoleDoor})
+	assert.ErrorIs(t, err, operatorRepository.ErrAlreadyExists)
+
+	require.NoError(t, repo.CreateSession(ctx, &operatorRepository.Session{TokenHash: "t", OperatorID: op.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+	_, got, err := repo.GetSession(ctx, "t")
+	require.NoError(t, err)
+	assert.Equal(t, "anna", got.Login)
+
+	// sessions of disabled operators are gone
+	op.Disabled = true
+	require.NoError(t, repo.UpdateOperator(ctx, op, []string{"disabled"}))
+	_, _, err = repo.GetSession(ctx, "t")
+	assert.ErrorIs(t, err, operatorRepository.ErrNotFound)
+}
@@ -0,0 +1,87 @@
+package edgeRepository
+
+import (
+	"time"
+)
+
+// Op kinds, named after the UserService method that sends them.
+const (
+	OpUpdate  = "UpdateUser"
+	OpCheckin = "CheckinByToken"
+)
+
+// Guest is the door copy of a guest. Data is the guest as door clients see
+// it, Central the guest as the central instance sent it last, they differ
+// while changes made at the door wait to be sent.
+type Guest struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement:false"`
+	EventID     uint64 `gorm:"index"`
+	InviteToken string `gorm:"index"`
+	// SearchText is the normalized surname, name, company, guest and phone.
+	SearchText string
+	// Version is the version of Central, changes sent to the central
+	// instance are based on it.
+	Version uint64
+	// LocalVersion changes with every write of the guest, door clients
+	// update the guest based on it.
+	LocalVersion uint64
+	Data         []byte
+	Central      []byte
+}
+
+func (Guest) TableName() string {
+	return "guests"
+}
+
+// Op is a change made at the door waiting to be sent to the central
+// instance, ops are sent in id order.
+type Op struct {
+	ID      uint64 `gorm:"primaryKey"`
+	EventID uint64 `gorm:"index"`
+	UserID  uint64 `gorm:"index"`
+	Kind    string
+	// Request is the request of Kind, encoded as JSON.
+	Request []byte
+	// Base is the guest as door clients saw it before the change, encoded
+	// as JSON, it tells which fields the change is about.
+	Base      []byte
+	Actor     string
+	CreatedAt time.Time
+}
+
+func (Op) TableName() string {
+	return "ops"
+}
+
+// Conflict is an op the central instance did not take as it was. Central
+// is the guest as the central instance has it, nil when it is gone.
+type Conflict struct {
+	ID      uint64 `gorm:"primaryKey"`
+	EventID uint64 `gorm:"index"`
+	UserID  uint64
+	Kind    string
+	Reason  string
+	Request []byte
+	Actor   string
+	// DoneAt is when the op was made at the door.
+	DoneAt    time.Time
+	Central   []byte
+	CreatedAt time.Time
+}
+
+func (Conflict) TableName() string {
+	return "conflicts"
+}
+
+// SyncState is how far the copy of an event follows the central instance.
+type SyncState struct {
+	EventID uint64 `gorm:"primaryKey;autoIncrement:false"`
+	// Seq is the last central change applied.
+	Seq uint64
+	// SyncedAt is when Seq was stored, zero until the guests are loaded.
+	SyncedAt time.Time
+}
+
+func (SyncState) TableName() string {
+	return "sync_state"
+}
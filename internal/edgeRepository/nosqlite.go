@@ -0,0 +1,18 @@
+//go:build !edge
+// +build !edge
+
+package edgeRepository
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// ErrNoSQLite is returned by Open in binaries built without the edge tag.
+// SQLite needs cgo, so only edge builds link it and others stay static.
+var ErrNoSQLite = errors.New("SQLite is not built in, build with the edge tag and CGO_ENABLED=1")
+
+// Open always fails without the edge tag.
+func Open(path string) (*gorm.DB, error) {
+	return nil, ErrNoSQLite
+}
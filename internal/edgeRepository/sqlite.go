@@ -0,0 +1,35 @@
+//go:build edge
+// +build edge
+
+package edgeRepository
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open opens the SQLite database at path, creating it and its tables when
+// missing.
+func Open(path string) (*gorm.DB, error) {
+	conn, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000", path)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "open edge database")
+	}
+	pool, err := conn.DB()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetMaxOpenConns(1)
+
+	if err := migrate(conn); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,95 @@
+package eventRepository
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var (
+	ConnError   = errors.New("get connection error")
+	ErrNotFound = errors.New("event not found")
+)
+
+type Repository interface {
+	GetEvent(ctx context.Context, id uint64) (*Event, error)
+	ListEvents(ctx context.Context) ([]*Event, error)
+	CreateEvent(ctx context.Context, data *Event) error
+	UpdateEvent(ctx context.Context, data *Event) error
+}
+
+type eventDBRepository struct {
+	dbConn *database.Connection
+}
+
+func NewEventDBRepository(pool *database.Connection) Repository {
+	return &eventDBRepository{dbConn: pool}
+}
+
+func (r *eventDBRepository) GetEvent(ctx context.Context, id uint64) (*Event, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var record Event
+
+	err = database.Retry(ctx, func() error {
+		return conn.First(&record, id).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *eventDBRepository) ListEvents(ctx context.Context) ([]*Event, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var records []*Event
+
+	if err := conn.Order("starts_at desc, id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (r *eventDBRepository) CreateEvent(ctx context.Context, data *Event) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return conn.Create(data).Error
+}
+
+func (r *eventDBRepository) UpdateEvent(ctx context.Context, data *Event) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	res := conn.Model(data).Select("*").Updates(data)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
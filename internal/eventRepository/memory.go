@@ -0,0 +1,90 @@
+package eventRepository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// eventMemoryRepository keeps events in memory for tests and demos. It
+// starts with the default event, as the database does after migrations.
+type eventMemoryRepository struct {
+	mu     sync.RWMutex
+	events map[uint64]*Event
+	lastID uint64
+}
+
+func NewEventMemoryRepository() Repository {
+	now := time.Now()
+	return &eventMemoryRepository{
+		events: map[uint64]*Event{
+			DefaultEventID: {ID: DefaultEventID, Name: "Default event", StartsAt: now, EndsAt: now},
+		},
+		lastID: DefaultEventID,
+	}
+}
+
+func (r *eventMemoryRepository) GetEvent(ctx context.Context, id uint64) (*Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.events[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	record := *e
+	return &record, nil
+}
+
+func (r *eventMemoryRepository) ListEvents(ctx context.Context) ([]*Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]*Event, 0, len(r.events))
+	for _, e := range r.events {
+		record := *e
+		records = append(records, &record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].StartsAt.Equal(records[j].StartsAt) {
+			return records[i].StartsAt.After(records[j].StartsAt)
+		}
+		return records[i].ID < records[j].ID
+	})
+
+	return records, nil
+}
+
+func (r *eventMemoryRepository) CreateEvent(ctx context.Context, data *Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if data.ID == 0 {
+		data.ID = r.lastID + 1
+	} else if _, ok := r.events[data.ID]; ok {
+		return errors.Errorf("event %d already exists", data.ID)
+	}
+	if data.ID > r.lastID {
+		r.lastID = data.ID
+	}
+	record := *data
+	r.events[record.ID] = &record
+
+	return nil
+}
+
+func (r *eventMemoryRepository) UpdateEvent(ctx context.Context, data *Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.events[data.ID]; !ok {
+		return ErrNotFound
+	}
+	record := *data
+	r.events[record.ID] = &record
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package eventRepository
+
+import (
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultEventID is the event guests loaded before multi-event support belong to.
+const DefaultEventID = 1
+
+type Event struct {
+	ID             uint64 `gorm:"primary_key"`
+	Name           string
+	Venue          string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	CovidPassTypes PassTypes
+}
+
+func (Event) TableName() string {
+	return "events"
+}
+
+// PassTypes lists covid pass types accepted at an event, stored as a comma separated string.
+type PassTypes []string
+
+// Allows reports whether the pass type is accepted, an empty list accepts any type.
+func (p PassTypes) Allows(passType string) bool {
+	if len(p) == 0 {
+		return true
+	}
+	for _, t := range p {
+		if strings.EqualFold(t, passType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p PassTypes) Value() (driver.Value, error) {
+	return strings.Join(p, ","), nil
+}
+
+func (p *PassTypes) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return errors.Errorf("unsupported pass types value %T", src)
+	}
+
+	*p = nil
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			*p = append(*p, t)
+		}
+	}
+	return nil
+}
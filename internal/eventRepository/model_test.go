@@ -0,0 +1,24 @@
+package eventRepository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassTypes(t *testing.T) {
+	var p PassTypes
+	require.NoError(t, p.Scan([]byte("qr, certificate,,")))
+	assert.Equal(t, PassTypes{"qr", "certificate"}, p)
+	assert.True(t, p.Allows("QR"))
+	assert.False(t, p.Allows("test"))
+
+	v, err := p.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "qr,certificate", v)
+
+	require.NoError(t, p.Scan(nil))
+	assert.Empty(t, p)
+	assert.True(t, p.Allows("anything"))
+}
@@ -0,0 +1,42 @@
+package eventRepository
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/tools/tracing"
+	"github.com/opentracing/opentracing-go"
+)
+
+func NewTracingRepository(ctx context.Context, r Repository) Repository {
+	tracer := tracing.FromContext(ctx)
+	return &tracingRepository{tracer, r}
+}
+
+type tracingRepository struct {
+	tracer opentracing.Tracer
+	Repository
+}
+
+func (r *tracingRepository) GetEvent(ctx context.Context, id uint64) (*Event, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetEvent")
+	defer span.Finish()
+	return r.Repository.GetEvent(ctx, id)
+}
+
+func (r *tracingRepository) ListEvents(ctx context.Context) ([]*Event, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "ListEvents")
+	defer span.Finish()
+	return r.Repository.ListEvents(ctx)
+}
+
+func (r *tracingRepository) CreateEvent(ctx context.Context, data *Event) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CreateEvent")
+	defer span.Finish()
+	return r.Repository.CreateEvent(ctx, data)
+}
+
+func (r *tracingRepository) UpdateEvent(ctx context.Context, data *Event) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "UpdateEvent")
+	defer span.Finish()
+	return r.Repository.UpdateEvent(ctx, data)
+}
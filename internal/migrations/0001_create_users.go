@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createUsers creates the guest table, databases set up by hand keep theirs.
+var createUsers = &gormigrate.Migration{
+	ID: "0001_create_users",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE TABLE IF NOT EXISTS users (
+				id bigserial PRIMARY KEY,
+				status text NOT NULL DEFAULT '',
+				company text NOT NULL DEFAULT '',
+				surname text NOT NULL DEFAULT '',
+				name text NOT NULL DEFAULT '',
+				guest text NOT NULL DEFAULT '',
+				covid_pass text NOT NULL DEFAULT '',
+				rank text NOT NULL DEFAULT '',
+				contact_phone text NOT NULL DEFAULT '',
+				contact_mail text NOT NULL DEFAULT '',
+				checkin boolean NOT NULL DEFAULT false
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_users_surname ON users (surname)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx, `DROP TABLE IF EXISTS users`)
+	},
+}
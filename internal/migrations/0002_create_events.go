@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createEvents adds events and moves existing guests into the default event.
+var createEvents = &gormigrate.Migration{
+	ID: "0002_create_events",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE TABLE IF NOT EXISTS events (
+				id bigserial PRIMARY KEY,
+				name text NOT NULL DEFAULT '',
+				venue text NOT NULL DEFAULT '',
+				starts_at timestamptz NOT NULL DEFAULT now(),
+				ends_at timestamptz NOT NULL DEFAULT now(),
+				covid_pass_types text NOT NULL DEFAULT '',
+				CONSTRAINT chk_events_period CHECK (ends_at >= starts_at)
+			)`,
+			`INSERT INTO events (id, name) VALUES (1, 'Default event') ON CONFLICT (id) DO NOTHING`,
+			`SELECT setval(pg_get_serial_sequence('events', 'id'), (SELECT max(id) FROM events))`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS event_id bigint NOT NULL DEFAULT 1`,
+			`ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_event`,
+			`ALTER TABLE users ADD CONSTRAINT fk_users_event FOREIGN KEY (event_id) REFERENCES events (id)`,
+			`CREATE INDEX IF NOT EXISTS idx_users_event_id ON users (event_id)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users DROP COLUMN IF EXISTS event_id`,
+			`DROP TABLE IF EXISTS events`,
+		)
+	},
+}
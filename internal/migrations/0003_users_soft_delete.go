@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersSoftDelete lets cancelled invitations be hidden instead of removed.
+var usersSoftDelete = &gormigrate.Migration{
+	ID: "0003_users_soft_delete",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamptz`,
+			`CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx, `ALTER TABLE users DROP COLUMN IF EXISTS deleted_at`)
+	},
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createUserAudit adds the append-only trail of guest changes,
+// a trigger rejects any update or delete of written records.
+var createUserAudit = &gormigrate.Migration{
+	ID: "0004_create_user_audit",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE TABLE IF NOT EXISTS user_audit (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users (id),
+				event_id bigint NOT NULL REFERENCES events (id),
+				action text NOT NULL,
+				actor text NOT NULL DEFAULT '',
+				transport text NOT NULL DEFAULT '',
+				old_values jsonb NOT NULL DEFAULT '{}',
+				new_values jsonb NOT NULL DEFAULT '{}',
+				created_at timestamptz NOT NULL DEFAULT now()
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_user_audit_user_id ON user_audit (user_id, id)`,
+			`CREATE OR REPLACE FUNCTION user_audit_append_only() RETURNS trigger AS $$
+			BEGIN
+				RAISE EXCEPTION 'user_audit is append-only';
+			END
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS user_audit_append_only ON user_audit`,
+			`CREATE TRIGGER user_audit_append_only BEFORE UPDATE OR DELETE ON user_audit
+				FOR EACH ROW EXECUTE PROCEDURE user_audit_append_only()`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP TABLE IF EXISTS user_audit`,
+			`DROP FUNCTION IF EXISTS user_audit_append_only()`,
+		)
+	},
+}
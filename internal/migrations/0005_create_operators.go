@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createOperators adds door staff accounts and their login sessions,
+// sessions keep only a hash of the issued token.
+var createOperators = &gormigrate.Migration{
+	ID: "0005_create_operators",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE TABLE IF NOT EXISTS operators (
+				id bigserial PRIMARY KEY,
+				login text NOT NULL,
+				name text NOT NULL DEFAULT '',
+				password_hash text NOT NULL,
+				role text NOT NULL CHECK (role IN ('door', 'coordinator', 'admin')),
+				disabled boolean NOT NULL DEFAULT false,
+				created_at timestamptz NOT NULL DEFAULT now(),
+				updated_at timestamptz NOT NULL DEFAULT now()
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_operators_login ON operators (lower(login))`,
+			`CREATE TABLE IF NOT EXISTS operator_sessions (
+				token_hash text PRIMARY KEY,
+				operator_id bigint NOT NULL REFERENCES operators (id) ON DELETE CASCADE,
+				created_at timestamptz NOT NULL DEFAULT now(),
+				expires_at timestamptz NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_operator_sessions_operator_id ON operator_sessions (operator_id)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP TABLE IF EXISTS operator_sessions`,
+			`DROP TABLE IF EXISTS operators`,
+		)
+	},
+}
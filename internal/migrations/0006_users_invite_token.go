@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersInviteToken gives every guest a random token printed on the invitation
+// as a QR code and remembers when the guest was checked in.
+var usersInviteToken = &gormigrate.Migration{
+	ID: "0006_users_invite_token",
+	Migrate: func(tx *gorm.DB) error {
+		if err := exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS invite_token text`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS checkin_at timestamptz`,
+		); err != nil {
+			return err
+		}
+
+		var ids []uint64
+		if err := tx.Table("users").Where("invite_token IS NULL").Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			b := make([]byte, 16)
+			if _, err := rand.Read(b); err != nil {
+				return err
+			}
+			token := base64.RawURLEncoding.EncodeToString(b)
+			if err := tx.Exec(`UPDATE users SET invite_token = ? WHERE id = ?`, token, id).Error; err != nil {
+				return err
+			}
+		}
+
+		return exec(tx,
+			`ALTER TABLE users ALTER COLUMN invite_token SET NOT NULL`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_invite_token ON users (invite_token)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users DROP COLUMN IF EXISTS checkin_at`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS invite_token`,
+		)
+	},
+}
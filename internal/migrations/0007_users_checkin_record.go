@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersCheckinRecord keeps who checked a guest in, when and at which entrance,
+// and the check-out undoing it. The checkin flag becomes a generated column
+// derived from the record, so filters on it keep working.
+var usersCheckinRecord = &gormigrate.Migration{
+	ID: "0007_users_checkin_record",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS checkin_by text NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS entrance text NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS checkout_at timestamptz`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS checkout_by text NOT NULL DEFAULT ''`,
+			// guests checked in before the record existed take the time and
+			// actor of their last check-in from the audit trail
+			`UPDATE users u SET
+				checkin_at = coalesce(u.checkin_at, a.created_at, now()),
+				checkin_by = coalesce(a.actor, '')
+			FROM users g
+			LEFT JOIN LATERAL (
+				SELECT created_at, actor FROM user_audit
+				WHERE user_id = g.id AND new_values->>'checkin' = 'true'
+				ORDER BY id DESC LIMIT 1
+			) a ON true
+			WHERE u.id = g.id AND u.checkin`,
+			`UPDATE users SET checkin_at = NULL WHERE NOT checkin`,
+			`ALTER TABLE users DROP COLUMN checkin`,
+			`ALTER TABLE users ADD COLUMN checkin boolean
+				GENERATED ALWAYS AS (checkin_at IS NOT NULL AND checkout_at IS NULL) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_users_checkin_at ON users (event_id, checkin_at)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN checkin_flag boolean NOT NULL DEFAULT false`,
+			`UPDATE users SET checkin_flag = checkin`,
+			`ALTER TABLE users DROP COLUMN checkin`,
+			`ALTER TABLE users RENAME COLUMN checkin_flag TO checkin`,
+			`UPDATE users SET checkin_at = NULL WHERE NOT checkin`,
+			`DROP INDEX IF EXISTS idx_users_checkin_at`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS checkout_by`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS checkout_at`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS entrance`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS checkin_by`,
+		)
+	},
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersSearch keeps the searchable guest fields in one lower case column
+// with a trigram index for fuzzy search. "ё" is folded into "е" since
+// guest lists spell it either way.
+var usersSearch = &gormigrate.Migration{
+	ID: "0008_users_search",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS search_text text
+				GENERATED ALWAYS AS (replace(lower(
+					surname || ' ' || name || ' ' || company || ' ' || guest || ' ' || contact_phone
+				), 'ё', 'е')) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_users_search_text ON users USING gin (search_text gin_trgm_ops)`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP INDEX IF EXISTS idx_users_search_text`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS search_text`,
+		)
+	},
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// passTypeCase maps the free text labels the web UI and guest lists used
+// onto pass types, anything else is dropped.
+const passTypeCase = `CASE lower(trim(%s))
+	WHEN 'пцр' THEN 'pcr' WHEN 'pcr' THEN 'pcr'
+	WHEN 'qr' THEN 'qr' WHEN 'qr-код' THEN 'qr' WHEN 'сертификат' THEN 'qr' WHEN 'certificate' THEN 'qr'
+	WHEN 'экспресс' THEN 'express' WHEN 'express' THEN 'express'
+	WHEN 'антитела' THEN 'antibodies' WHEN 'antibodies' THEN 'antibodies'
+	ELSE '' END`
+
+// passLabelCase maps pass types back onto the labels for a rollback.
+const passLabelCase = `CASE %s
+	WHEN 'pcr' THEN 'ПЦР' WHEN 'qr' THEN 'QR' WHEN 'express' THEN 'Экспресс' WHEN 'antibodies' THEN 'Антитела'
+	ELSE %[1]s END`
+
+// usersCovidPass turns the covid pass into a known type with the test date,
+// expiry and certificate number, the accepted types of events are mapped too.
+var usersCovidPass = &gormigrate.Migration{
+	ID: "0009_users_covid_pass",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS covid_pass_test_date date`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS covid_pass_expires_at date`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS covid_pass_number text NOT NULL DEFAULT ''`,
+			fmt.Sprintf(`UPDATE users SET covid_pass = `+passTypeCase+` WHERE covid_pass <> ''`, "covid_pass"),
+			`ALTER TABLE users ADD CONSTRAINT users_covid_pass_type
+				CHECK (covid_pass IN ('', 'pcr', 'qr', 'express', 'antibodies'))`,
+			fmt.Sprintf(`UPDATE events SET covid_pass_types = array_to_string(array(
+				SELECT DISTINCT p FROM (
+					SELECT `+passTypeCase+` AS p FROM unnest(string_to_array(covid_pass_types, ',')) t
+				) m WHERE p <> ''
+			), ',') WHERE covid_pass_types <> ''`, "t"),
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users DROP CONSTRAINT IF EXISTS users_covid_pass_type`,
+			fmt.Sprintf(`UPDATE users SET covid_pass = `+passLabelCase, "covid_pass"),
+			fmt.Sprintf(`UPDATE events SET covid_pass_types = array_to_string(array(
+				SELECT `+passLabelCase+` FROM unnest(string_to_array(covid_pass_types, ',')) t
+			), ',')`, "t"),
+			`ALTER TABLE users DROP COLUMN IF EXISTS covid_pass_number`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS covid_pass_expires_at`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS covid_pass_test_date`,
+		)
+	},
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersCovidPassCheck keeps the outcome of the last certificate scanned for
+// a guest.
+var usersCovidPassCheck = &gormigrate.Migration{
+	ID: "0010_users_covid_pass_check",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS covid_pass_check jsonb`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users DROP COLUMN IF EXISTS covid_pass_check`,
+		)
+	},
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// userAuditNotify announces every audit record on the user_changes channel
+// once its transaction commits, so all replicas can push the change to
+// watching clients. The payload is "event_id:id".
+var userAuditNotify = &gormigrate.Migration{
+	ID: "0011_user_audit_notify",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`CREATE INDEX IF NOT EXISTS idx_user_audit_event_id ON user_audit (event_id, id)`,
+			`CREATE OR REPLACE FUNCTION user_audit_notify() RETURNS trigger AS $$
+			BEGIN
+				PERFORM pg_notify('user_changes', NEW.event_id || ':' || NEW.id);
+				RETURN NULL;
+			END
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS user_audit_notify ON user_audit`,
+			`CREATE TRIGGER user_audit_notify AFTER INSERT ON user_audit
+				FOR EACH ROW EXECUTE PROCEDURE user_audit_notify()`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP TRIGGER IF EXISTS user_audit_notify ON user_audit`,
+			`DROP FUNCTION IF EXISTS user_audit_notify()`,
+			`DROP INDEX IF EXISTS idx_user_audit_event_id`,
+		)
+	},
+}
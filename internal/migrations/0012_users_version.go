@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// usersVersion numbers the states of a guest for optimistic locking, a
+// trigger bumps the version on every update whoever makes it.
+var usersVersion = &gormigrate.Migration{
+	ID: "0012_users_version",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1`,
+			`CREATE OR REPLACE FUNCTION users_bump_version() RETURNS trigger AS $$
+			BEGIN
+				NEW.version := OLD.version + 1;
+				RETURN NEW;
+			END
+			$$ LANGUAGE plpgsql`,
+			`DROP TRIGGER IF EXISTS users_bump_version ON users`,
+			`CREATE TRIGGER users_bump_version BEFORE UPDATE ON users
+				FOR EACH ROW EXECUTE PROCEDURE users_bump_version()`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP TRIGGER IF EXISTS users_bump_version ON users`,
+			`DROP FUNCTION IF EXISTS users_bump_version()`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS version`,
+		)
+	},
+}
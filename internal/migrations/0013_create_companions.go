@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// createCompanions keeps the people a guest comes with as records of their
+// own, with the pass and check-in columns of guests. max_companions is the
+// party size an invitation allows besides the guest.
+var createCompanions = &gormigrate.Migration{
+	ID: "0013_create_companions",
+	Migrate: func(tx *gorm.DB) error {
+		return exec(tx,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS max_companions integer NOT NULL DEFAULT 0
+				CHECK (max_companions >= 0)`,
+			`CREATE TABLE IF NOT EXISTS companions (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users (id),
+				event_id bigint NOT NULL,
+				surname text NOT NULL DEFAULT '',
+				name text NOT NULL DEFAULT '',
+				covid_pass text NOT NULL DEFAULT ''
+					CHECK (covid_pass IN ('', 'pcr', 'qr', 'express', 'antibodies')),
+				covid_pass_test_date date,
+				covid_pass_expires_at date,
+				covid_pass_number text NOT NULL DEFAULT '',
+				checkin_at timestamptz,
+				checkin_by text NOT NULL DEFAULT '',
+				entrance text NOT NULL DEFAULT '',
+				checkout_at timestamptz,
+				checkout_by text NOT NULL DEFAULT '',
+				checkin boolean GENERATED ALWAYS AS (checkin_at IS NOT NULL AND checkout_at IS NULL) STORED,
+				created_at timestamptz NOT NULL DEFAULT now(),
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_companions_user_id ON companions (user_id) WHERE deleted_at IS NULL`,
+		)
+	},
+	Rollback: func(tx *gorm.DB) error {
+		return exec(tx,
+			`DROP TABLE IF EXISTS companions`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS max_companions`,
+		)
+	},
+}
@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const tableName = "schema_migrations"
+
+// migrations are applied in order, new schema changes go to the end of the list.
+// Applied migrations must never be edited, add a new one instead.
+var migrations = []*gormigrate.Migration{
+	createUsers,
+	createEvents,
+	usersSoftDelete,
+	createUserAudit,
+	createOperators,
+	usersInviteToken,
+	usersCheckinRecord,
+	usersSearch,
+	usersCovidPass,
+	usersCovidPassCheck,
+	userAuditNotify,
+	usersVersion,
+	createCompanions,
+}
+
+// State tells whether a migration has been applied.
+type State struct {
+	ID      string
+	Applied bool
+}
+
+func newMigrator(db *gorm.DB) *gormigrate.Gormigrate {
+	return gormigrate.New(db, &gormigrate.Options{
+		TableName:      tableName,
+		IDColumnName:   "id",
+		IDColumnSize:   255,
+		UseTransaction: true,
+	}, migrations)
+}
+
+// Up applies pending migrations up to and including id, all of them when id is empty.
+func Up(ctx context.Context, pool *database.Connection, id string) error {
+	conn, err := database.GetMasterConn(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		return newMigrator(conn).Migrate()
+	}
+	return newMigrator(conn).MigrateTo(id)
+}
+
+// Down rolls back the last applied migration, or every migration applied after id.
+func Down(ctx context.Context, pool *database.Connection, id string) error {
+	conn, err := database.GetMasterConn(ctx, pool)
+	if err != nil {
+		return err
+	}
+
+	if id == "" {
+		return newMigrator(conn).RollbackLast()
+	}
+	return newMigrator(conn).RollbackTo(id)
+}
+
+// Status lists known migrations in order with their applied state.
+func Status(ctx context.Context, pool *database.Connection) ([]State, error) {
+	conn, err := database.GetMasterConn(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool)
+	if conn.Migrator().HasTable(tableName) {
+		var ids []string
+		if err := conn.Table(tableName).Pluck("id", &ids).Error; err != nil {
+			return nil, errors.Wrap(err, "read applied migrations")
+		}
+		for _, id := range ids {
+			applied[id] = true
+		}
+	}
+
+	states := make([]State, 0, len(migrations))
+	for _, m := range migrations {
+		states = append(states, State{ID: m.ID, Applied: applied[m.ID]})
+	}
+	return states, nil
+}
+
+// Pending lists the IDs of migrations not applied yet, in order.
+func Pending(ctx context.Context, pool *database.Connection) ([]string, error) {
+	states, err := Status(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, s := range states {
+		if !s.Applied {
+			pending = append(pending, s.ID)
+		}
+	}
+	return pending, nil
+}
+
+// exec runs statements one by one, stopping on the first error.
+func exec(tx *gorm.DB, statements ...string) error {
+	for _, stmt := range statements {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	ids := make([]string, 0, len(migrations))
+	seen := make(map[string]bool)
+	for _, m := range migrations {
+		assert.False(t, seen[m.ID], "duplicate migration %s", m.ID)
+		assert.NotNil(t, m.Migrate, m.ID)
+		assert.NotNil(t, m.Rollback, m.ID)
+		seen[m.ID] = true
+		ids = append(ids, m.ID)
+	}
+	assert.True(t, sort.StringsAreSorted(ids), "migrations must be listed in id order")
+}
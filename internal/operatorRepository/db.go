@@ -0,0 +1,216 @@
+package operatorRepository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var (
+	ConnError        = errors.New("get connection error")
+	ErrNotFound      = errors.New("operator not found")
+	ErrAlreadyExists = errors.New("operator login is taken")
+)
+
+type Repository interface {
+	GetOperator(ctx context.Context, id uint64) (*Operator, error)
+	GetOperatorByLogin(ctx context.Context, login string) (*Operator, error)
+	ListOperators(ctx context.Context) ([]*Operator, error)
+	CountOperators(ctx context.Context) (int64, error)
+	CreateOperator(ctx context.Context, data *Operator) error
+	// UpdateOperator writes the given columns of data.
+	UpdateOperator(ctx context.Context, data *Operator, columns []string) error
+
+	CreateSession(ctx context.Context, data *Session) error
+	// GetSession returns an unexpired session together with its enabled operator.
+	GetSession(ctx context.Context, tokenHash string) (*Session, *Operator, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteOperatorSessions(ctx context.Context, operatorID uint64) error
+}
+
+type operatorDBRepository struct {
+	dbConn *database.Connection
+}
+
+func NewOperatorDBRepository(pool *database.Connection) Repository {
+	return &operatorDBRepository{dbConn: pool}
+}
+
+func (r *operatorDBRepository) GetOperator(ctx context.Context, id uint64) (*Operator, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var record Operator
+
+	if err := conn.First(&record, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *operatorDBRepository) GetOperatorByLogin(ctx context.Context, login string) (*Operator, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var record Operator
+
+	if err := conn.Where("lower(login) = lower(?)", login).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *operatorDBRepository) ListOperators(ctx context.Context) ([]*Operator, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var records []*Operator
+
+	if err := conn.Order("login").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (r *operatorDBRepository) CountOperators(ctx context.Context) (int64, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return 0, errors.Wrap(ConnError, err.Error())
+	}
+
+	var count int64
+	err = conn.Model(&Operator{}).Count(&count).Error
+
+	return count, err
+}
+
+func (r *operatorDBRepository) CreateOperator(ctx context.Context, data *Operator) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return uniqueError(conn.Create(data).Error)
+}
+
+func (r *operatorDBRepository) UpdateOperator(ctx context.Context, data *Operator, columns []string) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	res := conn.Model(data).Select(append(columns, "updated_at")).Updates(data)
+	if res.Error != nil {
+		return uniqueError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *operatorDBRepository) CreateSession(ctx context.Context, data *Session) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		// expired sessions of the operator are dropped on each login
+		if err := tx.Where("operator_id = ? AND expires_at < ?", data.OperatorID, time.Now()).
+			Delete(&Session{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(data).Error
+	})
+}
+
+func (r *operatorDBRepository) GetSession(ctx context.Context, tokenHash string) (*Session, *Operator, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	// every authenticated request looks its session up
+	var session Session
+	var operator Operator
+	err = database.Retry(ctx, func() error {
+		session, operator = Session{}, Operator{}
+		if err := conn.Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).
+			First(&session).Error; err != nil {
+			return err
+		}
+		return conn.Where("disabled = false").First(&operator, session.OperatorID).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &session, &operator, nil
+}
+
+func (r *operatorDBRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return conn.Where("token_hash = ?", tokenHash).Delete(&Session{}).Error
+}
+
+func (r *operatorDBRepository) DeleteOperatorSessions(ctx context.Context, operatorID uint64) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return conn.Where("operator_id = ?", operatorID).Delete(&Session{}).Error
+}
+
+// uniqueError maps the unique login violation onto ErrAlreadyExists, edges
+// keep their operators in SQLite.
+func uniqueError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrAlreadyExists
+	}
+	// matched by message, the driver error type needs cgo
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return ErrAlreadyExists
+	}
+	return err
+}
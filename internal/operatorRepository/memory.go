@@ -0,0 +1,220 @@
+package operatorRepository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// operatorMemoryRepository keeps operators and their sessions in memory for
+// tests and demos, logins are unique ignoring case as in the database.
+type operatorMemoryRepository struct {
+	mu        sync.RWMutex
+	operators map[uint64]*Operator
+	sessions  map[string]*Session
+	lastID    uint64
+}
+
+func NewOperatorMemoryRepository() Repository {
+	return &operatorMemoryRepository{
+		operators: make(map[uint64]*Operator),
+		sessions:  make(map[string]*Session),
+	}
+}
+
+// operatorColumns copy a column from one operator to another, the way
+// UpdateOperator writes them.
+var operatorColumns = map[string]func(dst, src *Operator){
+	"login":         func(dst, src *Operator) { dst.Login = src.Login },
+	"name":          func(dst, src *Operator) { dst.Name = src.Name },
+	"password_hash": func(dst, src *Operator) { dst.PasswordHash = src.PasswordHash },
+	"role":          func(dst, src *Operator) { dst.Role = src.Role },
+	"disabled":      func(dst, src *Operator) { dst.Disabled = src.Disabled },
+	"updated_at":    func(dst, src *Operator) { dst.UpdatedAt = src.UpdatedAt },
+}
+
+func (r *operatorMemoryRepository) GetOperator(ctx context.Context, id uint64) (*Operator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	op, ok := r.operators[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	record := *op
+	return &record, nil
+}
+
+func (r *operatorMemoryRepository) GetOperatorByLogin(ctx context.Context, login string) (*Operator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	op := r.byLogin(login)
+	if op == nil {
+		return nil, ErrNotFound
+	}
+	record := *op
+	return &record, nil
+}
+
+func (r *operatorMemoryRepository) ListOperators(ctx context.Context) ([]*Operator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records := make([]*Operator, 0, len(r.operators))
+	for _, op := range r.operators {
+		record := *op
+		records = append(records, &record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Login < records[j].Login })
+
+	return records, nil
+}
+
+func (r *operatorMemoryRepository) CountOperators(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.operators)), nil
+}
+
+func (r *operatorMemoryRepository) CreateOperator(ctx context.Context, data *Operator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byLogin(data.Login) != nil {
+		return ErrAlreadyExists
+	}
+	if !ValidRole(data.Role) {
+		return errors.Errorf("unknown role %q", data.Role)
+	}
+
+	if data.ID == 0 {
+		data.ID = r.lastID + 1
+	} else if _, ok := r.operators[data.ID]; ok {
+		return errors.Errorf("operator %d already exists", data.ID)
+	}
+	if data.ID > r.lastID {
+		r.lastID = data.ID
+	}
+	now := time.Now()
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = now
+	}
+	if data.UpdatedAt.IsZero() {
+		data.UpdatedAt = now
+	}
+	record := *data
+	r.operators[record.ID] = &record
+
+	return nil
+}
+
+func (r *operatorMemoryRepository) UpdateOperator(ctx context.Context, data *Operator, columns []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operators[data.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	for _, c := range columns {
+		if operatorColumns[c] == nil {
+			return errors.Errorf("unknown column %q", c)
+		}
+	}
+	if other := r.byLogin(data.Login); other != nil && other.ID != data.ID {
+		for _, c := range columns {
+			if c == "login" {
+				return ErrAlreadyExists
+			}
+		}
+	}
+
+	data.UpdatedAt = time.Now()
+	record := *op
+	for _, c := range append(columns, "updated_at") {
+		operatorColumns[c](&record, data)
+	}
+	r.operators[record.ID] = &record
+
+	return nil
+}
+
+func (r *operatorMemoryRepository) CreateSession(ctx context.Context, data *Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operators[data.OperatorID]; !ok {
+		return errors.Wrapf(ErrNotFound, "operator %d", data.OperatorID)
+	}
+	if _, ok := r.sessions[data.TokenHash]; ok {
+		return errors.New("session already exists")
+	}
+
+	// expired sessions of the operator are dropped on each login
+	now := time.Now()
+	for hash, s := range r.sessions {
+		if s.OperatorID == data.OperatorID && s.ExpiresAt.Before(now) {
+			delete(r.sessions, hash)
+		}
+	}
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = now
+	}
+	record := *data
+	r.sessions[record.TokenHash] = &record
+
+	return nil
+}
+
+func (r *operatorMemoryRepository) GetSession(ctx context.Context, tokenHash string) (*Session, *Operator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.sessions[tokenHash]
+	if !ok || !s.ExpiresAt.After(time.Now()) {
+		return nil, nil, ErrNotFound
+	}
+	op, ok := r.operators[s.OperatorID]
+	if !ok || op.Disabled {
+		return nil, nil, ErrNotFound
+	}
+
+	session, operator := *s, *op
+	return &session, &operator, nil
+}
+
+func (r *operatorMemoryRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, tokenHash)
+	return nil
+}
+
+func (r *operatorMemoryRepository) DeleteOperatorSessions(ctx context.Context, operatorID uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, s := range r.sessions {
+		if s.OperatorID == operatorID {
+			delete(r.sessions, hash)
+		}
+	}
+	return nil
+}
+
+// byLogin finds the operator with the login ignoring case.
+func (r *operatorMemoryRepository) byLogin(login string) *Operator {
+	for _, op := range r.operators {
+		if strings.EqualFold(op.Login, login) {
+			return op
+		}
+	}
+	return nil
+}
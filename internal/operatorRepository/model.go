@@ -0,0 +1,45 @@
+package operatorRepository
+
+import "time"
+
+const (
+	RoleDoor        = "door"
+	RoleCoordinator = "coordinator"
+	RoleAdmin       = "admin"
+)
+
+// ValidRole reports whether role is one of the known operator roles.
+func ValidRole(role string) bool {
+	switch role {
+	case RoleDoor, RoleCoordinator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+type Operator struct {
+	ID           uint64 `gorm:"primary_key"`
+	Login        string
+	Name         string
+	PasswordHash string
+	Role         string
+	Disabled     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (Operator) TableName() string {
+	return "operators"
+}
+
+// Session is an issued login, only the hash of its token is stored.
+type Session struct {
+	TokenHash  string `gorm:"primary_key"`
+	OperatorID uint64
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+func (Session) TableName() string {
+	return "operator_sessions"
+}
@@ -0,0 +1,78 @@
+package operatorRepository
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/tools/tracing"
+	"github.com/opentracing/opentracing-go"
+)
+
+func NewTracingRepository(ctx context.Context, r Repository) Repository {
+	tracer := tracing.FromContext(ctx)
+	return &tracingRepository{tracer, r}
+}
+
+type tracingRepository struct {
+	tracer opentracing.Tracer
+	Repository
+}
+
+func (r *tracingRepository) GetOperator(ctx context.Context, id uint64) (*Operator, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetOperator")
+	defer span.Finish()
+	return r.Repository.GetOperator(ctx, id)
+}
+
+func (r *tracingRepository) GetOperatorByLogin(ctx context.Context, login string) (*Operator, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetOperatorByLogin")
+	defer span.Finish()
+	return r.Repository.GetOperatorByLogin(ctx, login)
+}
+
+func (r *tracingRepository) ListOperators(ctx context.Context) ([]*Operator, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "ListOperators")
+	defer span.Finish()
+	return r.Repository.ListOperators(ctx)
+}
+
+func (r *tracingRepository) CountOperators(ctx context.Context) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CountOperators")
+	defer span.Finish()
+	return r.Repository.CountOperators(ctx)
+}
+
+func (r *tracingRepository) CreateOperator(ctx context.Context, data *Operator) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CreateOperator")
+	defer span.Finish()
+	return r.Repository.CreateOperator(ctx, data)
+}
+
+func (r *tracingRepository) UpdateOperator(ctx context.Context, data *Operator, columns []string) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "UpdateOperator")
+	defer span.Finish()
+	return r.Repository.UpdateOperator(ctx, data, columns)
+}
+
+func (r *tracingRepository) CreateSession(ctx context.Context, data *Session) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CreateSession")
+	defer span.Finish()
+	return r.Repository.CreateSession(ctx, data)
+}
+
+func (r *tracingRepository) GetSession(ctx context.Context, tokenHash string) (*Session, *Operator, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetSession")
+	defer span.Finish()
+	return r.Repository.GetSession(ctx, tokenHash)
+}
+
+func (r *tracingRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "DeleteSession")
+	defer span.Finish()
+	return r.Repository.DeleteSession(ctx, tokenHash)
+}
+
+func (r *tracingRepository) DeleteOperatorSessions(ctx context.Context, operatorID uint64) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "DeleteOperatorSessions")
+	defer span.Finish()
+	return r.Repository.DeleteOperatorSessions(ctx, operatorID)
+}
@@ -0,0 +1,49 @@
+// Package qr encodes short byte strings, such as invitation tokens, as QR
+// codes and renders them as PNG or SVG images. Encoding is done by
+// github.com/skip2/go-qrcode.
+package qr
+
+import (
+	"github.com/pkg/errors"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Level is the error correction level of a code.
+type Level = qrcode.RecoveryLevel
+
+const (
+	Low      = qrcode.Low
+	Medium   = qrcode.Medium
+	Quartile = qrcode.High
+	High     = qrcode.Highest
+)
+
+var ErrTooLong = errors.New("data does not fit into a QR code")
+
+// Code is an encoded QR symbol without the quiet zone.
+type Code struct {
+	Size    int
+	Version int
+
+	modules [][]bool
+}
+
+// Black reports whether the module at column x and row y is dark.
+// Coordinates outside of the symbol are light.
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// Encode builds the smallest code holding data at the given level.
+func Encode(data []byte, level Level) (*Code, error) {
+	q, err := qrcode.New(string(data), level)
+	if err != nil {
+		return nil, errors.Wrapf(ErrTooLong, "%d bytes", len(data))
+	}
+	q.DisableBorder = true
+	modules := q.Bitmap()
+	return &Code{Size: len(modules), Version: q.VersionNumber, modules: modules}, nil
+}
@@ -0,0 +1,64 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	cases := []struct {
+		data    string
+		level   Level
+		version int
+	}{
+		{"hello", Medium, 1},
+		{"kqF3n8XyVbT0pL2wRzA9cD4eHs6uJm1o", Medium, 3},
+		{strings.Repeat("x", 100), Low, 5},
+		{strings.Repeat("guest", 25), Quartile, 9},
+		{strings.Repeat("z", 100), High, 10},
+	}
+	for _, tc := range cases {
+		c, err := Encode([]byte(tc.data), tc.level)
+		require.NoError(t, err)
+		assert.Equal(t, tc.version, c.Version, tc.data)
+		assert.Equal(t, tc.version*4+17, c.Size)
+
+		// finder pattern corners and the dark module
+		assert.True(t, c.Black(0, 0))
+		assert.True(t, c.Black(c.Size-1, 0))
+		assert.True(t, c.Black(0, c.Size-1))
+		assert.False(t, c.Black(7, 7))
+		assert.True(t, c.Black(8, c.Size-8))
+		assert.False(t, c.Black(-1, 0))
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	_, err := Encode(bytes.Repeat([]byte("x"), 3000), Medium)
+	assert.ErrorIs(t, err, ErrTooLong)
+}
+
+func TestRender(t *testing.T) {
+	c, err := Encode([]byte("token"), Medium)
+	require.NoError(t, err)
+
+	b, err := c.PNG(4)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, (c.Size+8)*4, img.Bounds().Dx())
+	r, _, _, _ := img.At(quietZone*4, quietZone*4).RGBA()
+	assert.Equal(t, uint32(0), r)
+	r, _, _, _ = img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+
+	svg := string(c.SVG(4))
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, `viewBox="0 0 29 29"`)
+	assert.Contains(t, svg, "M4,4h1v1h-1z")
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// accessControl answers CORS requests from the listed origins only, the
+// web app served by the service itself is same origin and needs none.
+func accessControl(origins string, h http.Handler) http.Handler {
+	allowed := make(map[string]bool)
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowed[strings.TrimSuffix(origin, "/")] = true
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && allowed[origin] {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE, PATCH")
+			w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, Last-Event-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Invite-Token")
+		}
+		w.Header().Add("Vary", "Origin")
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
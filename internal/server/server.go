@@ -54,14 +54,35 @@ func SetHandler(handlers map[string]http.Handler) Option {
 			}
 		}
 
+		s.router = mux
 		s.handler = mux
 	}
 }
 
+// SetWebApp serves the web app on every path the handlers do not take, it
+// has to go after SetHandler.
+func SetWebApp(app http.Handler) Option {
+	return func(s *Server) {
+		if s.router != nil {
+			s.router.NotFoundHandler = app
+		}
+	}
+}
+
+// SetGRPCInterceptors adds interceptors run after the go-kit one,
+// it has to go before SetGRPC.
+func SetGRPCInterceptors(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, unary)
+		s.streamInterceptors = append(s.streamInterceptors, stream)
+	}
+}
+
 func SetGRPC(joins ...func(grpc *grpc.Server)) Option {
 	return func(s *Server) {
 		grpcServer := grpc.NewServer(
-			grpc.UnaryInterceptor(grpctransport.Interceptor),
+			grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{grpctransport.Interceptor}, s.unaryInterceptors...)...),
+			grpc.ChainStreamInterceptor(s.streamInterceptors...),
 			grpc.ConnectionTimeout(time.Second*time.Duration(s.cfg.Server.GRPC.TimeoutSec)),
 		)
 		for _, j := range joins {
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// A shutdown goes in order: the OnShutdown hooks mark the service not
+// ready, after the shutdown delay the OnDrain hooks end long-lived streams
+// while HTTP and gRPC finish in-flight requests within the shutdown
+// timeout, then Close runs the OnClose hooks.
+
+// OnShutdown adds a function called as soon as a shutdown signal arrives,
+// readiness should fail from then on.
+func OnShutdown(f func()) Option {
+	return func(s *Server) {
+		s.onShutdown = append(s.onShutdown, f)
+	}
+}
+
+// OnDrain adds a function called when the servers start draining, it ends
+// streams that would otherwise hold the drain up to the timeout.
+func OnDrain(f func()) Option {
+	return func(s *Server) {
+		s.onDrain = append(s.onDrain, f)
+	}
+}
+
+// OnClose adds a resource Close releases once the servers are drained, in
+// the order added.
+func OnClose(name string, f func() error) Option {
+	return func(s *Server) {
+		s.closers = append(s.closers, closer{name: name, close: f})
+	}
+}
+
+type closer struct {
+	name  string
+	close func() error
+}
+
+type shutdownMetrics struct {
+	shuttingDown metrics.Gauge
+	drainSeconds metrics.Gauge
+	forced       metrics.Counter
+}
+
+var (
+	shutdownMetricsOnce sync.Once
+	shutdownMetricsSet  *shutdownMetrics
+)
+
+func getShutdownMetrics() *shutdownMetrics {
+	shutdownMetricsOnce.Do(func() {
+		shutdownMetricsSet = &shutdownMetrics{
+			shuttingDown: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "guest_covider",
+				Name:      "shutting_down",
+				Help:      "1 once a shutdown has started.",
+			}, nil),
+			drainSeconds: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "guest_covider",
+				Name:      "shutdown_drain_seconds",
+				Help:      "How long a server took to finish in-flight requests on shutdown.",
+			}, []string{"server"}),
+			forced: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "shutdown_forced_total",
+				Help:      "Number of servers stopped with requests still in flight when the shutdown timeout passed.",
+			}, []string{"server"}),
+		}
+	})
+	return shutdownMetricsSet
+}
+
+// startShutdown marks the service not ready and waits for the shutdown
+// delay, a second signal cuts the wait short.
+func (s *Server) startShutdown(signals <-chan struct{}) {
+	getShutdownMetrics().shuttingDown.Set(1)
+	for _, f := range s.onShutdown {
+		f()
+	}
+
+	delay := time.Second * time.Duration(s.cfg.Server.ShutdownDelaySec)
+	level.Info(s.logger).Log("msg", "shutting down, not ready anymore", "delay", delay)
+	select {
+	case <-time.After(delay):
+	case <-signals:
+	}
+}
+
+// drainContext returns the deadline every server drains within, the first
+// call starts the drain.
+func (s *Server) drainContext() context.Context {
+	s.drainOnce.Do(func() {
+		timeout := time.Second * time.Duration(s.cfg.Server.ShutdownTimeoutSec)
+		s.drainCtx, s.drainCancel = context.WithTimeout(context.Background(), timeout)
+		level.Info(s.logger).Log("msg", "draining in-flight requests", "timeout", timeout)
+		for _, f := range s.onDrain {
+			f()
+		}
+	})
+	return s.drainCtx
+}
+
+// drainHTTP stops accepting connections and waits for in-flight requests,
+// the ones left when the deadline passes are cut off.
+func (s *Server) drainHTTP(srv *http.Server) error {
+	defer s.draining.Done()
+	start := time.Now()
+	err := srv.Shutdown(s.drainContext())
+	if err != nil {
+		srv.Close()
+	}
+	s.drained("HTTP", start, err)
+	return err
+}
+
+// drainGRPC is drainHTTP for gRPC.
+func (s *Server) drainGRPC(srv *grpc.Server) error {
+	defer s.draining.Done()
+	start := time.Now()
+	ctx := s.drainContext()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		err = ctx.Err()
+		srv.Stop()
+		<-stopped
+	}
+	s.drained("GRPC", start, err)
+	return err
+}
+
+func (s *Server) drained(server string, start time.Time, err error) {
+	m := getShutdownMetrics()
+	took := time.Since(start)
+	m.drainSeconds.With("server", server).Set(took.Seconds())
+	if err != nil {
+		m.forced.With("server", server).Add(1)
+		level.Warn(s.logger).Log("component", server+" server", "msg", "in-flight requests cut off", "took", took, "err", err)
+		return
+	}
+	level.Info(s.logger).Log("component", server+" server", "msg", "drained", "took", took)
+}
+
+// Close releases the resources added with OnClose once the servers are
+// drained, it is safe to call more than once.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		s.draining.Wait()
+		if s.drainCancel != nil {
+			s.drainCancel()
+		}
+		for _, c := range s.closers {
+			if err := c.close(); err != nil {
+				level.Error(s.logger).Log("msg", "close "+c.name, "err", err)
+				continue
+			}
+			level.Info(s.logger).Log("msg", "closed "+c.name)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nakiner/guestcovider/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainHTTP(t *testing.T) {
+	var order []string
+	cfg := configs.NewConfig()
+	cfg.Server.ShutdownTimeoutSec = 5
+	s, err := NewServer(
+		SetConfig(cfg),
+		SetLogger(log.NewNopLogger()),
+		OnDrain(func() { order = append(order, "drain") }),
+		OnClose("postgres", func() error {
+			order = append(order, "postgres")
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("checked in"))
+	})}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(listener)
+
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		body <- string(b)
+	}()
+	<-started
+
+	s.draining.Add(1)
+	drained := make(chan error, 1)
+	go func() {
+		drained <- s.drainHTTP(srv)
+	}()
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+
+	assert.NoError(t, <-drained)
+	assert.Equal(t, "checked in", <-body)
+	s.Close()
+	assert.Equal(t, []string{"drain", "postgres"}, order)
+}
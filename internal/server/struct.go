@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/gorilla/mux"
 	"github.com/oklog/run"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -26,8 +28,21 @@ type Server struct {
 	cfg     *configs.Config
 	logger  log.Logger
 	handler http.Handler
+	router  *mux.Router
 	grpc    *grpc.Server
 	group   run.Group
+
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+
+	onShutdown  []func()
+	onDrain     []func()
+	closers     []closer
+	drainOnce   sync.Once
+	drainCtx    context.Context
+	drainCancel context.CancelFunc
+	draining    sync.WaitGroup
+	closeOnce   sync.Once
 }
 
 type Option func(*Server)
@@ -41,11 +56,6 @@ func (s *Server) Run() error {
 	return s.logger.Log("exit", s.group.Run())
 }
 
-// Close closes everything that is open and should be closed after server shutdown
-func (s *Server) Close() {
-
-}
-
 // AddHTTP  http server start when Server.Run()
 func (s *Server) AddHTTP() error {
 	addr := fmt.Sprintf(":%d", s.cfg.Server.HTTP.Port)
@@ -54,24 +64,31 @@ func (s *Server) AddHTTP() error {
 		return errors.Wrap(err, "cann't add HTTP transport")
 	}
 
+	if s.cfg.Limiter.Enabled {
+		l := limiting.NewLimiter(context.Background(), s.cfg.Limiter.Limit)
+		s.handler = l.Middleware(s.handler)
+	}
+	if s.cfg.Sentry.Enabled {
+		s.handler = sentry.Middleware(s.handler)
+	}
+
+	httpServer := &http.Server{
+		Handler:      accessControl(s.cfg.Server.HTTP.CORSOrigins, s.handler),
+		WriteTimeout: time.Second * time.Duration(s.cfg.Server.HTTP.TimeoutSec),
+	}
+
+	drained := make(chan error, 1)
 	s.group.Add(func() error {
 		level.Info(s.logger).Log("component", "HTTP server", "addr", addr, "msg", "listening...")
-		if s.cfg.Limiter.Enabled {
-			l := limiting.NewLimiter(context.Background(), s.cfg.Limiter.Limit)
-			s.handler = l.Middleware(s.handler)
-		}
-		if s.cfg.Sentry.Enabled {
-			s.handler = sentry.Middleware(s.handler)
+		if err := httpServer.Serve(listener); err != http.ErrServerClosed {
+			return err
 		}
-
-		httpServer := &http.Server{
-			Handler:      accessControl(s.handler),
-			WriteTimeout: time.Second * time.Duration(s.cfg.Server.HTTP.TimeoutSec),
-		}
-
-		return httpServer.Serve(listener)
+		return <-drained
 	}, func(error) {
-		listener.Close()
+		s.draining.Add(1)
+		go func() {
+			drained <- s.drainHTTP(httpServer)
+		}()
 	})
 	return nil
 }
@@ -84,11 +101,18 @@ func (s *Server) AddGRPC() error {
 		return errors.Wrap(err, "cann't add GRPC transport")
 	}
 
+	drained := make(chan error, 1)
 	s.group.Add(func() error {
 		level.Info(s.logger).Log("component", "GRPC server", "addr", addr, "msg", "listening...")
-		return s.grpc.Serve(listener)
+		if err := s.grpc.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+			return err
+		}
+		return <-drained
 	}, func(error) {
-		listener.Close()
+		s.draining.Add(1)
+		go func() {
+			drained <- s.drainGRPC(s.grpc)
+		}()
 	})
 	return nil
 }
@@ -108,7 +132,13 @@ func (s *Server) AddMetrics() error {
 		http.Handle("/metrics", promhttp.Handler())
 		return http.Serve(listener, http.DefaultServeMux)
 	}, func(error) {
-		listener.Close()
+		// keep serving until the other servers are drained, so the
+		// shutdown metrics can still be scraped; their interrupts are
+		// added and so run before this one
+		go func() {
+			s.draining.Wait()
+			listener.Close()
+		}()
 	})
 
 	return nil
@@ -118,10 +148,17 @@ func (s *Server) AddMetrics() error {
 func (s *Server) AddSignalHandler() {
 	ch := make(chan struct{})
 	s.group.Add(func() error {
-		c := make(chan os.Signal, 1)
+		c := make(chan os.Signal, 2)
 		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 		select {
 		case sig := <-c:
+			level.Info(s.logger).Log("msg", "received signal", "signal", sig)
+			again := make(chan struct{})
+			go func() {
+				<-c
+				close(again)
+			}()
+			s.startShutdown(again)
 			return errors.Errorf("received signal %s", sig)
 		case <-ch:
 			return nil
@@ -130,17 +167,3 @@ func (s *Server) AddSignalHandler() {
 		close(ch)
 	})
 }
-
-func accessControl(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE, UPDATE, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
-
-		if r.Method == "OPTIONS" {
-			return
-		}
-
-		h.ServeHTTP(w, r)
-	})
-}
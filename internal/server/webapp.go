@@ -0,0 +1,86 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WebConfig is handed to the web app at runtime as window.guestcovider,
+// so one build of the app runs behind any address.
+type WebConfig struct {
+	// APIURL is the base URL the app calls the API at.
+	APIURL string `json:"apiURL"`
+}
+
+// hashedDirs hold the files of the app build with a content hash in their
+// names, they never change and may be cached for good.
+var hashedDirs = map[string]bool{"js": true, "css": true, "img": true, "fonts": true}
+
+type webApp struct {
+	fsys    fs.FS
+	files   http.Handler
+	index   []byte
+	started time.Time
+}
+
+// NewWebApp serves the built web app from fsys. Paths that are not files
+// get index.html, so the app router handles them.
+func NewWebApp(fsys fs.FS, cfg WebConfig) (http.Handler, error) {
+	index, err := fs.ReadFile(fsys, "index.html")
+	if err != nil {
+		return nil, errors.Wrap(err, "web app")
+	}
+	config, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "web app config")
+	}
+	head := bytes.Index(index, []byte("</head>"))
+	if head < 0 {
+		return nil, errors.New("web app index.html has no </head>")
+	}
+	script := fmt.Sprintf("<script>window.guestcovider = %s;</script>", config)
+
+	return &webApp{
+		fsys:    fsys,
+		files:   http.FileServer(http.FS(fsys)),
+		index:   append(append(append([]byte{}, index[:head]...), script...), index[head:]...),
+		started: time.Now(),
+	}, nil
+}
+
+func (a *webApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name != "" && name != "index.html" {
+		if info, err := fs.Stat(a.fsys, name); err == nil && !info.IsDir() {
+			if hashedDirs[strings.SplitN(name, "/", 2)[0]] {
+				w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			} else {
+				w.Header().Set("Cache-Control", "public, max-age=3600")
+			}
+			a.files.ServeHTTP(w, r)
+			return
+		}
+		// a missing asset is not a page of the app
+		if path.Ext(name) != "" {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	http.ServeContent(w, r, "index.html", a.started, bytes.NewReader(a.index))
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebApp(t *testing.T) {
+	app, err := NewWebApp(fstest.MapFS{
+		"index.html":         {Data: []byte("<html><head><title>Гости</title></head><body></body></html>")},
+		"favicon.ico":        {Data: []byte("icon")},
+		"js/app.1a2b3c4d.js": {Data: []byte("app()")},
+	}, WebConfig{APIURL: "/"})
+	require.NoError(t, err)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest("GET", target, nil))
+		return w
+	}
+
+	for _, target := range []string{"/", "/index.html", "/guests/7"} {
+		w := get(target)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"), target)
+		assert.Contains(t, w.Body.String(), `<script>window.guestcovider = {"apiURL":"/"};</script></head>`, target)
+	}
+
+	w := get("/js/app.1a2b3c4d.js")
+	assert.Equal(t, "app()", w.Body.String())
+	assert.Equal(t, "public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+
+	w = get("/favicon.ico")
+	assert.Equal(t, "public, max-age=3600", w.Header().Get("Cache-Control"))
+
+	assert.Equal(t, http.StatusNotFound, get("/js/app.0000.js").Code)
+
+	_, err = NewWebApp(fstest.MapFS{}, WebConfig{})
+	assert.Error(t, err)
+}
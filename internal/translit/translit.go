@@ -0,0 +1,143 @@
+// Package translit converts guest names between Cyrillic and Latin script,
+// so that a name typed on the wrong keyboard layout can still be found.
+// The rules are the common passport style ones and not reversible: the
+// result is meant for fuzzy matching only.
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+var toLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// icao lists the letters ICAO Doc 9303 transliterates unlike toLatin,
+// as found on passports and travel certificates.
+var icao = map[rune]string{
+	'й': "i", 'ъ': "ie", 'ю': "iu", 'я': "ia",
+}
+
+// toCyrillic lists Latin letter groups, longer ones first.
+var toCyrillic = []struct {
+	from, to string
+}{
+	{"shch", "щ"},
+	{"sch", "щ"},
+	{"zh", "ж"},
+	{"kh", "х"},
+	{"ts", "ц"},
+	{"tz", "ц"},
+	{"ch", "ч"},
+	{"sh", "ш"},
+	{"yu", "ю"},
+	{"ju", "ю"},
+	{"ya", "я"},
+	{"ja", "я"},
+	{"yo", "ё"},
+	{"jo", "ё"},
+	{"ye", "е"},
+	{"ph", "ф"},
+	{"a", "а"}, {"b", "б"}, {"c", "к"}, {"d", "д"}, {"e", "е"}, {"f", "ф"},
+	{"g", "г"}, {"h", "х"}, {"i", "и"}, {"j", "й"}, {"k", "к"}, {"l", "л"},
+	{"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"}, {"q", "к"}, {"r", "р"},
+	{"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"},
+	{"z", "з"},
+}
+
+// ToLatin returns s in lower case with Cyrillic letters transliterated.
+func ToLatin(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if l, ok := toLatin[r]; ok {
+			b.WriteString(l)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ToICAO returns s in lower case transliterated the way machine readable
+// travel documents are.
+func ToICAO(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if l, ok := icao[r]; ok {
+			b.WriteString(l)
+			continue
+		}
+		if l, ok := toLatin[r]; ok {
+			b.WriteString(l)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ToCyrillic returns s in lower case with Latin letters transliterated.
+// A "y" is read as "й" at the end of a word or before a vowel and as "ы"
+// otherwise, as in Dmitriy and Bykov.
+func ToCyrillic(s string) string {
+	s = strings.ToLower(s)
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] == 'y' && !startsWithAny(s[i:], "yu", "ya", "yo", "ye") {
+			if i+1 == len(s) || !isLetter(s[i+1]) || strings.IndexByte("aeiou", s[i+1]) >= 0 {
+				b.WriteString("й")
+			} else {
+				b.WriteString("ы")
+			}
+			i++
+			continue
+		}
+
+		matched := false
+		for _, t := range toCyrillic {
+			if strings.HasPrefix(s[i:], t.from) {
+				b.WriteString(t.to)
+				i += len(t.from)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		r := []rune(s[i:])[0]
+		b.WriteRune(r)
+		i += len(string(r))
+	}
+	return b.String()
+}
+
+// IsCyrillic reports whether s has at least one Cyrillic letter.
+func IsCyrillic(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithAny(s string, prefixes ...string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
@@ -0,0 +1,35 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToLatin(t *testing.T) {
+	assert.Equal(t, "ivanov", ToLatin("Иванов"))
+	assert.Equal(t, "shchukina yuliya", ToLatin("Щукина Юлия"))
+	assert.Equal(t, "podyachev", ToLatin("Подъячев"))
+	assert.Equal(t, "smith", ToLatin("Smith"))
+}
+
+func TestToICAO(t *testing.T) {
+	assert.Equal(t, "iuliia", ToICAO("Юлия"))
+	assert.Equal(t, "andrei", ToICAO("Андрей"))
+	assert.Equal(t, "shchukina", ToICAO("Щукина"))
+}
+
+func TestToCyrillic(t *testing.T) {
+	assert.Equal(t, "иванов", ToCyrillic("Ivanov"))
+	assert.Equal(t, "дмитрий", ToCyrillic("Dmitriy"))
+	assert.Equal(t, "быков", ToCyrillic("Bykov"))
+	assert.Equal(t, "щукина юлия", ToCyrillic("Shchukina Yuliya"))
+	assert.Equal(t, "жуков-хабаров", ToCyrillic("Zhukov-Khabarov"))
+	assert.Equal(t, "иванов", ToCyrillic("иванов"))
+}
+
+func TestIsCyrillic(t *testing.T) {
+	assert.True(t, IsCyrillic("Иванов"))
+	assert.True(t, IsCyrillic("Ivanов"))
+	assert.False(t, IsCyrillic("Ivanov 8916"))
+}
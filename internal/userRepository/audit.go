@@ -0,0 +1,186 @@
+package userRepository
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionPatch   = "patch"
+	ActionDelete  = "delete"
+	ActionCheckin = "checkin"
+)
+
+// Audit is an append-only record of a single guest change.
+type Audit struct {
+	ID        uint64 `gorm:"primary_key"`
+	UserID    uint64
+	EventID   uint64
+	Action    string
+	Actor     string
+	Transport string
+	OldValues Values
+	NewValues Values
+	CreatedAt time.Time
+}
+
+func (Audit) TableName() string {
+	return "user_audit"
+}
+
+// Values maps column names to their values, stored as jsonb.
+type Values map[string]string
+
+func (v Values) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func (v *Values) Scan(src interface{}) error {
+	switch b := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(b), v)
+	case []byte:
+		return json.Unmarshal(b, v)
+	default:
+		return errors.Errorf("unsupported audit values %T", src)
+	}
+}
+
+// Actor describes who makes a change and through which transport.
+type Actor struct {
+	Name      string
+	Transport string
+}
+
+type actorKey struct{}
+
+// WithActor stores the actor written to the audit trail by repository updates.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor.
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+// auditValues lists audited columns of a guest.
+func auditValues(u *User) Values {
+	return Values{
+		"status":        u.Status,
+		"company":       u.Company,
+		"surname":       u.Surname,
+		"name":          u.Name,
+		"guest":         u.Guest,
+		"covid_pass":    u.CovidPass,
+		"rank":          u.Rank,
+		"contact_phone": u.ContactPhone,
+		"contact_mail":  u.ContactMail,
+		"checkin":       strconv.FormatBool(u.Checkin),
+		"entrance":      u.Entrance,
+
+		"max_companions": strconv.Itoa(u.MaxCompanions),
+
+		"covid_pass_test_date":  formatDate(u.CovidPassTestDate),
+		"covid_pass_expires_at": formatDate(u.CovidPassExpiresAt),
+		"covid_pass_number":     u.CovidPassNumber,
+		"covid_pass_check":      passCheckStatus(u.CovidPassCheck),
+	}
+}
+
+func passCheckStatus(c *PassCheck) string {
+	if c == nil {
+		return ""
+	}
+	return c.Status
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// diffValues keeps only the columns that differ between from and to,
+// either side may be nil for created and deleted guests.
+func diffValues(from, to *User) (Values, Values) {
+	var before, after Values
+	if from != nil {
+		before = auditValues(from)
+	}
+	if to != nil {
+		after = auditValues(to)
+	}
+	return diff(before, after)
+}
+
+// diff keeps only the values that differ, either side may be nil.
+func diff(before, after Values) (Values, Values) {
+	if before == nil || after == nil {
+		return before, after
+	}
+
+	for k, v := range before {
+		if after[k] == v {
+			delete(before, k)
+			delete(after, k)
+		}
+	}
+	return before, after
+}
+
+// writeAudit records the change within tx, unchanged updates are skipped.
+func writeAudit(ctx context.Context, tx *gorm.DB, action string, from, to *User) error {
+	if record := userAudit(ctx, action, from, to); record != nil {
+		return tx.Create(record).Error
+	}
+	return nil
+}
+
+// userAudit returns the audit record of a guest change, nil when nothing
+// changed.
+func userAudit(ctx context.Context, action string, from, to *User) *Audit {
+	before, after := diffValues(from, to)
+	if len(before) == 0 && len(after) == 0 {
+		return nil
+	}
+
+	u := to
+	if u == nil {
+		u = from
+	}
+	return newAudit(ctx, action, u, before, after)
+}
+
+// newAudit returns an audit record of the guest u made by the actor of ctx.
+func newAudit(ctx context.Context, action string, u *User, before, after Values) *Audit {
+	record := Audit{
+		UserID:    u.ID,
+		EventID:   u.EventID,
+		Action:    action,
+		OldValues: before,
+		NewValues: after,
+	}
+
+	actor := ActorFromContext(ctx)
+	record.Actor, record.Transport = actor.Name, actor.Transport
+
+	return &record
+}
@@ -0,0 +1,45 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValues(t *testing.T) {
+	from := &User{ID: 1, Surname: "Иванов", CovidPass: "qr"}
+	to := *from
+	to.Checkin = true
+	to.CovidPass = "certificate"
+
+	before, after := diffValues(from, &to)
+	assert.Equal(t, Values{"checkin": "false", "covid_pass": "qr"}, before)
+	assert.Equal(t, Values{"checkin": "true", "covid_pass": "certificate"}, after)
+
+	before, after = diffValues(nil, &to)
+	assert.Nil(t, before)
+	assert.Equal(t, "Иванов", after["surname"])
+
+	before, after = diffValues(from, from)
+	assert.Empty(t, before)
+	assert.Empty(t, after)
+}
+
+func TestValues(t *testing.T) {
+	v, err := Values{"checkin": "true"}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"checkin":"true"}`, v)
+
+	var out Values
+	require.NoError(t, out.Scan([]byte(`{"checkin":"true"}`)))
+	assert.Equal(t, Values{"checkin": "true"}, out)
+}
+
+func TestActorFromContext(t *testing.T) {
+	assert.Equal(t, Actor{}, ActorFromContext(context.Background()))
+
+	ctx := WithActor(context.Background(), Actor{Name: "10.0.0.1:5000", Transport: "grpc"})
+	assert.Equal(t, "grpc", ActorFromContext(ctx).Transport)
+}
@@ -0,0 +1,156 @@
+package userRepository
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrBulkAborted is the result of guests left unchanged because another
+	// guest of an all-or-nothing update failed.
+	ErrBulkAborted = errors.New("not updated, another guest failed")
+	// ErrBulkTooLarge is returned when more guests match than the limit.
+	ErrBulkTooLarge = errors.New("too many guests to update at once")
+)
+
+// BulkQuery picks guests of an event by IDs or, when IDs is empty, by
+// company and host ignoring case. Limit caps the number of guests.
+type BulkQuery struct {
+	EventID uint64
+	IDs     []uint64
+	Company string
+	Guest   string
+	Limit   int
+}
+
+// BulkResult is the outcome for one guest, User is the guest as written
+// when Err is nil.
+type BulkResult struct {
+	ID   uint64
+	User *User
+	Err  error
+}
+
+// PatchUsers locks the picked guests and writes the columns patch returns
+// for each of them in one transaction. A guest patch fails on is left as it
+// is, with atomic no guest is written then and the others get
+// ErrBulkAborted. Database errors fail the whole update.
+func (r *userDBRepository) PatchUsers(ctx context.Context, q BulkQuery, atomic bool, patch func(*User) ([]string, error)) ([]*BulkResult, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var results []*BulkResult
+
+	err = database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("event_id = ?", q.EventID)
+		if len(q.IDs) > 0 {
+			query = query.Where("id IN ?", q.IDs)
+		} else {
+			if q.Company != "" {
+				query = query.Where("lower(company) = lower(?)", q.Company)
+			}
+			if q.Guest != "" {
+				query = query.Where("lower(guest) = lower(?)", q.Guest)
+			}
+		}
+		if q.Limit > 0 {
+			query = query.Limit(q.Limit + 1)
+		}
+
+		var records []*User
+		if err := query.Order("id").Find(&records).Error; err != nil {
+			return err
+		}
+		if q.Limit > 0 && len(records) > q.Limit {
+			return ErrBulkTooLarge
+		}
+		if err := loadCompanions(tx, records...); err != nil {
+			return err
+		}
+		results = bulkResults(q.IDs, records)
+		olds, columns := patchBulk(results, atomic, patch)
+
+		for _, res := range results {
+			if res.Err != nil {
+				continue
+			}
+			old := olds[res.ID]
+			if err := patchRecord(ctx, tx, &old, res.User, columns[res.ID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// bulkResults lists a result per guest, in the order of ids when the guests
+// were picked by them and with ErrNotFound for missing ones.
+func bulkResults(ids []uint64, records []*User) []*BulkResult {
+	if len(ids) == 0 {
+		results := make([]*BulkResult, 0, len(records))
+		for _, u := range records {
+			results = append(results, &BulkResult{ID: u.ID, User: u})
+		}
+		return results
+	}
+
+	byID := make(map[uint64]*User, len(records))
+	for _, u := range records {
+		byID[u.ID] = u
+	}
+	results := make([]*BulkResult, 0, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if u, ok := byID[id]; ok {
+			results = append(results, &BulkResult{ID: id, User: u})
+		} else {
+			results = append(results, &BulkResult{ID: id, Err: ErrNotFound})
+		}
+	}
+	return results
+}
+
+// patchBulk patches every guest before any is written, so a failure of an
+// atomic update leaves nothing to roll back. It returns the guests as they
+// were read and the columns to write, results left without an error are
+// the ones to write.
+func patchBulk(results []*BulkResult, atomic bool, patch func(*User) ([]string, error)) (map[uint64]User, map[uint64][]string) {
+	olds := make(map[uint64]User, len(results))
+	columns := make(map[uint64][]string, len(results))
+	failed := false
+	for _, res := range results {
+		if res.Err != nil {
+			failed = true
+			continue
+		}
+		olds[res.ID] = *res.User
+		if columns[res.ID], res.Err = patch(res.User); res.Err != nil {
+			res.User = nil
+			failed = true
+		}
+	}
+	if atomic && failed {
+		for _, res := range results {
+			if res.Err == nil {
+				res.User, res.Err = nil, ErrBulkAborted
+			}
+		}
+	}
+	return olds, columns
+}
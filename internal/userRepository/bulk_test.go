@@ -0,0 +1,27 @@
+package userRepository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkResults(t *testing.T) {
+	records := []*User{{ID: 1}, {ID: 3}}
+
+	results := bulkResults(nil, records)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, uint64(1), results[0].ID)
+		assert.Same(t, records[1], results[1].User)
+	}
+
+	results = bulkResults([]uint64{3, 2, 3, 1}, records)
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, uint64(3), results[0].ID)
+		assert.Equal(t, uint64(2), results[1].ID)
+		assert.Equal(t, ErrNotFound, results[1].Err)
+		assert.Nil(t, results[1].User)
+		assert.Equal(t, uint64(1), results[2].ID)
+		assert.NoError(t, results[2].Err)
+	}
+}
@@ -0,0 +1,390 @@
+package userRepository
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// CacheChannel is the Postgres channel replicas announce search cache
+// invalidations on. The payload is "event_id:user_id", a zero user id
+// stands for every guest of the event.
+const CacheChannel = "user_search_cache"
+
+// searchColumns are the columns SearchUsers matches the terms against.
+var searchColumns = map[string]bool{
+	"surname":       true,
+	"name":          true,
+	"company":       true,
+	"guest":         true,
+	"contact_phone": true,
+}
+
+type searchCacheMetrics struct {
+	hits      metrics.Counter
+	misses    metrics.Counter
+	evictions metrics.Counter
+}
+
+var (
+	searchCacheMetricsOnce sync.Once
+	searchCacheMetricsSet  *searchCacheMetrics
+)
+
+func getSearchCacheMetrics() *searchCacheMetrics {
+	searchCacheMetricsOnce.Do(func() {
+		searchCacheMetricsSet = &searchCacheMetrics{
+			hits: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "search_cache_hits_total",
+				Help:      "Number of guest searches answered from the cache.",
+			}, nil),
+			misses: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "search_cache_misses_total",
+				Help:      "Number of guest searches the cache had no fresh result for.",
+			}, nil),
+			evictions: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "search_cache_evictions_total",
+				Help:      "Number of search results dropped to keep the cache within its size.",
+			}, nil),
+		}
+	})
+	return searchCacheMetricsSet
+}
+
+// SearchCache keeps up to size search results for ttl, the least recently
+// used one is dropped first.
+type SearchCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the entries, most recently used first
+	order *list.List
+	// generation grows with every invalidation, a result read before one
+	// may be stale and is not stored
+	generation uint64
+}
+
+type cacheEntry struct {
+	key     string
+	eventID uint64
+	users   map[uint64]bool
+	result  *SearchResult
+	expires time.Time
+}
+
+func NewSearchCache(size int, ttl time.Duration) *SearchCache {
+	return &SearchCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the fresh result stored under key, and the
+// generation to store a result read on a miss with.
+func (c *SearchCache) get(key string) (*SearchResult, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, c.generation, false
+	}
+	e := el.Value.(*cacheEntry)
+	if !time.Now().Before(e.expires) {
+		c.remove(el)
+		return nil, c.generation, false
+	}
+	c.order.MoveToFront(el)
+	return cloneResult(e.result), c.generation, true
+}
+
+// put stores a copy of result unless the cache was invalidated since
+// generation.
+func (c *SearchCache) put(key string, eventID uint64, result *SearchResult, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 || generation != c.generation {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+
+	e := &cacheEntry{
+		key:     key,
+		eventID: eventID,
+		users:   make(map[uint64]bool, len(result.Users)),
+		result:  cloneResult(result),
+		expires: time.Now().Add(c.ttl),
+	}
+	for _, u := range result.Users {
+		e.users[u.ID] = true
+	}
+	c.entries[key] = c.order.PushFront(e)
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		getSearchCacheMetrics().evictions.Add(1)
+	}
+}
+
+// Invalidate drops the results of the event listing the guest, every
+// result of the event when userID is 0.
+func (c *SearchCache) Invalidate(eventID uint64, userID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		e := el.Value.(*cacheEntry)
+		if e.eventID == eventID && (userID == 0 || e.users[userID]) {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+// Clear drops every result.
+func (c *SearchCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// HandleInvalidation applies an invalidation received on CacheChannel. An
+// empty payload, sent by a Listener after invalidations may have been
+// missed, drops every result.
+func (c *SearchCache) HandleInvalidation(payload string) error {
+	if payload == "" {
+		c.Clear()
+		return nil
+	}
+
+	event, user := payload, ""
+	if i := strings.IndexByte(payload, ':'); i >= 0 {
+		event, user = payload[:i], payload[i+1:]
+	}
+	eventID, err := strconv.ParseUint(event, 10, 64)
+	if err != nil {
+		c.Clear()
+		return errors.Errorf("bad search cache invalidation %q", payload)
+	}
+	userID, err := strconv.ParseUint(user, 10, 64)
+	if err != nil {
+		userID = 0
+	}
+	c.Invalidate(eventID, userID)
+	return nil
+}
+
+func (c *SearchCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+// searchKey is the cache key of q. Terms are normalized and sorted since
+// their order and case do not change the result.
+func searchKey(q SearchQuery) string {
+	terms := make([]string, 0, len(q.Terms))
+	for _, term := range q.Terms {
+		terms = append(terms, NormalizeSearch(term))
+	}
+	sort.Strings(terms)
+
+	key := fmt.Sprintf("%d/%d/%q", q.EventID, q.Limit, terms)
+	if q.After != nil {
+		key += fmt.Sprintf("/%g/%d", q.After.Rank, q.After.ID)
+	}
+	return key
+}
+
+func cloneResult(r *SearchResult) *SearchResult {
+	result := *r
+	if r.Next != nil {
+		next := *r.Next
+		result.Next = &next
+	}
+	result.Users = make([]*User, len(r.Users))
+	for i, u := range r.Users {
+		record := *u
+		if u.CovidPassCheck != nil {
+			check := *u.CovidPassCheck
+			record.CovidPassCheck = &check
+		}
+		if u.Companions != nil {
+			record.Companions = make([]*Companion, len(u.Companions))
+			for j, comp := range u.Companions {
+				companion := *comp
+				record.Companions[j] = &companion
+			}
+		}
+		result.Users[i] = &record
+	}
+	return &result
+}
+
+// NewCachingRepository answers searches from cache and invalidates the
+// results a write may change. Writes are announced through publish, when
+// it is set, for the caches of other replicas. A result may still lag
+// behind for up to the cache ttl when it was read from a lagging replica.
+func NewCachingRepository(r Repository, cache *SearchCache, publish func(ctx context.Context, payload string)) Repository {
+	return &cachingRepository{cache, publish, r}
+}
+
+type cachingRepository struct {
+	cache   *SearchCache
+	publish func(ctx context.Context, payload string)
+	Repository
+}
+
+func (r *cachingRepository) SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	key := searchKey(q)
+	result, generation, ok := r.cache.get(key)
+	if ok {
+		getSearchCacheMetrics().hits.Add(1)
+		return result, nil
+	}
+	getSearchCacheMetrics().misses.Add(1)
+
+	result, err := r.Repository.SearchUsers(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(key, q.EventID, result, generation)
+	return result, nil
+}
+
+// UpdateUser changes neither the searched columns nor the rank of the
+// guest, only the results listing the guest are dropped.
+func (r *cachingRepository) UpdateUser(ctx context.Context, data *User) error {
+	if err := r.Repository.UpdateUser(ctx, data); err != nil {
+		return err
+	}
+	r.invalidate(ctx, data.EventID, data.ID)
+	return nil
+}
+
+func (r *cachingRepository) UpsertUsers(ctx context.Context, data []*User) (int, int, error) {
+	created, updated, err := r.Repository.UpsertUsers(ctx, data)
+	if err != nil {
+		return created, updated, err
+	}
+	events := make(map[uint64]bool)
+	for _, u := range data {
+		if !events[u.EventID] {
+			events[u.EventID] = true
+			r.invalidate(ctx, u.EventID, 0)
+		}
+	}
+	return created, updated, nil
+}
+
+func (r *cachingRepository) CreateUser(ctx context.Context, data *User) error {
+	if err := r.Repository.CreateUser(ctx, data); err != nil {
+		return err
+	}
+	r.invalidate(ctx, data.EventID, 0)
+	return nil
+}
+
+func (r *cachingRepository) PatchUser(ctx context.Context, data *User, columns []string) error {
+	if err := r.Repository.PatchUser(ctx, data, columns); err != nil {
+		return err
+	}
+	userID := data.ID
+	for _, c := range columns {
+		if searchColumns[c] {
+			userID = 0
+		}
+	}
+	r.invalidate(ctx, data.EventID, userID)
+	return nil
+}
+
+func (r *cachingRepository) DeleteUser(ctx context.Context, eventID uint64, id uint64) error {
+	if err := r.Repository.DeleteUser(ctx, eventID, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, eventID, 0)
+	return nil
+}
+
+func (r *cachingRepository) CheckinByToken(ctx context.Context, eventID uint64, token string, entrance string) (*User, error) {
+	u, err := r.Repository.CheckinByToken(ctx, eventID, token, entrance)
+	if err != nil {
+		return u, err
+	}
+	r.invalidate(ctx, eventID, u.ID)
+	return u, nil
+}
+
+// PatchUsers may write the searched columns of many guests, every result
+// of the event is dropped.
+func (r *cachingRepository) PatchUsers(ctx context.Context, q BulkQuery, atomic bool, patch func(*User) ([]string, error)) ([]*BulkResult, error) {
+	results, err := r.Repository.PatchUsers(ctx, q, atomic, patch)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if res.Err == nil {
+			r.invalidate(ctx, q.EventID, 0)
+			break
+		}
+	}
+	return results, nil
+}
+
+func (r *cachingRepository) AddCompanion(ctx context.Context, c *Companion) error {
+	if err := r.Repository.AddCompanion(ctx, c); err != nil {
+		return err
+	}
+	r.invalidate(ctx, c.EventID, c.UserID)
+	return nil
+}
+
+func (r *cachingRepository) PatchCompanion(ctx context.Context, c *Companion, columns []string) error {
+	if err := r.Repository.PatchCompanion(ctx, c, columns); err != nil {
+		return err
+	}
+	r.invalidate(ctx, c.EventID, c.UserID)
+	return nil
+}
+
+func (r *cachingRepository) DeleteCompanion(ctx context.Context, eventID uint64, userID uint64, id uint64) error {
+	if err := r.Repository.DeleteCompanion(ctx, eventID, userID, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, eventID, userID)
+	return nil
+}
+
+// invalidate drops the results of the event listing the guest, every
+// result of the event when userID is 0, here and at the other replicas.
+func (r *cachingRepository) invalidate(ctx context.Context, eventID uint64, userID uint64) {
+	r.cache.Invalidate(eventID, userID)
+	if r.publish != nil {
+		r.publish(ctx, fmt.Sprintf("%d:%d", eventID, userID))
+	}
+}
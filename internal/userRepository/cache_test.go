@@ -0,0 +1,108 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRepository counts the searches reaching the repository.
+type countingRepository struct {
+	Repository
+	searches int
+}
+
+func (r *countingRepository) SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	r.searches++
+	return r.Repository.SearchUsers(ctx, q)
+}
+
+func TestCachingRepositoryInvalidation(t *testing.T) {
+	ctx := context.Background()
+	counting := &countingRepository{Repository: NewUserMemoryRepository()}
+	var published []string
+	repo := NewCachingRepository(counting, NewSearchCache(10, time.Minute), func(ctx context.Context, payload string) {
+		published = append(published, payload)
+	})
+
+	ivanov := &User{EventID: 1, Surname: "Иванов", Company: "ACME"}
+	petrov := &User{EventID: 1, Surname: "Петров", Company: "ACME"}
+	require.NoError(t, repo.CreateUser(ctx, ivanov))
+	require.NoError(t, repo.CreateUser(ctx, petrov))
+
+	ivanovs := SearchQuery{EventID: 1, Terms: []string{"Иванов"}, Limit: 5}
+	acme := SearchQuery{EventID: 1, Terms: []string{" acme"}, Limit: 5}
+	for _, q := range []SearchQuery{ivanovs, acme, {EventID: 1, Terms: []string{"ИВАНОВ "}, Limit: 5}} {
+		_, err := repo.SearchUsers(ctx, q)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, counting.searches)
+
+	// a result handed out is a copy
+	result, err := repo.SearchUsers(ctx, acme)
+	require.NoError(t, err)
+	result.Users[0].Surname = "changed"
+	result, err = repo.SearchUsers(ctx, acme)
+	require.NoError(t, err)
+	assert.Equal(t, "Иванов", result.Users[0].Surname)
+	assert.Equal(t, 2, counting.searches)
+
+	// only the results listing the checked in guest are dropped
+	require.NoError(t, repo.UpdateUser(ctx, &User{ID: petrov.ID, EventID: 1, Version: 1, Checkin: true}))
+	result, err = repo.SearchUsers(ctx, ivanovs)
+	require.NoError(t, err)
+	assert.Equal(t, 2, counting.searches)
+	result, err = repo.SearchUsers(ctx, acme)
+	require.NoError(t, err)
+	assert.Equal(t, 3, counting.searches)
+	assert.True(t, result.Users[1].Checkin)
+
+	// a new guest may show up in any result of the event
+	require.NoError(t, repo.CreateUser(ctx, &User{EventID: 1, Surname: "Иванова"}))
+	result, err = repo.SearchUsers(ctx, ivanovs)
+	require.NoError(t, err)
+	assert.Equal(t, 4, counting.searches)
+	assert.Len(t, result.Users, 2)
+
+	assert.Equal(t, []string{"1:0", "1:0", "1:2", "1:0"}, published)
+}
+
+func TestSearchCacheEviction(t *testing.T) {
+	cache := NewSearchCache(2, time.Minute)
+	for i, key := range []string{"a", "b", "a", "c"} {
+		_, generation, ok := cache.get(key)
+		if !ok {
+			cache.put(key, 1, &SearchResult{Users: []*User{{ID: uint64(i)}}}, generation)
+		}
+	}
+	_, _, ok := cache.get("b")
+	assert.False(t, ok, "least recently used")
+	_, _, ok = cache.get("a")
+	assert.True(t, ok)
+
+	// a result read before an invalidation is not stored
+	_, generation, _ := cache.get("d")
+	require.NoError(t, cache.HandleInvalidation("2:0"))
+	cache.put("d", 1, &SearchResult{}, generation)
+	_, _, ok = cache.get("d")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.HandleInvalidation("1:3"))
+	_, _, ok = cache.get("c")
+	assert.False(t, ok)
+	_, _, ok = cache.get("a")
+	assert.True(t, ok)
+
+	assert.Error(t, cache.HandleInvalidation("x:1"))
+	_, _, ok = cache.get("a")
+	assert.False(t, ok)
+
+	expiring := NewSearchCache(2, 0)
+	_, generation, _ = expiring.get("a")
+	expiring.put("a", 1, &SearchResult{}, generation)
+	_, _, ok = expiring.get("a")
+	assert.False(t, ok)
+}
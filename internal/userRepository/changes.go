@@ -0,0 +1,113 @@
+package userRepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+)
+
+// ChangesChannel is the Postgres channel a trigger announces every audit
+// record on, see migration 0011.
+const ChangesChannel = "user_changes"
+
+// Change is a committed guest change. Seq is the id of its audit record and
+// orders changes of all events.
+type Change struct {
+	Seq       uint64
+	EventID   uint64
+	UserID    uint64
+	Action    string
+	Actor     string
+	CreatedAt time.Time
+	// User is the guest as it is now, nil once deleted.
+	User *User
+}
+
+// ChangeQuery selects changes after a sequence number, of every event when
+// EventID is zero.
+type ChangeQuery struct {
+	EventID uint64
+	After   uint64
+	Limit   int
+}
+
+// ListChanges returns changes in sequence order.
+func (r *userDBRepository) ListChanges(ctx context.Context, q ChangeQuery) ([]*Change, error) {
+	if q.Limit <= 0 {
+		return nil, errors.New("changes limit must be positive")
+	}
+
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	query := conn.Where("id > ?", q.After)
+	if q.EventID != 0 {
+		query = query.Where("event_id = ?", q.EventID)
+	}
+
+	var records []*Audit
+	if err := query.Order("id").Limit(q.Limit).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint64, 0, len(records))
+	for _, a := range records {
+		ids = append(ids, a.UserID)
+	}
+	var users []*User
+	if err := conn.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	if err := loadCompanions(conn, users...); err != nil {
+		return nil, err
+	}
+
+	return changesOf(records, users), nil
+}
+
+// LastChangeSeq returns the sequence number of the latest change, zero
+// when there is none.
+func (r *userDBRepository) LastChangeSeq(ctx context.Context) (uint64, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return 0, errors.Wrap(ConnError, err.Error())
+	}
+
+	var seq uint64
+	if err := conn.Model(&Audit{}).Select("coalesce(max(id), 0)").Scan(&seq).Error; err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// changesOf pairs audit records with the current state of their guests,
+// users lists guests that are not deleted.
+func changesOf(records []*Audit, users []*User) []*Change {
+	byID := make(map[uint64]*User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	changes := make([]*Change, 0, len(records))
+	for _, a := range records {
+		changes = append(changes, &Change{
+			Seq:       a.ID,
+			EventID:   a.EventID,
+			UserID:    a.UserID,
+			Action:    a.Action,
+			Actor:     a.Actor,
+			CreatedAt: a.CreatedAt,
+			User:      byID[a.UserID],
+		})
+	}
+	return changes
+}
@@ -0,0 +1,266 @@
+package userRepository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPartyFull is returned when a guest already has as many companions as
+// the invitation allows.
+var ErrPartyFull = errors.New("party is full")
+
+// ActionCompanion is the audit action of companion changes, recorded on
+// the guest they come with.
+const ActionCompanion = "companion"
+
+// Companion is a person coming with a guest, checked in and holding a pass
+// of their own. Checkin is derived from the check-in record like the one of
+// guests.
+type Companion struct {
+	ID                 uint64 `gorm:"primary_key"`
+	UserID             uint64
+	EventID            uint64
+	Surname            string
+	Name               string
+	CovidPass          string
+	CovidPassTestDate  *time.Time
+	CovidPassExpiresAt *time.Time
+	CovidPassNumber    string
+	Checkin            bool `gorm:"->"`
+	CheckinAt          *time.Time
+	CheckinBy          string
+	Entrance           string
+	CheckoutAt         *time.Time
+	CheckoutBy         string
+	CreatedAt          time.Time
+	DeletedAt          gorm.DeletedAt
+}
+
+func (Companion) TableName() string {
+	return "companions"
+}
+
+// Person returns the companion as a guest of the event holding the pass
+// and check-in record, so rules for guests apply to companions alike.
+func (c *Companion) Person() *User {
+	return &User{
+		ID:                 c.ID,
+		EventID:            c.EventID,
+		Surname:            c.Surname,
+		Name:               c.Name,
+		CovidPass:          c.CovidPass,
+		CovidPassTestDate:  c.CovidPassTestDate,
+		CovidPassExpiresAt: c.CovidPassExpiresAt,
+		CovidPassNumber:    c.CovidPassNumber,
+		Checkin:            c.Checkin,
+		CheckinAt:          c.CheckinAt,
+		CheckinBy:          c.CheckinBy,
+		Entrance:           c.Entrance,
+		CheckoutAt:         c.CheckoutAt,
+		CheckoutBy:         c.CheckoutBy,
+	}
+}
+
+// SetPerson copies the name, pass and check-in record of p back.
+func (c *Companion) SetPerson(p *User) {
+	c.Surname, c.Name = p.Surname, p.Name
+	c.CovidPass = p.CovidPass
+	c.CovidPassTestDate, c.CovidPassExpiresAt = p.CovidPassTestDate, p.CovidPassExpiresAt
+	c.CovidPassNumber = p.CovidPassNumber
+	c.Checkin = p.Checkin
+	c.CheckinAt, c.CheckinBy, c.Entrance = p.CheckinAt, p.CheckinBy, p.Entrance
+	c.CheckoutAt, c.CheckoutBy = p.CheckoutAt, p.CheckoutBy
+}
+
+// AddCompanion adds a companion to the guest c.UserID of the event, unless
+// the party is full.
+func (r *userDBRepository) AddCompanion(ctx context.Context, c *Companion) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	id := c.ID
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		c.ID = id
+		var owner User
+
+		// the guest row serializes additions to the party
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", c.EventID).
+			First(&owner, c.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&Companion{}).Where("user_id = ?", owner.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= int64(owner.MaxCompanions) {
+			return errors.Wrapf(ErrPartyFull, "at most %d companions", owner.MaxCompanions)
+		}
+
+		p := c.Person()
+		stampCheckin(ctx, &User{}, p, time.Now())
+		c.SetPerson(p)
+		if err := tx.Create(c).Error; err != nil {
+			return err
+		}
+
+		return writeCompanionAudit(ctx, tx, &owner, nil, c)
+	})
+}
+
+// PatchCompanion writes only the given columns of c, zero values included.
+func (r *userDBRepository) PatchCompanion(ctx context.Context, c *Companion, columns []string) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		var old Companion
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? and user_id = ?", c.EventID, c.UserID).
+			First(&old, c.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		p := c.Person()
+		changed := append(writableColumns(columns), stampCheckin(ctx, old.Person(), p, time.Now())...)
+		c.SetPerson(p)
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(c).Select(changed).Updates(c).Error; err != nil {
+			return err
+		}
+
+		return writeCompanionAudit(ctx, tx, &User{ID: c.UserID, EventID: c.EventID}, &old, c)
+	})
+}
+
+// DeleteCompanion removes a companion from the party of the guest.
+func (r *userDBRepository) DeleteCompanion(ctx context.Context, eventID uint64, userID uint64, id uint64) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		var record Companion
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? and user_id = ?", eventID, userID).
+			First(&record, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+
+		return writeCompanionAudit(ctx, tx, &User{ID: userID, EventID: eventID}, &record, nil)
+	})
+}
+
+// loadCompanions sets the companions of the guests, oldest first.
+func loadCompanions(conn *gorm.DB, users ...*User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint64]*User, len(users))
+	ids := make([]uint64, 0, len(users))
+	for _, u := range users {
+		u.Companions = nil
+		byID[u.ID] = u
+		ids = append(ids, u.ID)
+	}
+
+	var companions []*Companion
+	if err := conn.Where("user_id IN ?", ids).Order("id").Find(&companions).Error; err != nil {
+		return err
+	}
+	for _, c := range companions {
+		if u, ok := byID[c.UserID]; ok {
+			u.Companions = append(u.Companions, c)
+		}
+	}
+	return nil
+}
+
+// companionValues lists audited columns of a companion.
+func companionValues(c *Companion) Values {
+	return Values{
+		"surname":               c.Surname,
+		"name":                  c.Name,
+		"covid_pass":            c.CovidPass,
+		"covid_pass_test_date":  formatDate(c.CovidPassTestDate),
+		"covid_pass_expires_at": formatDate(c.CovidPassExpiresAt),
+		"covid_pass_number":     c.CovidPassNumber,
+		"checkin":               strconv.FormatBool(c.Checkin),
+		"entrance":              c.Entrance,
+	}
+}
+
+// writeCompanionAudit records a companion change on the guest within tx.
+func writeCompanionAudit(ctx context.Context, tx *gorm.DB, owner *User, from, to *Companion) error {
+	if record := companionAudit(ctx, owner, from, to); record != nil {
+		return tx.Create(record).Error
+	}
+	return nil
+}
+
+// companionAudit returns the audit record of a companion change, values
+// carry the companion_id next to the changed columns. It is nil when
+// nothing changed.
+func companionAudit(ctx context.Context, owner *User, from, to *Companion) *Audit {
+	var before, after Values
+	if from != nil {
+		before = companionValues(from)
+	}
+	if to != nil {
+		after = companionValues(to)
+	}
+	before, after = diff(before, after)
+	if len(before) == 0 && len(after) == 0 {
+		return nil
+	}
+
+	id := from
+	if id == nil {
+		id = to
+	}
+	for _, v := range []Values{before, after} {
+		if v != nil {
+			v["companion_id"] = strconv.FormatUint(id.ID, 10)
+		}
+	}
+
+	return newAudit(ctx, ActionCompanion, owner, before, after)
+}
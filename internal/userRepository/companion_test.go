@@ -0,0 +1,42 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompanionPerson(t *testing.T) {
+	ctx := WithActor(context.Background(), Actor{Name: "door1"})
+	at := time.Date(2021, 9, 14, 19, 42, 0, 0, time.UTC)
+
+	c := &Companion{ID: 3, UserID: 7, EventID: 1, Surname: "Иванова", CovidPass: "pcr"}
+	old := c.Person()
+	p := c.Person()
+	p.Checkin, p.Entrance = true, "B"
+	columns := stampCheckin(ctx, old, p, at)
+	c.SetPerson(p)
+
+	assert.Contains(t, columns, "checkin_at")
+	assert.True(t, c.Checkin)
+	assert.Equal(t, &at, c.CheckinAt)
+	assert.Equal(t, "door1", c.CheckinBy)
+	assert.Equal(t, "B", c.Entrance)
+	assert.Equal(t, "pcr", c.CovidPass)
+	assert.Equal(t, uint64(7), c.UserID)
+}
+
+func TestCompanionValuesDiff(t *testing.T) {
+	from := &Companion{Surname: "Иванова", Entrance: "A"}
+	to := &Companion{Surname: "Иванова", Entrance: "A", Checkin: true}
+
+	before, after := diff(companionValues(from), companionValues(to))
+	assert.Equal(t, Values{"checkin": "false"}, before)
+	assert.Equal(t, Values{"checkin": "true"}, after)
+
+	before, after = diff(nil, companionValues(to))
+	assert.Nil(t, before)
+	assert.Equal(t, "Иванова", after["surname"])
+}
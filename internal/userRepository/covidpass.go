@@ -0,0 +1,101 @@
+package userRepository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Covid pass types, the empty type means the guest has shown no pass.
+const (
+	PassPCR        = "pcr"
+	PassQR         = "qr"
+	PassExpress    = "express"
+	PassAntibodies = "antibodies"
+)
+
+// Outcomes of a pass check.
+const (
+	CheckValid        = "valid"
+	CheckInvalid      = "invalid"
+	CheckNameMismatch = "name_mismatch"
+)
+
+// PassCheck is the outcome of the last certificate scanned for a guest.
+type PassCheck struct {
+	Status        string     `json:"status"`
+	Reason        string     `json:"reason,omitempty"`
+	Source        string     `json:"source"`
+	CertificateID string     `json:"certificateId,omitempty"`
+	Holder        string     `json:"holder,omitempty"`
+	BirthDate     string     `json:"birthDate,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	CheckedAt     time.Time  `json:"checkedAt"`
+	CheckedBy     string     `json:"checkedBy,omitempty"`
+}
+
+func (c PassCheck) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	return string(b), err
+}
+
+func (c *PassCheck) Scan(src interface{}) error {
+	switch b := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(b), c)
+	case []byte:
+		return json.Unmarshal(b, c)
+	default:
+		return errors.Errorf("unsupported pass check %T", src)
+	}
+}
+
+// passLabels maps the labels of the old web UI and of guest lists onto
+// pass types.
+var passLabels = map[string]string{
+	"pcr":         PassPCR,
+	"пцр":         PassPCR,
+	"qr":          PassQR,
+	"qr-код":      PassQR,
+	"сертификат":  PassQR,
+	"certificate": PassQR,
+	"express":     PassExpress,
+	"экспресс":    PassExpress,
+	"antibodies":  PassAntibodies,
+	"антитела":    PassAntibodies,
+}
+
+// passValidity is how many days a pass stays valid after the test date
+// when it has no expiry of its own.
+var passValidity = map[string]int{
+	PassPCR:        3,
+	PassExpress:    2,
+	PassAntibodies: 180,
+	PassQR:         365,
+}
+
+// ParsePassType returns the pass type of a type or label, false when unknown.
+func ParsePassType(s string) (string, bool) {
+	t, ok := passLabels[strings.ToLower(strings.TrimSpace(s))]
+	return t, ok
+}
+
+// PassExpiry returns the last day the pass of u is valid on, nil when
+// the pass has neither an expiry nor a test date.
+func PassExpiry(u *User) *time.Time {
+	if u.CovidPassExpiresAt != nil {
+		return u.CovidPassExpiresAt
+	}
+	if u.CovidPassTestDate == nil {
+		return nil
+	}
+	days, ok := passValidity[u.CovidPass]
+	if !ok {
+		return nil
+	}
+	expiry := u.CovidPassTestDate.AddDate(0, 0, days)
+	return &expiry
+}
@@ -0,0 +1,29 @@
+package userRepository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePassType(t *testing.T) {
+	for label, want := range map[string]string{
+		"ПЦР": PassPCR, "QR": PassQR, " Экспресс ": PassExpress, "Антитела": PassAntibodies, "pcr": PassPCR,
+	} {
+		got, ok := ParsePassType(label)
+		assert.True(t, ok, label)
+		assert.Equal(t, want, got, label)
+	}
+	_, ok := ParsePassType("вакцина")
+	assert.False(t, ok)
+}
+
+func TestPassExpiry(t *testing.T) {
+	tested := time.Date(2021, 9, 12, 0, 0, 0, 0, time.UTC)
+	expires := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, PassExpiry(&User{CovidPass: PassQR}))
+	assert.Equal(t, time.Date(2021, 9, 15, 0, 0, 0, 0, time.UTC), *PassExpiry(&User{CovidPass: PassPCR, CovidPassTestDate: &tested}))
+	assert.Equal(t, expires, *PassExpiry(&User{CovidPass: PassPCR, CovidPassTestDate: &tested, CovidPassExpiresAt: &expires}))
+}
@@ -4,17 +4,56 @@ import (
 	"context"
 	"github.com/pkg/errors"
 	"github.com/nakiner/guestcovider/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 var (
-	ConnError = errors.New("get connection error")
+	ConnError           = errors.New("get connection error")
+	ErrNotFound         = errors.New("user not found")
+	ErrAlreadyCheckedIn = errors.New("user already checked in")
+	ErrConflict         = errors.New("user was changed since it was read")
 )
 
+// ConflictError is returned by UpdateUser when the guest has been changed
+// since the version the update is based on.
+type ConflictError struct {
+	// Current is the guest as it is now.
+	Current *User
+}
+
+func (e *ConflictError) Error() string {
+	return ErrConflict.Error()
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
 type Repository interface {
-	FindBySurname(ctx context.Context, surname string) ([]*User, error)
+	SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error)
 	UpdateUser(ctx context.Context, data *User) error
+	UpsertUsers(ctx context.Context, data []*User) (created int, updated int, err error)
+	IterateUsers(ctx context.Context, filter Filter, fn func(*User) error) error
+	CreateUser(ctx context.Context, data *User) error
+	GetUser(ctx context.Context, eventID uint64, id uint64) (*User, error)
+	PatchUser(ctx context.Context, data *User, columns []string) error
+	DeleteUser(ctx context.Context, eventID uint64, id uint64) error
+	GetUserHistory(ctx context.Context, eventID uint64, id uint64) ([]*Audit, error)
+	GetUserByToken(ctx context.Context, eventID uint64, token string) (*User, error)
+	CheckinByToken(ctx context.Context, eventID uint64, token string, entrance string) (*User, error)
+	PatchUsers(ctx context.Context, q BulkQuery, atomic bool, patch func(*User) ([]string, error)) ([]*BulkResult, error)
+	AddCompanion(ctx context.Context, c *Companion) error
+	PatchCompanion(ctx context.Context, c *Companion, columns []string) error
+	DeleteCompanion(ctx context.Context, eventID uint64, userID uint64, id uint64) error
+	ListChanges(ctx context.Context, q ChangeQuery) ([]*Change, error)
+	LastChangeSeq(ctx context.Context) (uint64, error)
 }
 
+// userDBRepository writes to the master. Searches, exports and history
+// read from replicas since they may lag a little, other reads go to the
+// master so a guest read right after a write is up to date.
 type userDBRepository struct {
 	dbConn *database.Connection
 }
@@ -23,43 +62,397 @@ func NewUserDBRepository(pool *database.Connection) Repository {
 	return &userDBRepository{dbConn: pool}
 }
 
-func (r *userDBRepository) FindBySurname(ctx context.Context, surname string) ([]*User, error) {
+func (r *userDBRepository) UpdateUser(ctx context.Context, data *User) error {
 	conn, err := database.GetMasterConn(ctx, r.dbConn)
 
 	if err != nil {
-		return nil, errors.Wrap(ConnError, err.Error())
+		return errors.Wrap(ConnError, err.Error())
 	}
 
-	var records []*User
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		var record User
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", data.EventID).
+			First(&record, data.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if record.Version != data.Version {
+			return &ConflictError{Current: &record}
+		}
+		old := record
+
+		// small fix
+		record.CovidPass = data.CovidPass
+		record.CovidPassTestDate = data.CovidPassTestDate
+		record.CovidPassExpiresAt = data.CovidPassExpiresAt
+		record.CovidPassNumber = data.CovidPassNumber
+		if record.CovidPass != old.CovidPass || record.CovidPassNumber != old.CovidPassNumber {
+			// a hand picked pass no longer is the one checked
+			record.CovidPassCheck = nil
+		}
+		record.Checkin = data.Checkin
+		record.Entrance = data.Entrance
+		stampCheckin(ctx, &old, &record, time.Now())
+
+		if err := tx.Model(&record).Select("*").Updates(&record).Error; err != nil {
+			return err
+		}
+		record.Version = old.Version + 1
+
+		if err := writeAudit(ctx, tx, ActionUpdate, &old, &record); err != nil {
+			return err
+		}
+		if err := loadCompanions(tx, &record); err != nil {
+			return err
+		}
+		*data = record
+		return nil
+	})
+}
+
+// UpsertUsers creates or updates imported guests in one transaction.
+// A guest is matched within its event by surname, name and company ignoring case,
+// check-in state and covid pass of matched guests are kept. Every created
+// or changed guest gets an audit record like a single write.
+func (r *userDBRepository) UpsertUsers(ctx context.Context, data []*User) (created int, updated int, err error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return 0, 0, errors.Wrap(ConnError, err.Error())
+	}
 
-	if err := conn.Debug().Where("surname ilike ?", "%" + surname + "%").Find(&records).Error; err != nil {
-		return nil, errors.Wrap(err, err.Error())
+	// a retried transaction starts over, with the ids of the guests it
+	// created rolled back
+	ids := make([]uint64, len(data))
+	for i, item := range data {
+		ids[i] = item.ID
 	}
 
+	err = database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		created, updated = 0, 0
+		for i, item := range data {
+			item.ID = ids[i]
+			var records []User
 
-	return records, nil
+			if err := tx.
+				Where("event_id = ? and lower(surname) = lower(?) and lower(name) = lower(?) and lower(company) = lower(?)",
+					item.EventID, item.Surname, item.Name, item.Company).
+				Limit(1).
+				Find(&records).Error; err != nil {
+				return err
+			}
+
+			if len(records) == 0 {
+				if err := tx.Create(item).Error; err != nil {
+					return err
+				}
+				item.Version = 1
+				if err := writeAudit(ctx, tx, ActionCreate, nil, item); err != nil {
+					return err
+				}
+				created++
+				continue
+			}
+
+			record := records[0]
+			old := record
+			record.Status = item.Status
+			record.Company = item.Company
+			record.Surname = item.Surname
+			record.Name = item.Name
+			record.Guest = item.Guest
+			record.Rank = item.Rank
+			record.ContactPhone = item.ContactPhone
+			record.ContactMail = item.ContactMail
+
+			if err := tx.Model(&record).Select("*").Updates(&record).Error; err != nil {
+				return err
+			}
+			record.Version = old.Version + 1
+			if err := writeAudit(ctx, tx, ActionUpdate, &old, &record); err != nil {
+				return err
+			}
+			item.ID = record.ID
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
 }
 
-func (r *userDBRepository) UpdateUser(ctx context.Context, data *User) error {
+// IterateUsers reads guests matching the filter through a database cursor
+// and calls fn for each of them in id order. Iteration stops on the first
+// error returned by fn.
+func (r *userDBRepository) IterateUsers(ctx context.Context, filter Filter, fn func(*User) error) error {
+	conn, err := database.GetReplicaConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	query := conn.Model(&User{}).Where("event_id = ?", filter.EventID)
+	if filter.Checkin != nil {
+		query = query.Where("checkin = ?", *filter.Checkin)
+	}
+	if filter.Company != "" {
+		query = query.Where("company ilike ?", filter.Company)
+	}
+	if filter.Status != "" {
+		query = query.Where("status ilike ?", filter.Status)
+	}
+
+	rows, err := query.Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record User
+		if err := conn.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *userDBRepository) CreateUser(ctx context.Context, data *User) error {
 	conn, err := database.GetMasterConn(ctx, r.dbConn)
 
 	if err != nil {
 		return errors.Wrap(ConnError, err.Error())
 	}
 
+	stampCheckin(ctx, &User{}, data, time.Now())
+
+	id := data.ID
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		data.ID = id
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+		// the column default, the version is never written
+		data.Version = 1
+		return writeAudit(ctx, tx, ActionCreate, nil, data)
+	})
+}
+
+func (r *userDBRepository) GetUser(ctx context.Context, eventID uint64, id uint64) (*User, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
 	var record User
 
-	if err := conn.First(&record, data.ID).Error; err != nil {
-		return err
+	err = database.Retry(ctx, func() error {
+		record = User{}
+		if err := conn.Where("event_id = ?", eventID).First(&record, id).Error; err != nil {
+			return err
+		}
+		return loadCompanions(conn, &record)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// PatchUser writes only the given columns of data, zero values included.
+func (r *userDBRepository) PatchUser(ctx context.Context, data *User, columns []string) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
 	}
 
-	// small fix
-	record.CovidPass = data.CovidPass
-	record.Checkin = data.Checkin
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		var old User
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", data.EventID).
+			First(&old, data.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		return patchRecord(ctx, tx, &old, data, columns)
+	})
+}
+
+// patchRecord writes the columns of data, old is the locked record it was
+// read as.
+func patchRecord(ctx context.Context, tx *gorm.DB, old *User, data *User, columns []string) error {
+	changed := append(writableColumns(columns), stampCheckin(ctx, old, data, time.Now())...)
+	if len(changed) == 0 {
+		return nil
+	}
 
-	if err := conn.Model(&record).Select("*").Updates(&record).Error; err != nil {
+	if err := tx.Model(data).Select(changed).Updates(data).Error; err != nil {
 		return err
 	}
+	data.Version = old.Version + 1
+
+	return writeAudit(ctx, tx, ActionPatch, old, data)
+}
+
+// writableColumns drops checkin and entrance from columns, checkin is
+// generated from the check-in record, which stampCheckin writes instead.
+func writableColumns(columns []string) []string {
+	var writable []string
+	for _, c := range columns {
+		if c != "checkin" && c != "entrance" {
+			writable = append(writable, c)
+		}
+	}
+	return writable
+}
+
+// DeleteUser marks the guest as deleted, the row is kept for history.
+func (r *userDBRepository) DeleteUser(ctx context.Context, eventID uint64, id uint64) error {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return errors.Wrap(ConnError, err.Error())
+	}
+
+	return database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		var record User
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ?", eventID).
+			First(&record, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&record).Error; err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, ActionDelete, &record, nil)
+	})
+}
+
+// GetUserHistory returns audit records of a guest, deleted ones included, oldest first.
+func (r *userDBRepository) GetUserHistory(ctx context.Context, eventID uint64, id uint64) ([]*Audit, error) {
+	conn, err := database.GetReplicaConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var records []*Audit
+
+	if err := conn.Where("event_id = ? and user_id = ?", eventID, id).Order("id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// GetUserByToken finds a guest of the event by the invitation token.
+func (r *userDBRepository) GetUserByToken(ctx context.Context, eventID uint64, token string) (*User, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var record User
+
+	err = database.Retry(ctx, func() error {
+		record = User{}
+		if err := conn.Where("event_id = ? and invite_token = ?", eventID, token).First(&record).Error; err != nil {
+			return err
+		}
+		return loadCompanions(conn, &record)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// CheckinByToken checks in the guest holding the invitation token. The row is
+// locked, so of concurrent scans only the first one succeeds, the others get
+// the guest together with ErrAlreadyCheckedIn.
+func (r *userDBRepository) CheckinByToken(ctx context.Context, eventID uint64, token string, entrance string) (*User, error) {
+	conn, err := database.GetMasterConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	var record User
+
+	err = database.Transaction(ctx, conn, func(tx *gorm.DB) error {
+		record = User{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? and invite_token = ?", eventID, token).
+			First(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if record.Checkin {
+			if err := loadCompanions(tx, &record); err != nil {
+				return err
+			}
+			return ErrAlreadyCheckedIn
+		}
+		old := record
+
+		record.Checkin = true
+		record.Entrance = entrance
+		columns := stampCheckin(ctx, &old, &record, time.Now())
+
+		if err := tx.Model(&record).Select(columns).Updates(&record).Error; err != nil {
+			return err
+		}
+		record.Version = old.Version + 1
+
+		if err := writeAudit(ctx, tx, ActionCheckin, &old, &record); err != nil {
+			return err
+		}
+		return loadCompanions(tx, &record)
+	})
+	if errors.Is(err, ErrAlreadyCheckedIn) {
+		return &record, err
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
-}
\ No newline at end of file
+	return &record, nil
+}
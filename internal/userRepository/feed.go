@@ -0,0 +1,239 @@
+package userRepository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrLagging is the error of a subscription dropped because its reader
+// could not keep up, it should resubscribe after the last change it read.
+var ErrLagging = errors.New("subscriber is lagging behind")
+
+// ErrFeedClosed is the error of subscriptions ended by closing the feed,
+// readers should resubscribe to another replica.
+var ErrFeedClosed = errors.New("changes feed is closed")
+
+const (
+	// feedPage is how many changes are read at once.
+	feedPage = 500
+	// feedPoll is how often the feed looks for changes without being
+	// notified, in case a notification was lost.
+	feedPoll = 5 * time.Second
+	// feedGapWait is how long a missing sequence number holds back later
+	// changes. Sequence numbers are taken before commit, so a transaction
+	// still in flight leaves a gap that is filled once it commits; a
+	// rolled back one leaves it for good.
+	feedGapWait = 3 * time.Second
+	// subscriptionBuffer is how many changes a reader may fall behind.
+	subscriptionBuffer = 256
+)
+
+// Feed fans committed guest changes out to subscribers in sequence order.
+// Notifications only tell the feed to look: it reads the changes from the
+// repository, so notifications of every replica and local writes are
+// handled alike.
+type Feed struct {
+	repo Repository
+	wake chan struct{}
+
+	mu       sync.Mutex
+	last     uint64
+	gapSince time.Time
+	subs     map[*Subscription]struct{}
+	closed   bool
+}
+
+func NewFeed(repo Repository) *Feed {
+	return &Feed{
+		repo: repo,
+		wake: make(chan struct{}, 1),
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Notify makes the feed look for new changes, it never blocks.
+func (f *Feed) Notify() {
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes changes committed from now on until ctx is done, read
+// errors are passed to onError and retried.
+func (f *Feed) Run(ctx context.Context, onError func(error)) {
+	for {
+		last, err := f.repo.LastChangeSeq(ctx)
+		if err == nil {
+			f.mu.Lock()
+			f.last = last
+			f.mu.Unlock()
+			break
+		}
+		onError(err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(feedPoll):
+		}
+	}
+
+	poll := time.NewTicker(feedPoll)
+	defer poll.Stop()
+	for {
+		var retry <-chan time.Time
+		if f.pendingGap() {
+			retry = time.After(feedGapWait / 4)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.wake:
+		case <-poll.C:
+		case <-retry:
+		}
+		if err := f.poll(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+	}
+}
+
+// Subscribe starts receiving changes of the event published after the
+// returned sequence number, earlier ones are read from the repository.
+func (f *Feed) Subscribe(eventID uint64) (*Subscription, uint64) {
+	s := &Subscription{
+		feed:    f,
+		eventID: eventID,
+		c:       make(chan *Change, subscriptionBuffer),
+		done:    make(chan struct{}),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		s.err = ErrFeedClosed
+		close(s.done)
+		return s, f.last
+	}
+	f.subs[s] = struct{}{}
+	return s, f.last
+}
+
+// Close drops every subscription with ErrFeedClosed, subscriptions made
+// afterwards are dropped right away. It ends watches on shutdown.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	for s := range f.subs {
+		f.drop(s, ErrFeedClosed)
+	}
+}
+
+func (f *Feed) pendingGap() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return !f.gapSince.IsZero()
+}
+
+// poll publishes every change after the last published one. A gap in the
+// sequence stops publishing until it is filled or feedGapWait passes.
+func (f *Feed) poll(ctx context.Context, now time.Time) error {
+	for {
+		f.mu.Lock()
+		after := f.last
+		f.mu.Unlock()
+
+		changes, err := f.repo.ListChanges(ctx, ChangeQuery{After: after, Limit: feedPage})
+		if err != nil {
+			return err
+		}
+
+		f.mu.Lock()
+		n := f.publish(changes, now)
+		f.mu.Unlock()
+		if len(changes) < feedPage || n < len(changes) {
+			return nil
+		}
+	}
+}
+
+// publish sends changes in order and returns how many were published,
+// f.mu must be held.
+func (f *Feed) publish(changes []*Change, now time.Time) int {
+	for i, c := range changes {
+		if c.Seq <= f.last {
+			continue
+		}
+		if c.Seq > f.last+1 {
+			if f.gapSince.IsZero() {
+				f.gapSince = now
+			}
+			if now.Sub(f.gapSince) < feedGapWait {
+				return i
+			}
+		}
+		f.gapSince = time.Time{}
+		f.last = c.Seq
+
+		for s := range f.subs {
+			if s.eventID != c.EventID {
+				continue
+			}
+			select {
+			case s.c <- c:
+			default:
+				f.drop(s, ErrLagging)
+			}
+		}
+	}
+	return len(changes)
+}
+
+// drop removes the subscription, f.mu must be held.
+func (f *Feed) drop(s *Subscription, err error) {
+	if _, ok := f.subs[s]; !ok {
+		return
+	}
+	delete(f.subs, s)
+	s.err = err
+	close(s.done)
+}
+
+// Subscription receives changes of one event.
+type Subscription struct {
+	feed    *Feed
+	eventID uint64
+	c       chan *Change
+	done    chan struct{}
+	err     error
+}
+
+// Next returns the next change, waiting for one to be published. It fails
+// once ctx is done or the subscription is dropped.
+func (s *Subscription) Next(ctx context.Context) (*Change, error) {
+	select {
+	case c := <-s.c:
+		return c, nil
+	default:
+	}
+
+	select {
+	case c := <-s.c:
+		return c, nil
+	case <-s.done:
+		return nil, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops the subscription.
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.drop(s, errors.New("subscription is closed"))
+}
@@ -0,0 +1,110 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changeLog is a Repository holding committed changes only.
+type changeLog struct {
+	Repository
+	changes []*Change
+}
+
+func (l *changeLog) ListChanges(_ context.Context, q ChangeQuery) ([]*Change, error) {
+	var out []*Change
+	for _, c := range l.changes {
+		if c.Seq > q.After && (q.EventID == 0 || c.EventID == q.EventID) && len(out) < q.Limit {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (l *changeLog) commit(seq, eventID uint64) {
+	l.changes = append(l.changes, &Change{Seq: seq, EventID: eventID, UserID: seq})
+	for i := len(l.changes) - 1; i > 0 && l.changes[i].Seq < l.changes[i-1].Seq; i-- {
+		l.changes[i], l.changes[i-1] = l.changes[i-1], l.changes[i]
+	}
+}
+
+func TestFeedOrder(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	log := &changeLog{}
+	feed := NewFeed(log)
+	sub, last := feed.Subscribe(1)
+	defer sub.Close()
+	assert.Zero(t, last)
+
+	now := time.Now()
+	log.commit(1, 1)
+	log.commit(2, 2)
+	log.commit(4, 1)
+	require.NoError(t, feed.poll(ctx, now))
+
+	c, err := sub.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), c.Seq)
+
+	// 3 is still in flight
+	require.NoError(t, feed.poll(ctx, now.Add(time.Second)))
+	log.commit(3, 1)
+	require.NoError(t, feed.poll(ctx, now.Add(2*time.Second)))
+	for _, seq := range []uint64{3, 4} {
+		c, err := sub.Next(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, seq, c.Seq)
+	}
+
+	// 5 is rolled back
+	log.commit(6, 1)
+	require.NoError(t, feed.poll(ctx, now))
+	assert.True(t, feed.pendingGap())
+	require.NoError(t, feed.poll(ctx, now.Add(feedGapWait)))
+	c, err = sub.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), c.Seq)
+	assert.False(t, feed.pendingGap())
+
+	_, last = feed.Subscribe(1)
+	assert.Equal(t, uint64(6), last)
+}
+
+func TestFeedLagging(t *testing.T) {
+	ctx := context.Background()
+	log := &changeLog{}
+	feed := NewFeed(log)
+	sub, _ := feed.Subscribe(1)
+
+	for seq := uint64(1); seq <= subscriptionBuffer+1; seq++ {
+		log.commit(seq, 1)
+	}
+	require.NoError(t, feed.poll(ctx, time.Now()))
+
+	var err error
+	for n := 0; err == nil; n++ {
+		_, err = sub.Next(ctx)
+		require.LessOrEqual(t, n, subscriptionBuffer)
+	}
+	assert.Equal(t, ErrLagging, err)
+}
+
+func TestFeedClose(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(&changeLog{})
+	sub, _ := feed.Subscribe(1)
+	feed.Close()
+
+	_, err := sub.Next(ctx)
+	assert.Equal(t, ErrFeedClosed, err)
+	sub.Close()
+
+	sub, _ = feed.Subscribe(1)
+	_, err = sub.Next(ctx)
+	assert.Equal(t, ErrFeedClosed, err)
+}
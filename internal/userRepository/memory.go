@@ -0,0 +1,752 @@
+package userRepository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// userMemoryRepository keeps guests in memory for tests and demos, nothing
+// survives a restart. It follows the database: versions are bumped on every
+// write, checkin is derived from the check-in record, deleted guests are
+// kept for history and every change is audited. Search matches terms as
+// substrings only, a guest ranks by the number of terms it matches.
+type userMemoryRepository struct {
+	mu         sync.RWMutex
+	users      map[uint64]*User
+	companions map[uint64]*Companion
+	audit      []*Audit
+	// changes announces written audit records, see MemoryChanges
+	changes chan struct{}
+
+	lastUserID      uint64
+	lastCompanionID uint64
+}
+
+func NewUserMemoryRepository() Repository {
+	return &userMemoryRepository{
+		users:      make(map[uint64]*User),
+		companions: make(map[uint64]*Companion),
+		changes:    make(chan struct{}, 1),
+	}
+}
+
+// MemoryChanges returns a channel receiving a value after changes are
+// written to repo, made by NewUserMemoryRepository, the way the database
+// announces them on ChangesChannel. Changes written before the value is
+// received share it. The channel is nil for other repositories.
+func MemoryChanges(repo Repository) <-chan struct{} {
+	if r, ok := repo.(*userMemoryRepository); ok {
+		return r.changes
+	}
+	return nil
+}
+
+// userColumns copy a column from one guest to another, the way PatchUser
+// writes them.
+var userColumns = map[string]func(dst, src *User){
+	"status":                func(dst, src *User) { dst.Status = src.Status },
+	"company":               func(dst, src *User) { dst.Company = src.Company },
+	"surname":               func(dst, src *User) { dst.Surname = src.Surname },
+	"name":                  func(dst, src *User) { dst.Name = src.Name },
+	"guest":                 func(dst, src *User) { dst.Guest = src.Guest },
+	"rank":                  func(dst, src *User) { dst.Rank = src.Rank },
+	"contact_phone":         func(dst, src *User) { dst.ContactPhone = src.ContactPhone },
+	"contact_mail":          func(dst, src *User) { dst.ContactMail = src.ContactMail },
+	"max_companions":        func(dst, src *User) { dst.MaxCompanions = src.MaxCompanions },
+	"covid_pass":            func(dst, src *User) { dst.CovidPass = src.CovidPass },
+	"covid_pass_test_date":  func(dst, src *User) { dst.CovidPassTestDate = src.CovidPassTestDate },
+	"covid_pass_expires_at": func(dst, src *User) { dst.CovidPassExpiresAt = src.CovidPassExpiresAt },
+	"covid_pass_number":     func(dst, src *User) { dst.CovidPassNumber = src.CovidPassNumber },
+	"covid_pass_check":      func(dst, src *User) { dst.CovidPassCheck = src.CovidPassCheck },
+	"checkin_at":            func(dst, src *User) { dst.CheckinAt = src.CheckinAt },
+	"checkin_by":            func(dst, src *User) { dst.CheckinBy = src.CheckinBy },
+	"entrance":              func(dst, src *User) { dst.Entrance = src.Entrance },
+	"checkout_at":           func(dst, src *User) { dst.CheckoutAt = src.CheckoutAt },
+	"checkout_by":           func(dst, src *User) { dst.CheckoutBy = src.CheckoutBy },
+}
+
+// companionColumns are the userColumns of companions.
+var companionColumns = map[string]func(dst, src *Companion){
+	"surname":               func(dst, src *Companion) { dst.Surname = src.Surname },
+	"name":                  func(dst, src *Companion) { dst.Name = src.Name },
+	"covid_pass":            func(dst, src *Companion) { dst.CovidPass = src.CovidPass },
+	"covid_pass_test_date":  func(dst, src *Companion) { dst.CovidPassTestDate = src.CovidPassTestDate },
+	"covid_pass_expires_at": func(dst, src *Companion) { dst.CovidPassExpiresAt = src.CovidPassExpiresAt },
+	"covid_pass_number":     func(dst, src *Companion) { dst.CovidPassNumber = src.CovidPassNumber },
+	"checkin_at":            func(dst, src *Companion) { dst.CheckinAt = src.CheckinAt },
+	"checkin_by":            func(dst, src *Companion) { dst.CheckinBy = src.CheckinBy },
+	"entrance":              func(dst, src *Companion) { dst.Entrance = src.Entrance },
+	"checkout_at":           func(dst, src *Companion) { dst.CheckoutAt = src.CheckoutAt },
+	"checkout_by":           func(dst, src *Companion) { dst.CheckoutBy = src.CheckoutBy },
+}
+
+func (r *userMemoryRepository) SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	if q.Limit <= 0 {
+		return nil, errors.New("search limit must be positive")
+	}
+
+	terms := make([]string, 0, len(q.Terms))
+	for _, term := range q.Terms {
+		terms = append(terms, NormalizeSearch(term))
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []rankedUser
+	for _, u := range r.users {
+		if u.EventID != q.EventID || u.DeletedAt.Valid {
+			continue
+		}
+		var rank float32
+		text := searchText(u)
+		for _, term := range terms {
+			if strings.Contains(text, term) {
+				rank++
+			}
+		}
+		if len(terms) > 0 && rank == 0 {
+			continue
+		}
+		records = append(records, rankedUser{User: *u, Rank: rank})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Rank != records[j].Rank {
+			return records[i].Rank > records[j].Rank
+		}
+		return records[i].ID < records[j].ID
+	})
+
+	result := SearchResult{Total: int64(len(records))}
+	if q.After != nil {
+		after := *q.After
+		i := sort.Search(len(records), func(i int) bool {
+			return records[i].Rank < after.Rank || (records[i].Rank == after.Rank && records[i].ID > after.ID)
+		})
+		records = records[i:]
+	}
+	if len(records) > q.Limit {
+		records = records[:q.Limit]
+		last := records[len(records)-1]
+		result.Next = &Cursor{Rank: last.Rank, ID: last.ID}
+	}
+	for i := range records {
+		result.Users = append(result.Users, r.read(&records[i].User))
+	}
+
+	return &result, nil
+}
+
+func (r *userMemoryRepository) UpdateUser(ctx context.Context, data *User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.find(data.EventID, data.ID)
+	if err != nil {
+		return err
+	}
+	if stored.Version != data.Version {
+		return &ConflictError{Current: cloneUser(stored)}
+	}
+	old := *cloneUser(stored)
+	record := cloneUser(stored)
+
+	record.CovidPass = data.CovidPass
+	record.CovidPassTestDate = data.CovidPassTestDate
+	record.CovidPassExpiresAt = data.CovidPassExpiresAt
+	record.CovidPassNumber = data.CovidPassNumber
+	if record.CovidPass != old.CovidPass || record.CovidPassNumber != old.CovidPassNumber {
+		// a hand picked pass no longer is the one checked
+		record.CovidPassCheck = nil
+	}
+	record.Checkin = data.Checkin
+	record.Entrance = data.Entrance
+	stampCheckin(ctx, &old, record, time.Now())
+	record.Version = old.Version + 1
+
+	r.store(record)
+	r.writeAudit(userAudit(ctx, ActionUpdate, &old, record))
+	*data = *r.read(record)
+	return nil
+}
+
+// UpsertUsers matches imported guests like the database repository does,
+// a failed batch leaves no guest changed.
+func (r *userMemoryRepository) UpsertUsers(ctx context.Context, data []*User) (created int, updated int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the guests as they were before the batch, nil for created ones
+	saved := make(map[uint64]*User)
+	lastID := r.lastUserID
+	audited := len(r.audit)
+	defer func() {
+		if err == nil {
+			return
+		}
+		r.audit = r.audit[:audited]
+		for id, u := range saved {
+			if u == nil {
+				delete(r.users, id)
+			} else {
+				r.users[id] = u
+			}
+		}
+		r.lastUserID = lastID
+		created, updated = 0, 0
+	}()
+
+	for _, item := range data {
+		record := r.match(item)
+		if record == nil {
+			if err := r.insert(item); err != nil {
+				return 0, 0, err
+			}
+			saved[item.ID] = nil
+			item.Version = 1
+			r.writeAudit(userAudit(ctx, ActionCreate, nil, item))
+			created++
+			continue
+		}
+
+		if _, ok := saved[record.ID]; !ok {
+			saved[record.ID] = record
+		}
+		old := record
+		record = cloneUser(record)
+		record.Status = item.Status
+		record.Company = item.Company
+		record.Surname = item.Surname
+		record.Name = item.Name
+		record.Guest = item.Guest
+		record.Rank = item.Rank
+		record.ContactPhone = item.ContactPhone
+		record.ContactMail = item.ContactMail
+		record.Version++
+		r.store(record)
+		r.writeAudit(userAudit(ctx, ActionUpdate, old, record))
+		item.ID = record.ID
+		updated++
+	}
+
+	return created, updated, nil
+}
+
+// match finds the guest an imported one stands for, the oldest first.
+func (r *userMemoryRepository) match(item *User) *User {
+	var found *User
+	for _, u := range r.users {
+		if u.EventID != item.EventID || u.DeletedAt.Valid ||
+			!strings.EqualFold(u.Surname, item.Surname) ||
+			!strings.EqualFold(u.Name, item.Name) ||
+			!strings.EqualFold(u.Company, item.Company) {
+			continue
+		}
+		if found == nil || u.ID < found.ID {
+			found = u
+		}
+	}
+	return found
+}
+
+// IterateUsers calls fn for a snapshot of the matching guests, so fn may
+// take its time without holding writes back.
+func (r *userMemoryRepository) IterateUsers(ctx context.Context, filter Filter, fn func(*User) error) error {
+	r.mu.RLock()
+	var records []*User
+	for _, u := range r.users {
+		if u.EventID != filter.EventID || u.DeletedAt.Valid {
+			continue
+		}
+		if filter.Checkin != nil && u.Checkin != *filter.Checkin {
+			continue
+		}
+		if filter.Company != "" && !strings.EqualFold(u.Company, filter.Company) {
+			continue
+		}
+		if filter.Status != "" && !strings.EqualFold(u.Status, filter.Status) {
+			continue
+		}
+		records = append(records, cloneUser(u))
+	}
+	r.mu.RUnlock()
+
+	sortUsers(records)
+	for _, u := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *userMemoryRepository) CreateUser(ctx context.Context, data *User) error {
+	stampCheckin(ctx, &User{}, data, time.Now())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.insert(data); err != nil {
+		return err
+	}
+	data.Version = 1
+	r.writeAudit(userAudit(ctx, ActionCreate, nil, data))
+	return nil
+}
+
+func (r *userMemoryRepository) GetUser(ctx context.Context, eventID uint64, id uint64) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, err := r.find(eventID, id)
+	if err != nil {
+		return nil, err
+	}
+	return r.read(record), nil
+}
+
+// PatchUser writes only the given columns of data, zero values included.
+func (r *userMemoryRepository) PatchUser(ctx context.Context, data *User, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, err := r.find(data.EventID, data.ID)
+	if err != nil {
+		return err
+	}
+	return r.patch(ctx, cloneUser(record), data, columns)
+}
+
+// patch writes the columns of data onto old, the guest as it is stored.
+func (r *userMemoryRepository) patch(ctx context.Context, old *User, data *User, columns []string) error {
+	changed := append(writableColumns(columns), stampCheckin(ctx, old, data, time.Now())...)
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := checkColumns(changed); err != nil {
+		return err
+	}
+
+	record := cloneUser(old)
+	for _, c := range changed {
+		userColumns[c](record, data)
+	}
+	record.Version = old.Version + 1
+	data.Version = record.Version
+
+	r.store(record)
+	r.writeAudit(userAudit(ctx, ActionPatch, old, data))
+	return nil
+}
+
+// DeleteUser marks the guest as deleted, it is kept for history.
+func (r *userMemoryRepository) DeleteUser(ctx context.Context, eventID uint64, id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, err := r.find(eventID, id)
+	if err != nil {
+		return err
+	}
+	record.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	r.writeAudit(userAudit(ctx, ActionDelete, record, nil))
+	return nil
+}
+
+// GetUserHistory returns audit records of a guest, deleted ones included, oldest first.
+func (r *userMemoryRepository) GetUserHistory(ctx context.Context, eventID uint64, id uint64) ([]*Audit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*Audit
+	for _, a := range r.audit {
+		if a.EventID == eventID && a.UserID == id {
+			record := *a
+			records = append(records, &record)
+		}
+	}
+	return records, nil
+}
+
+// GetUserByToken finds a guest of the event by the invitation token.
+func (r *userMemoryRepository) GetUserByToken(ctx context.Context, eventID uint64, token string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, err := r.findByToken(eventID, token)
+	if err != nil {
+		return nil, err
+	}
+	return r.read(record), nil
+}
+
+// CheckinByToken checks in the guest holding the invitation token, the
+// guest is returned together with ErrAlreadyCheckedIn when it was checked
+// in before.
+func (r *userMemoryRepository) CheckinByToken(ctx context.Context, eventID uint64, token string, entrance string) (*User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.findByToken(eventID, token)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Checkin {
+		return r.read(stored), ErrAlreadyCheckedIn
+	}
+	old := *cloneUser(stored)
+	record := cloneUser(stored)
+
+	record.Checkin = true
+	record.Entrance = entrance
+	stampCheckin(ctx, &old, record, time.Now())
+	record.Version = old.Version + 1
+
+	r.store(record)
+	r.writeAudit(userAudit(ctx, ActionCheckin, &old, record))
+	return r.read(record), nil
+}
+
+// PatchUsers writes the columns patch returns for each picked guest, see
+// the database repository for the rules.
+func (r *userMemoryRepository) PatchUsers(ctx context.Context, q BulkQuery, atomic bool, patch func(*User) ([]string, error)) ([]*BulkResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[uint64]bool, len(q.IDs))
+	for _, id := range q.IDs {
+		ids[id] = true
+	}
+	var records []*User
+	for _, u := range r.users {
+		if u.EventID != q.EventID || u.DeletedAt.Valid {
+			continue
+		}
+		if len(q.IDs) > 0 {
+			if !ids[u.ID] {
+				continue
+			}
+		} else if q.Company != "" && !strings.EqualFold(u.Company, q.Company) ||
+			q.Guest != "" && !strings.EqualFold(u.Guest, q.Guest) {
+			continue
+		}
+		records = append(records, u)
+	}
+	if q.Limit > 0 && len(records) > q.Limit {
+		return nil, ErrBulkTooLarge
+	}
+	sortUsers(records)
+	for i, u := range records {
+		records[i] = r.read(u)
+	}
+
+	results := bulkResults(q.IDs, records)
+	olds, columns := patchBulk(results, atomic, patch)
+	// nothing is written when any guest is patched with an unknown column
+	for _, res := range results {
+		if res.Err == nil {
+			if err := checkColumns(writableColumns(columns[res.ID])); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, res := range results {
+		if res.Err != nil {
+			continue
+		}
+		old := olds[res.ID]
+		if err := r.patch(ctx, &old, res.User, columns[res.ID]); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// AddCompanion adds a companion to the guest c.UserID of the event, unless
+// the party is full.
+func (r *userMemoryRepository) AddCompanion(ctx context.Context, c *Companion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	owner, err := r.find(c.EventID, c.UserID)
+	if err != nil {
+		return err
+	}
+	if count := len(r.party(owner.ID)); count >= owner.MaxCompanions {
+		return errors.Wrapf(ErrPartyFull, "at most %d companions", owner.MaxCompanions)
+	}
+
+	p := c.Person()
+	stampCheckin(ctx, &User{}, p, time.Now())
+	c.SetPerson(p)
+
+	if c.ID == 0 {
+		c.ID = r.lastCompanionID + 1
+	} else if _, ok := r.companions[c.ID]; ok {
+		return errors.Errorf("companion %d already exists", c.ID)
+	}
+	if c.ID > r.lastCompanionID {
+		r.lastCompanionID = c.ID
+	}
+	c.CreatedAt = time.Now()
+	r.storeCompanion(c)
+
+	r.writeAudit(companionAudit(ctx, owner, nil, c))
+	return nil
+}
+
+// PatchCompanion writes only the given columns of c, zero values included.
+func (r *userMemoryRepository) PatchCompanion(ctx context.Context, c *Companion, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.findCompanion(c.EventID, c.UserID, c.ID)
+	if err != nil {
+		return err
+	}
+	old := *stored
+
+	p := c.Person()
+	changed := append(writableColumns(columns), stampCheckin(ctx, old.Person(), p, time.Now())...)
+	c.SetPerson(p)
+	if len(changed) == 0 {
+		return nil
+	}
+	for _, col := range changed {
+		if companionColumns[col] == nil {
+			return errors.Errorf("unknown companion column %q", col)
+		}
+	}
+
+	record := old
+	for _, col := range changed {
+		companionColumns[col](&record, c)
+	}
+	r.storeCompanion(&record)
+
+	r.writeAudit(companionAudit(ctx, &User{ID: c.UserID, EventID: c.EventID}, &old, c))
+	return nil
+}
+
+// DeleteCompanion removes a companion from the party of the guest.
+func (r *userMemoryRepository) DeleteCompanion(ctx context.Context, eventID uint64, userID uint64, id uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, err := r.findCompanion(eventID, userID, id)
+	if err != nil {
+		return err
+	}
+	record.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	r.writeAudit(companionAudit(ctx, &User{ID: userID, EventID: eventID}, record, nil))
+	return nil
+}
+
+// ListChanges returns changes in sequence order.
+func (r *userMemoryRepository) ListChanges(ctx context.Context, q ChangeQuery) ([]*Change, error) {
+	if q.Limit <= 0 {
+		return nil, errors.New("changes limit must be positive")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// audit ids follow the position in the trail
+	start := q.After
+	if start > uint64(len(r.audit)) {
+		start = uint64(len(r.audit))
+	}
+	var records []*Audit
+	for _, a := range r.audit[start:] {
+		if q.EventID != 0 && a.EventID != q.EventID {
+			continue
+		}
+		records = append(records, a)
+		if len(records) == q.Limit {
+			break
+		}
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var users []*User
+	for _, a := range records {
+		if u, ok := r.users[a.UserID]; ok && !u.DeletedAt.Valid {
+			users = append(users, r.read(u))
+		}
+	}
+
+	return changesOf(records, users), nil
+}
+
+// LastChangeSeq returns the sequence number of the latest change, zero
+// when there is none.
+func (r *userMemoryRepository) LastChangeSeq(ctx context.Context) (uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return uint64(len(r.audit)), nil
+}
+
+// find returns the stored guest of the event unless it is deleted.
+func (r *userMemoryRepository) find(eventID uint64, id uint64) (*User, error) {
+	u, ok := r.users[id]
+	if !ok || u.EventID != eventID || u.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *userMemoryRepository) findByToken(eventID uint64, token string) (*User, error) {
+	for _, u := range r.users {
+		if u.EventID == eventID && u.InviteToken == token && !u.DeletedAt.Valid {
+			return u, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// insert stores a new guest, issuing the id and invitation token the
+// database would.
+func (r *userMemoryRepository) insert(u *User) error {
+	if u.InviteToken == "" {
+		token, err := NewInviteToken()
+		if err != nil {
+			return err
+		}
+		u.InviteToken = token
+	}
+	// the token index of the database covers deleted guests too
+	for _, other := range r.users {
+		if other.InviteToken == u.InviteToken {
+			return errors.Errorf("invite token of guest %d is taken", other.ID)
+		}
+	}
+
+	if u.ID == 0 {
+		u.ID = r.lastUserID + 1
+	} else if _, ok := r.users[u.ID]; ok {
+		return errors.Errorf("guest %d already exists", u.ID)
+	}
+	if u.ID > r.lastUserID {
+		r.lastUserID = u.ID
+	}
+
+	record := cloneUser(u)
+	record.Version = 1
+	r.store(record)
+	return nil
+}
+
+// store keeps u, deriving checkin from its check-in record.
+func (r *userMemoryRepository) store(u *User) {
+	u.Checkin = u.CheckinAt != nil && u.CheckoutAt == nil
+	r.users[u.ID] = u
+}
+
+// read returns a copy of the stored guest u with its companions.
+func (r *userMemoryRepository) read(u *User) *User {
+	record := cloneUser(u)
+	for _, c := range r.party(u.ID) {
+		companion := *c
+		record.Companions = append(record.Companions, &companion)
+	}
+	return record
+}
+
+// party lists the companions of the guest, oldest first.
+func (r *userMemoryRepository) party(userID uint64) []*Companion {
+	var party []*Companion
+	for _, c := range r.companions {
+		if c.UserID == userID && !c.DeletedAt.Valid {
+			party = append(party, c)
+		}
+	}
+	sort.Slice(party, func(i, j int) bool { return party[i].ID < party[j].ID })
+	return party
+}
+
+func (r *userMemoryRepository) findCompanion(eventID uint64, userID uint64, id uint64) (*Companion, error) {
+	c, ok := r.companions[id]
+	if !ok || c.EventID != eventID || c.UserID != userID || c.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	return c, nil
+}
+
+// storeCompanion keeps a copy of c, deriving checkin from its check-in
+// record.
+func (r *userMemoryRepository) storeCompanion(c *Companion) {
+	record := *c
+	record.Checkin = record.CheckinAt != nil && record.CheckoutAt == nil
+	r.companions[record.ID] = &record
+}
+
+// writeAudit appends the record to the trail, numbering it like the
+// database sequence.
+func (r *userMemoryRepository) writeAudit(record *Audit) {
+	if record == nil {
+		return
+	}
+	record.ID = uint64(len(r.audit)) + 1
+	record.CreatedAt = time.Now()
+	r.audit = append(r.audit, record)
+
+	select {
+	case r.changes <- struct{}{}:
+	default:
+	}
+}
+
+// checkColumns fails on columns the memory repository does not know.
+func checkColumns(columns []string) error {
+	for _, c := range columns {
+		if userColumns[c] == nil {
+			return errors.Errorf("unknown column %q", c)
+		}
+	}
+	return nil
+}
+
+// cloneUser copies u without its companions, which are stored apart.
+func cloneUser(u *User) *User {
+	record := *u
+	if u.CovidPassCheck != nil {
+		check := *u.CovidPassCheck
+		record.CovidPassCheck = &check
+	}
+	record.Companions = nil
+	return &record
+}
+
+func sortUsers(users []*User) {
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+}
+
+// searchText folds the searched columns of u the way the database builds
+// its search column.
+func searchText(u *User) string {
+	return NormalizeSearch(strings.Join([]string{u.Surname, u.Name, u.Company, u.Guest, u.ContactPhone}, " "))
+}
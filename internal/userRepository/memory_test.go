@@ -0,0 +1,141 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySearchUsers(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserMemoryRepository()
+
+	for _, u := range []*User{
+		{EventID: 1, Surname: "Иванов", Name: "Иван", Company: "ACME"},
+		{EventID: 1, Surname: "Петров", Name: "Пётр", Company: "Acme"},
+		{EventID: 1, Surname: "Сидоров", Name: "Иван", Company: "Globex"},
+		{EventID: 2, Surname: "Иванов", Name: "Иван", Company: "ACME"},
+	} {
+		require.NoError(t, repo.CreateUser(ctx, u))
+	}
+
+	result, err := repo.SearchUsers(ctx, SearchQuery{EventID: 1, Terms: []string{"иван", "acme"}, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, uint64(1), result.Users[0].ID)
+	require.NotNil(t, result.Next)
+
+	result, err = repo.SearchUsers(ctx, SearchQuery{EventID: 1, Terms: []string{"иван", "acme"}, Limit: 5, After: result.Next})
+	require.NoError(t, err)
+	var ids []uint64
+	for _, u := range result.Users {
+		ids = append(ids, u.ID)
+	}
+	assert.Equal(t, []uint64{2, 3}, ids)
+	assert.Nil(t, result.Next)
+
+	result, err = repo.SearchUsers(ctx, SearchQuery{EventID: 1, Terms: []string{"петр"}, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "Петров", result.Users[0].Surname)
+}
+
+func TestMemoryUpdateUser(t *testing.T) {
+	ctx := WithActor(context.Background(), Actor{Name: "door1"})
+	repo := NewUserMemoryRepository()
+
+	u := &User{EventID: 1, Surname: "Иванов"}
+	require.NoError(t, repo.CreateUser(ctx, u))
+	assert.NotEmpty(t, u.InviteToken)
+
+	err := repo.UpdateUser(ctx, &User{ID: 7, EventID: 1, Version: 1})
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = repo.UpdateUser(ctx, &User{ID: u.ID, EventID: 2, Version: 1})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	update := &User{ID: u.ID, EventID: 1, Version: 1, Checkin: true, Entrance: "north"}
+	require.NoError(t, repo.UpdateUser(ctx, update))
+	assert.True(t, update.Checkin)
+	assert.Equal(t, uint64(2), update.Version)
+	assert.Equal(t, "door1", update.CheckinBy)
+
+	err = repo.UpdateUser(ctx, &User{ID: u.ID, EventID: 1, Version: 1})
+	var conflict *ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, uint64(2), conflict.Current.Version)
+
+	got, err := repo.CheckinByToken(ctx, 1, u.InviteToken, "south")
+	assert.ErrorIs(t, err, ErrAlreadyCheckedIn)
+	assert.Equal(t, "north", got.Entrance)
+
+	require.NoError(t, repo.DeleteUser(ctx, 1, u.ID))
+	_, err = repo.GetUser(ctx, 1, u.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	history, err := repo.GetUserHistory(ctx, 1, u.ID)
+	require.NoError(t, err)
+	var actions []string
+	for _, a := range history {
+		actions = append(actions, a.Action)
+	}
+	assert.Equal(t, []string{ActionCreate, ActionUpdate, ActionDelete}, actions)
+
+	changes, err := repo.ListChanges(ctx, ChangeQuery{After: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, uint64(2), changes[0].Seq)
+	assert.Nil(t, changes[1].User)
+}
+
+func TestMemoryUpsertUsers(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserMemoryRepository()
+
+	require.NoError(t, repo.CreateUser(ctx, &User{EventID: 1, Surname: "Иванов", Name: "Иван", Company: "ACME", InviteToken: "taken"}))
+
+	created, updated, err := repo.UpsertUsers(ctx, []*User{
+		{EventID: 1, Surname: "иванов", Name: "иван", Company: "acme", Rank: "vip"},
+		{EventID: 1, Surname: "Петров"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, updated)
+
+	u, err := repo.GetUser(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "vip", u.Rank)
+	assert.Equal(t, uint64(2), u.Version)
+
+	history, err := repo.GetUserHistory(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, ActionUpdate, history[1].Action)
+	assert.Equal(t, "vip", history[1].NewValues["rank"])
+	seq, err := repo.LastChangeSeq(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+
+	// a failed batch leaves every guest as it was
+	_, _, err = repo.UpsertUsers(ctx, []*User{
+		{EventID: 1, Surname: "Иванов", Name: "Иван", Company: "ACME"},
+		{EventID: 1, Surname: "Сидоров", InviteToken: "taken"},
+	})
+	assert.Error(t, err)
+	u, err = repo.GetUser(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "vip", u.Rank)
+	seq, err = repo.LastChangeSeq(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seq)
+
+	var surnames []string
+	require.NoError(t, repo.IterateUsers(ctx, Filter{EventID: 1}, func(u *User) error {
+		surnames = append(surnames, u.Surname)
+		return nil
+	}))
+	assert.Equal(t, []string{"иванов", "Петров"}, surnames)
+}
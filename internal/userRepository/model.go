@@ -1,19 +1,100 @@
 package userRepository
 
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User is an invited guest. Checkin is derived from the check-in record by
+// the database, repository updates treat it as the requested state.
+// CovidPass holds one of the Pass types, CovidPassCheck the last scanned
+// certificate.
 type User struct {
-	ID           uint64 `gorm:"primary_key"`
-	Status       string
-	Company      string
-	Surname      string
-	Name         string
-	Guest        string
-	CovidPass    string
-	Rank         string
-	ContactPhone string
-	ContactMail  string
-	Checkin      bool
+	ID                 uint64 `gorm:"primary_key"`
+	EventID            uint64
+	Status             string
+	Company            string
+	Surname            string
+	Name               string
+	Guest              string
+	CovidPass          string
+	CovidPassTestDate  *time.Time
+	CovidPassExpiresAt *time.Time
+	CovidPassNumber    string
+	CovidPassCheck     *PassCheck
+	Rank               string
+	ContactPhone       string
+	ContactMail        string
+	Checkin            bool `gorm:"->"`
+	CheckinAt          *time.Time
+	CheckinBy          string
+	Entrance           string
+	CheckoutAt         *time.Time
+	CheckoutBy         string
+	InviteToken        string
+	Version            uint64 `gorm:"->"`
+	MaxCompanions      int
+	// Companions are loaded by the methods returning a guest to show, they
+	// are never written with it.
+	Companions []*Companion `gorm:"-"`
+	DeletedAt  gorm.DeletedAt
 }
 
 func (User) TableName() string {
 	return "users"
 }
+
+// BeforeCreate issues an invitation token to guests created without one.
+func (u *User) BeforeCreate(*gorm.DB) error {
+	if u.InviteToken != "" {
+		return nil
+	}
+	token, err := NewInviteToken()
+	if err != nil {
+		return err
+	}
+	u.InviteToken = token
+	return nil
+}
+
+// NewInviteToken returns a random url safe token for an invitation QR code.
+func NewInviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// stampCheckin fills the check-in record of u when the requested check-in
+// state differs from old and returns the changed columns. Checking in starts
+// a new record at the entrance set on u, checking out closes the current one.
+func stampCheckin(ctx context.Context, old, u *User, at time.Time) []string {
+	entrance := u.Entrance
+	u.Entrance = old.Entrance
+	if old.Checkin == u.Checkin {
+		return nil
+	}
+
+	actor := ActorFromContext(ctx).Name
+	if !u.Checkin {
+		u.CheckoutAt, u.CheckoutBy = &at, actor
+		return []string{"checkout_at", "checkout_by"}
+	}
+
+	u.CheckinAt, u.CheckinBy, u.Entrance = &at, actor, entrance
+	u.CheckoutAt, u.CheckoutBy = nil, ""
+	return []string{"checkin_at", "checkin_by", "entrance", "checkout_at", "checkout_by"}
+}
+
+// Filter narrows down guests for listing operations.
+type Filter struct {
+	EventID uint64
+	Checkin *bool
+	Company string
+	Status  string
+}
@@ -0,0 +1,57 @@
+package userRepository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeforeCreateIssuesToken(t *testing.T) {
+	var u User
+	require.NoError(t, u.BeforeCreate(nil))
+	assert.Len(t, u.InviteToken, 22)
+
+	other := User{InviteToken: "kept"}
+	require.NoError(t, other.BeforeCreate(nil))
+	assert.Equal(t, "kept", other.InviteToken)
+
+	var next User
+	require.NoError(t, next.BeforeCreate(nil))
+	assert.NotEqual(t, u.InviteToken, next.InviteToken)
+}
+
+func TestStampCheckin(t *testing.T) {
+	ctx := WithActor(context.Background(), Actor{Name: "door1"})
+	at := time.Date(2021, 9, 14, 19, 42, 0, 0, time.UTC)
+
+	old := User{Entrance: "A"}
+	u := User{Checkin: true, Entrance: "B"}
+	assert.Equal(t, []string{"checkin_at", "checkin_by", "entrance", "checkout_at", "checkout_by"},
+		stampCheckin(ctx, &old, &u, at))
+	assert.Equal(t, &at, u.CheckinAt)
+	assert.Equal(t, "door1", u.CheckinBy)
+	assert.Equal(t, "B", u.Entrance)
+
+	again := u
+	again.Entrance = "C"
+	assert.Empty(t, stampCheckin(ctx, &u, &again, at.Add(time.Hour)))
+	assert.Equal(t, &at, again.CheckinAt)
+	assert.Equal(t, "B", again.Entrance)
+
+	out := u
+	out.Checkin = false
+	later := at.Add(time.Hour)
+	assert.Equal(t, []string{"checkout_at", "checkout_by"}, stampCheckin(ctx, &u, &out, later))
+	assert.Equal(t, &at, out.CheckinAt)
+	assert.Equal(t, &later, out.CheckoutAt)
+	assert.Equal(t, "B", out.Entrance)
+
+	back := out
+	back.Checkin = true
+	stampCheckin(ctx, &out, &back, later)
+	assert.Nil(t, back.CheckoutAt)
+	assert.Empty(t, back.CheckoutBy)
+}
@@ -0,0 +1,123 @@
+package userRepository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nakiner/guestcovider/internal/database"
+	"github.com/pkg/errors"
+)
+
+// searchThreshold is the lowest pg_trgm word similarity of a match,
+// low enough to let a typo or two through.
+const searchThreshold = 0.3
+
+// SearchQuery selects a page of guests matching any of the terms, best
+// matches first. No terms list every guest of the event in id order.
+type SearchQuery struct {
+	EventID uint64
+	Terms   []string
+	Limit   int
+	After   *Cursor
+}
+
+// Cursor points at the last guest of a page.
+type Cursor struct {
+	Rank float32
+	ID   uint64
+}
+
+type SearchResult struct {
+	Users []*User
+	Total int64
+	// Next is set when there are more guests after this page.
+	Next *Cursor
+}
+
+type rankedUser struct {
+	User `gorm:"embedded"`
+	Rank float32
+}
+
+// SearchUsers matches the terms against surname, name, company, guest and
+// phone with pg_trgm word similarity or as a substring, and ranks guests by
+// the best similarity of any term.
+func (r *userDBRepository) SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	if q.Limit <= 0 {
+		return nil, errors.New("search limit must be positive")
+	}
+
+	conn, err := database.GetReplicaConn(ctx, r.dbConn)
+
+	if err != nil {
+		return nil, errors.Wrap(ConnError, err.Error())
+	}
+
+	rank := "0::real"
+	filter := "event_id = ? AND deleted_at IS NULL"
+	var rankArgs []interface{}
+	filterArgs := []interface{}{q.EventID}
+
+	if len(q.Terms) > 0 {
+		var ranks, matches []string
+		for _, term := range q.Terms {
+			term = NormalizeSearch(term)
+			ranks = append(ranks, "word_similarity(?, search_text)")
+			rankArgs = append(rankArgs, term)
+			matches = append(matches, "word_similarity(?, search_text) >= ? OR search_text LIKE ?")
+			filterArgs = append(filterArgs, term, searchThreshold, "%"+escapeLike(term)+"%")
+		}
+		rank = "greatest(" + strings.Join(ranks, ", ") + ")"
+		filter += " AND (" + strings.Join(matches, " OR ") + ")"
+	}
+
+	result := SearchResult{}
+
+	err = database.Retry(ctx, func() error {
+		return conn.Raw("SELECT count(*) FROM users WHERE "+filter, filterArgs...).Scan(&result.Total).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sql := "SELECT * FROM (SELECT *, " + rank + " AS rank FROM users WHERE " + filter + ") s"
+	args := append(rankArgs, filterArgs...)
+	if q.After != nil {
+		sql += " WHERE rank < ? OR (rank = ? AND id > ?)"
+		args = append(args, q.After.Rank, q.After.Rank, q.After.ID)
+	}
+	sql += " ORDER BY rank DESC, id LIMIT ?"
+	args = append(args, q.Limit+1)
+
+	var records []rankedUser
+	err = database.Retry(ctx, func() error {
+		records = nil
+		return conn.Raw(sql, args...).Scan(&records).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > q.Limit {
+		records = records[:q.Limit]
+		last := records[len(records)-1]
+		result.Next = &Cursor{Rank: last.Rank, ID: last.ID}
+	}
+	for i := range records {
+		result.Users = append(result.Users, &records[i].User)
+	}
+	if err := loadCompanions(conn, result.Users...); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// NormalizeSearch folds a term the same way the search column is built.
+func NormalizeSearch(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "ё", "е")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
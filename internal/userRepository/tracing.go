@@ -16,10 +16,10 @@ type tracingRepository struct {
 	Repository
 }
 
-func (r *tracingRepository) FindBySurname(ctx context.Context, surname string) ([]*User, error) {
-	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "FindBySurname")
+func (r *tracingRepository) SearchUsers(ctx context.Context, q SearchQuery) (*SearchResult, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "SearchUsers")
 	defer span.Finish()
-	return r.Repository.FindBySurname(ctx, surname)
+	return r.Repository.SearchUsers(ctx, q)
 }
 
 func (r *tracingRepository) UpdateUser(ctx context.Context, data *User) error {
@@ -27,3 +27,93 @@ func (r *tracingRepository) UpdateUser(ctx context.Context, data *User) error {
 	defer span.Finish()
 	return r.Repository.UpdateUser(ctx, data)
 }
+
+func (r *tracingRepository) UpsertUsers(ctx context.Context, data []*User) (int, int, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "UpsertUsers")
+	defer span.Finish()
+	return r.Repository.UpsertUsers(ctx, data)
+}
+
+func (r *tracingRepository) IterateUsers(ctx context.Context, filter Filter, fn func(*User) error) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "IterateUsers")
+	defer span.Finish()
+	return r.Repository.IterateUsers(ctx, filter, fn)
+}
+
+func (r *tracingRepository) CreateUser(ctx context.Context, data *User) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CreateUser")
+	defer span.Finish()
+	return r.Repository.CreateUser(ctx, data)
+}
+
+func (r *tracingRepository) GetUser(ctx context.Context, eventID uint64, id uint64) (*User, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetUser")
+	defer span.Finish()
+	return r.Repository.GetUser(ctx, eventID, id)
+}
+
+func (r *tracingRepository) PatchUser(ctx context.Context, data *User, columns []string) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "PatchUser")
+	defer span.Finish()
+	return r.Repository.PatchUser(ctx, data, columns)
+}
+
+func (r *tracingRepository) DeleteUser(ctx context.Context, eventID uint64, id uint64) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "DeleteUser")
+	defer span.Finish()
+	return r.Repository.DeleteUser(ctx, eventID, id)
+}
+
+func (r *tracingRepository) GetUserHistory(ctx context.Context, eventID uint64, id uint64) ([]*Audit, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetUserHistory")
+	defer span.Finish()
+	return r.Repository.GetUserHistory(ctx, eventID, id)
+}
+
+func (r *tracingRepository) GetUserByToken(ctx context.Context, eventID uint64, token string) (*User, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "GetUserByToken")
+	defer span.Finish()
+	return r.Repository.GetUserByToken(ctx, eventID, token)
+}
+
+func (r *tracingRepository) CheckinByToken(ctx context.Context, eventID uint64, token string, entrance string) (*User, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "CheckinByToken")
+	defer span.Finish()
+	return r.Repository.CheckinByToken(ctx, eventID, token, entrance)
+}
+
+func (r *tracingRepository) ListChanges(ctx context.Context, q ChangeQuery) ([]*Change, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "ListChanges")
+	defer span.Finish()
+	return r.Repository.ListChanges(ctx, q)
+}
+
+func (r *tracingRepository) LastChangeSeq(ctx context.Context) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "LastChangeSeq")
+	defer span.Finish()
+	return r.Repository.LastChangeSeq(ctx)
+}
+
+func (r *tracingRepository) PatchUsers(ctx context.Context, q BulkQuery, atomic bool, patch func(*User) ([]string, error)) ([]*BulkResult, error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "PatchUsers")
+	defer span.Finish()
+	return r.Repository.PatchUsers(ctx, q, atomic, patch)
+}
+
+func (r *tracingRepository) AddCompanion(ctx context.Context, c *Companion) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "AddCompanion")
+	defer span.Finish()
+	return r.Repository.AddCompanion(ctx, c)
+}
+
+func (r *tracingRepository) PatchCompanion(ctx context.Context, c *Companion, columns []string) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "PatchCompanion")
+	defer span.Finish()
+	return r.Repository.PatchCompanion(ctx, c, columns)
+}
+
+func (r *tracingRepository) DeleteCompanion(ctx context.Context, eventID uint64, userID uint64, id uint64) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, r.tracer, "DeleteCompanion")
+	defer span.Finish()
+	return r.Repository.DeleteCompanion(ctx, eventID, userID, id)
+}
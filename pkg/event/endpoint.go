@@ -0,0 +1,123 @@
+//go:generate easyjson -all endpoint.go
+package event
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	_ "github.com/mailru/easyjson/gen"
+)
+
+//easyjson:json
+type Status struct {
+	Status  bool   `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+//easyjson:json
+type Event struct {
+	Id             uint64   `json:"id,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Venue          string   `json:"venue,omitempty"`
+	StartsAt       string   `json:"startsAt,omitempty"`
+	EndsAt         string   `json:"endsAt,omitempty"`
+	CovidPassTypes []string `json:"covidPassTypes,omitempty"`
+}
+
+//easyjson:json
+type CreateEventRequest struct {
+	Name           string   `json:"name,omitempty"`
+	Venue          string   `json:"venue,omitempty"`
+	StartsAt       string   `json:"startsAt,omitempty"`
+	EndsAt         string   `json:"endsAt,omitempty"`
+	CovidPassTypes []string `json:"covidPassTypes,omitempty"`
+}
+
+//easyjson:json
+type CreateEventResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Event  *Event  `json:"event,omitempty"`
+}
+
+//easyjson:json
+type ListEventsRequest struct {
+}
+
+//easyjson:json
+type ListEventsResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   []Event `json:"data,omitempty"`
+}
+
+// UpdateEventRequest holds event fields to change, nil fields are left untouched.
+//
+//easyjson:json
+type UpdateEventRequest struct {
+	Id             uint64    `json:"id,omitempty"`
+	Name           *string   `json:"name,omitempty"`
+	Venue          *string   `json:"venue,omitempty"`
+	StartsAt       *string   `json:"startsAt,omitempty"`
+	EndsAt         *string   `json:"endsAt,omitempty"`
+	CovidPassTypes *[]string `json:"covidPassTypes,omitempty"`
+}
+
+//easyjson:json
+type UpdateEventResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Event  *Event  `json:"event,omitempty"`
+}
+
+//easyjson:skip
+type endpoints struct {
+	CreateEventEndpoint endpoint.Endpoint
+	ListEventsEndpoint  endpoint.Endpoint
+	UpdateEventEndpoint endpoint.Endpoint
+}
+
+func (e endpoints) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	response, err := e.CreateEventEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(CreateEventResponse)
+	return &r, err
+}
+
+func (e endpoints) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	response, err := e.ListEventsEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(ListEventsResponse)
+	return &r, err
+}
+
+func (e endpoints) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	response, err := e.UpdateEventEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(UpdateEventResponse)
+	return &r, err
+}
+
+func makeCreateEventEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateEventRequest)
+		return s.CreateEvent(ctx, &req)
+	}
+}
+
+func makeListEventsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListEventsRequest)
+		return s.ListEvents(ctx, &req)
+	}
+}
+
+func makeUpdateEventEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateEventRequest)
+		return s.UpdateEvent(ctx, &req)
+	}
+}
@@ -0,0 +1,87 @@
+package event
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvalidArgument is returned when one or more arguments are invalid.
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrBadRequest      = errors.New("bad request")
+	ErrNotFound        = errors.New("not found")
+	errBadRoute        = errors.New("bad route")
+	ErrInvalidRequest  = errors.New("invalid params in request")
+	// ErrPermissionDenied is returned when the role of the operator does not allow the call.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+type ContextHTTPKey struct{}
+
+type HTTPInfo struct {
+	Method   string
+	URL      string
+	From     string
+	Protocol string
+}
+
+type errorCode interface {
+	Code() int
+}
+
+// getHTTPStatusCode returns http status code from error.
+func getHTTPStatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	if e, ok := err.(errorCode); ok && e.Code() != 0 {
+		return e.Code()
+	}
+
+	switch errors.Cause(err) {
+	case ErrInvalidArgument:
+		return http.StatusBadRequest
+	case ErrAlreadyExists:
+		return http.StatusBadRequest
+	case ErrBadRequest:
+		return http.StatusBadRequest
+	case ErrNotFound:
+		return http.StatusNotFound
+	case ErrPermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// getGRPCCode returns grpc status code from error.
+func getGRPCCode(err error) codes.Code {
+	switch getHTTPStatusCode(err) {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcError turns an error from business-layer into a grpc status,
+// errors that already carry a status are returned as is.
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(getGRPCCode(err), err.Error())
+}
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/nakiner/guestcovider/internal/auth"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"google.golang.org/grpc"
+)
+
+// NewGRPCClient returns an Service backed by a gRPC server at the other end
+// of the conn. The caller is responsible for constructing the conn, and
+// eventually closing the underlying transport. We bake-in certain middlewares,
+// implementing the client library pattern.
+func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger log.Logger) Service {
+	// global client middlewares
+	options := []grpctransport.ClientOption{
+		grpctransport.ClientBefore(opentracing.ContextToGRPC(tracer, logger)),
+		grpctransport.ClientBefore(auth.ContextToGRPC()),
+	}
+
+	return endpoints{
+		// Each individual endpoint is an grpc/transport.Client (which implements
+		// endpoint.Endpoint) that gets wrapped with various middlewares. If you
+		// made your own client library, you'd do this work there, so your server
+		// could rely on a consistent set of client behavior.
+		CreateEventEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.EventService",
+			"CreateEvent",
+			encodeGRPCCreateEventRequest,
+			decodeGRPCCreateEventResponse,
+			pb.CreateEventResponse{},
+			options...,
+		).Endpoint(),
+		ListEventsEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.EventService",
+			"ListEvents",
+			encodeGRPCListEventsRequest,
+			decodeGRPCListEventsResponse,
+			pb.ListEventsResponse{},
+			options...,
+		).Endpoint(),
+		UpdateEventEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.EventService",
+			"UpdateEvent",
+			encodeGRPCUpdateEventRequest,
+			decodeGRPCUpdateEventResponse,
+			pb.UpdateEventResponse{},
+			options...,
+		).Endpoint(),
+	}
+}
+
+func encodeGRPCCreateEventRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*CreateEventRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateEventRequest wrong request")
+	}
+
+	return CreateEventRequestToPB(inReq), nil
+}
+
+func encodeGRPCListEventsRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*ListEventsRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCListEventsRequest wrong request")
+	}
+
+	return ListEventsRequestToPB(inReq), nil
+}
+
+func encodeGRPCUpdateEventRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*UpdateEventRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCUpdateEventRequest wrong request")
+	}
+
+	return UpdateEventRequestToPB(inReq), nil
+}
+
+func decodeGRPCCreateEventResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.CreateEventResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateEventResponse wrong response")
+	}
+
+	resp := PBToCreateEventResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCListEventsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.ListEventsResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCListEventsResponse wrong response")
+	}
+
+	resp := PBToListEventsResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCUpdateEventResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.UpdateEventResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCUpdateEventResponse wrong response")
+	}
+
+	resp := PBToUpdateEventResponse(inResp)
+
+	return *resp, nil
+}
@@ -0,0 +1,415 @@
+package event
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport/grpc"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"github.com/nakiner/guestcovider/tools/logging"
+	"github.com/nakiner/guestcovider/tools/tracing"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type grpcServer struct {
+	createEvent grpctransport.Handler
+	listEvents  grpctransport.Handler
+	updateEvent grpctransport.Handler
+}
+
+type ContextGRPCKey struct{}
+
+type GRPCInfo struct {
+	From string
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC eventServer.
+func NewGRPCServer(ctx context.Context, s Service) pb.EventServiceServer {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "grpc handler", "event")
+	tracer := tracing.FromContext(ctx)
+
+	options := []grpctransport.ServerOption{
+		// grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(grpcToContext()),
+		grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "grpc server", logger)),
+		grpctransport.ServerFinalizer(closeGRPCTracer()),
+	}
+
+	return &grpcServer{
+		createEvent: grpctransport.NewServer(
+			makeCreateEventEndpoint(s),
+			decodeGRPCCreateEventRequest,
+			encodeGRPCCreateEventResponse,
+			options...,
+		),
+		listEvents: grpctransport.NewServer(
+			makeListEventsEndpoint(s),
+			decodeGRPCListEventsRequest,
+			encodeGRPCListEventsResponse,
+			options...,
+		),
+		updateEvent: grpctransport.NewServer(
+			makeUpdateEventEndpoint(s),
+			decodeGRPCUpdateEventRequest,
+			encodeGRPCUpdateEventResponse,
+			options...,
+		),
+	}
+}
+
+func JoinGRPC(ctx context.Context, s Service) func(*googlegrpc.Server) {
+	return func(g *googlegrpc.Server) {
+		pb.RegisterEventServiceServer(g, NewGRPCServer(ctx, s))
+	}
+}
+
+func grpcToContext() grpc.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		var info GRPCInfo
+		if p, ok := peer.FromContext(ctx); ok {
+			info.From = p.Addr.String()
+		}
+		return context.WithValue(ctx, ContextGRPCKey{}, info)
+	}
+}
+
+func closeGRPCTracer() grpc.ServerFinalizerFunc {
+	return func(ctx context.Context, err error) {
+		span := stdopentracing.SpanFromContext(ctx)
+		span.Finish()
+	}
+}
+
+func (s *grpcServer) CreateEvent(ctx context.Context, req *pb.CreateEventRequest) (*pb.CreateEventResponse, error) {
+	_, rep, err := s.createEvent.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.CreateEventResponse), nil
+}
+
+func (s *grpcServer) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb.ListEventsResponse, error) {
+	_, rep, err := s.listEvents.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.ListEventsResponse), nil
+}
+
+func (s *grpcServer) UpdateEvent(ctx context.Context, req *pb.UpdateEventRequest) (*pb.UpdateEventResponse, error) {
+	_, rep, err := s.updateEvent.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.UpdateEventResponse), nil
+}
+
+func decodeGRPCCreateEventRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.CreateEventRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateEventRequest wrong request")
+	}
+
+	req := PBToCreateEventRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCListEventsRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.ListEventsRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCListEventsRequest wrong request")
+	}
+
+	req := PBToListEventsRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCUpdateEventRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.UpdateEventRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCUpdateEventRequest wrong request")
+	}
+
+	req := PBToUpdateEventRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func encodeGRPCCreateEventResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*CreateEventResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateEventResponse wrong response")
+	}
+
+	return CreateEventResponseToPB(inResp), nil
+}
+
+func encodeGRPCListEventsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*ListEventsResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCListEventsResponse wrong response")
+	}
+
+	return ListEventsResponseToPB(inResp), nil
+}
+
+func encodeGRPCUpdateEventResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*UpdateEventResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCUpdateEventResponse wrong response")
+	}
+
+	return UpdateEventResponseToPB(inResp), nil
+}
+
+func StatusToPB(d *Status) *pb.Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func PBToStatus(d *pb.Status) *Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func EventToPB(d *Event) *pb.Event {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Event{
+		Id:             d.Id,
+		Name:           d.Name,
+		Venue:          d.Venue,
+		StartsAt:       d.StartsAt,
+		EndsAt:         d.EndsAt,
+		CovidPassTypes: d.CovidPassTypes,
+	}
+
+	return &resp
+}
+
+func PBToEvent(d *pb.Event) *Event {
+	if d == nil {
+		return nil
+	}
+
+	resp := Event{
+		Id:             d.Id,
+		Name:           d.Name,
+		Venue:          d.Venue,
+		StartsAt:       d.StartsAt,
+		EndsAt:         d.EndsAt,
+		CovidPassTypes: d.CovidPassTypes,
+	}
+
+	return &resp
+}
+
+func CreateEventRequestToPB(d *CreateEventRequest) *pb.CreateEventRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateEventRequest{
+		Name:           d.Name,
+		Venue:          d.Venue,
+		StartsAt:       d.StartsAt,
+		EndsAt:         d.EndsAt,
+		CovidPassTypes: d.CovidPassTypes,
+	}
+
+	return &resp
+}
+
+func PBToCreateEventRequest(d *pb.CreateEventRequest) *CreateEventRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateEventRequest{
+		Name:           d.Name,
+		Venue:          d.Venue,
+		StartsAt:       d.StartsAt,
+		EndsAt:         d.EndsAt,
+		CovidPassTypes: d.CovidPassTypes,
+	}
+
+	return &resp
+}
+
+func CreateEventResponseToPB(d *CreateEventResponse) *pb.CreateEventResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateEventResponse{
+		Status: StatusToPB(d.Status),
+		Event:  EventToPB(d.Event),
+	}
+
+	return &resp
+}
+
+func PBToCreateEventResponse(d *pb.CreateEventResponse) *CreateEventResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateEventResponse{
+		Status: PBToStatus(d.Status),
+		Event:  PBToEvent(d.Event),
+	}
+
+	return &resp
+}
+
+func ListEventsRequestToPB(d *ListEventsRequest) *pb.ListEventsRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ListEventsRequest{}
+
+	return &resp
+}
+
+func PBToListEventsRequest(d *pb.ListEventsRequest) *ListEventsRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := ListEventsRequest{}
+
+	return &resp
+}
+
+func ListEventsResponseToPB(d *ListEventsResponse) *pb.ListEventsResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ListEventsResponse{
+		Status: StatusToPB(d.Status),
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, EventToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToListEventsResponse(d *pb.ListEventsResponse) *ListEventsResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := ListEventsResponse{
+		Status: PBToStatus(d.Status),
+	}
+
+	for _, v := range d.Data {
+		if e := PBToEvent(v); e != nil {
+			resp.Data = append(resp.Data, *e)
+		}
+	}
+
+	return &resp
+}
+
+func UpdateEventRequestToPB(d *UpdateEventRequest) *pb.UpdateEventRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateEventRequest{
+		Id:       d.Id,
+		Name:     d.Name,
+		Venue:    d.Venue,
+		StartsAt: d.StartsAt,
+		EndsAt:   d.EndsAt,
+	}
+	if d.CovidPassTypes != nil {
+		resp.CovidPassTypes = &pb.PassTypes{Types: *d.CovidPassTypes}
+	}
+
+	return &resp
+}
+
+func PBToUpdateEventRequest(d *pb.UpdateEventRequest) *UpdateEventRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateEventRequest{
+		Id:       d.Id,
+		Name:     d.Name,
+		Venue:    d.Venue,
+		StartsAt: d.StartsAt,
+		EndsAt:   d.EndsAt,
+	}
+	if d.CovidPassTypes != nil {
+		types := d.CovidPassTypes.Types
+		resp.CovidPassTypes = &types
+	}
+
+	return &resp
+}
+
+func UpdateEventResponseToPB(d *UpdateEventResponse) *pb.UpdateEventResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateEventResponse{
+		Status: StatusToPB(d.Status),
+		Event:  EventToPB(d.Event),
+	}
+
+	return &resp
+}
+
+func PBToUpdateEventResponse(d *pb.UpdateEventResponse) *UpdateEventResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateEventResponse{
+		Status: PBToStatus(d.Status),
+		Event:  PBToEvent(d.Event),
+	}
+
+	return &resp
+}
@@ -0,0 +1,147 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/schema"
+	"github.com/nakiner/guestcovider/internal/auth"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// NewHTTPClient returns an Service backed by an HTTP server living at the
+// remote instance. We expect instance to come from a service discovery system,
+// so likely of the form "host:port". We bake-in certain middlewares,
+// implementing the client library pattern.
+func NewHTTPClient(instance string, tracer stdopentracing.Tracer, logger log.Logger) (Service, error) {
+	// Quickly sanitize the instance string.
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	// global client middlewares
+	options := []httptransport.ClientOption{
+		httptransport.ClientBefore(auth.ContextToHTTP()),
+	}
+	if tracer != nil {
+		options = append(
+			options,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(tracer, logger)),
+		)
+	}
+
+	return endpoints{
+		CreateEventEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/event"),
+			encodeHTTPCreateEventCreateEventRequest,
+			decodeHTTPCreateEventCreateEventResponse,
+			options...,
+		).Endpoint(),
+		ListEventsEndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/event"),
+			encodeHTTPListEventsListEventsRequest,
+			decodeHTTPListEventsListEventsResponse,
+			options...,
+		).Endpoint(),
+		UpdateEventEndpoint: httptransport.NewClient(
+			"PATCH",
+			copyURL(u, "/event"),
+			encodeHTTPUpdateEventUpdateEventRequest,
+			decodeHTTPUpdateEventUpdateEventResponse,
+			options...,
+		).Endpoint(),
+	}, nil
+}
+
+func copyURL(base *url.URL, path string) *url.URL {
+	next := *base
+	next.Path = path
+	return &next
+}
+
+func encodeHTTPCreateEventCreateEventRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPListEventsListEventsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPUpdateEventUpdateEventRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*UpdateEventRequest)
+	if !ok {
+		return errors.New("encodeHTTPUpdateEventUpdateEventRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/event/%d", req.Id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func decodeHTTPCreateEventCreateEventResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request CreateEventResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPListEventsListEventsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request ListEventsResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPUpdateEventUpdateEventResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request UpdateEventResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
@@ -0,0 +1,171 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/nakiner/guestcovider/tools/logging"
+	"github.com/nakiner/guestcovider/tools/tracing"
+)
+
+func MakeHTTPHandler(ctx context.Context, s Service) http.Handler {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "http handler", "event")
+	tracer := tracing.FromContext(ctx)
+
+	r := mux.NewRouter()
+
+	options := []httptransport.ServerOption{
+		httptransport.ServerErrorEncoder(encodeError),
+		// httptransport.ServerErrorLogger(logger),
+		httptransport.ServerBefore(httpToContext()),
+		httptransport.ServerBefore(opentracing.HTTPToContext(tracer, "http server", logger)),
+		httptransport.ServerFinalizer(closeHTTPTracer()),
+	}
+
+	r.Methods("POST").Path("/event").Handler(httptransport.NewServer(
+		makeCreateEventEndpoint(s),
+		decodePOSTCreateEventRequest,
+		encodeCreateEventResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/event").Handler(httptransport.NewServer(
+		makeListEventsEndpoint(s),
+		decodeGETListEventsRequest,
+		encodeListEventsResponse,
+		options...,
+	))
+
+	r.Methods("PATCH").Path("/event/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makeUpdateEventEndpoint(s),
+		decodePATCHUpdateEventRequest,
+		encodeUpdateEventResponse,
+		options...,
+	))
+
+	return r
+}
+
+func httpToContext() httptransport.RequestFunc {
+	return func(ctx context.Context, req *http.Request) context.Context {
+		return context.WithValue(ctx, ContextHTTPKey{}, HTTPInfo{
+			Method:   req.Method,
+			URL:      req.RequestURI,
+			From:     req.RemoteAddr,
+			Protocol: req.Proto,
+		})
+	}
+}
+func closeHTTPTracer() httptransport.ServerFinalizerFunc {
+	return func(ctx context.Context, code int, r *http.Request) {
+		span := stdopentracing.SpanFromContext(ctx)
+		span.Finish()
+	}
+}
+
+func decodePOSTCreateEventRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request CreateEventRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETListEventsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request ListEventsRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePATCHUpdateEventRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request UpdateEventRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func encodeCreateEventResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeListEventsResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeUpdateEventResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+type errorer interface {
+	error() error
+}
+
+// encodeError handles error from business-layer.
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("X-Esp-Error", err.Error())
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+
+	w.WriteHeader(getHTTPStatusCode(err))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -destination service_mock.go -package event  github.com/nakiner/guestcovider/pkg/event Service
+package event
+
+import (
+	"context"
+
+	_ "github.com/golang/mock/mockgen/model"
+)
+
+type Service interface {
+	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+
+	// UpdateEvent changes only the fields set in the request.
+	UpdateEvent(context.Context, *UpdateEventRequest) (*UpdateEventResponse, error)
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	tool "github.com/nakiner/guestcovider/tools/metrics"
+)
+
+// NewMetricService returns an instance of an instrumenting Service.
+func NewMetricsService(ctx context.Context, s Service) Service {
+	counter, latency := tool.FromContext(ctx)
+	return &metricService{counter, latency, s}
+}
+
+type metricService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	Service
+}
+
+func (s *metricService) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "event", "handler", "CreateEvent", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "event", "handler", "CreateEvent", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.CreateEvent(ctx, req)
+}
+
+func (s *metricService) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "event", "handler", "ListEvents", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "event", "handler", "ListEvents", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.ListEvents(ctx, req)
+}
+
+func (s *metricService) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "event", "handler", "UpdateEvent", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "event", "handler", "UpdateEvent", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.UpdateEvent(ctx, req)
+}
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+)
+
+// NewPolicyService returns a Service that lets only admins manage events,
+// any operator may list them to pick the event they work at.
+func NewPolicyService(s Service) Service {
+	return &policyService{s}
+}
+
+type policyService struct {
+	Service
+}
+
+func allow(ctx context.Context, method, role string) error {
+	op, ok := auth.OperatorFromContext(ctx)
+	if !ok {
+		return errors.Wrap(ErrPermissionDenied, "no operator")
+	}
+	if !op.HasRole(role) {
+		return errors.Wrapf(ErrPermissionDenied, "%s requires the %s role", method, role)
+	}
+	return nil
+}
+
+func (s *policyService) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	if err := allow(ctx, "CreateEvent", operatorRepository.RoleAdmin); err != nil {
+		return &CreateEventResponse{}, err
+	}
+	return s.Service.CreateEvent(ctx, req)
+}
+
+func (s *policyService) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	if err := allow(ctx, "ListEvents", operatorRepository.RoleDoor); err != nil {
+		return &ListEventsResponse{}, err
+	}
+	return s.Service.ListEvents(ctx, req)
+}
+
+func (s *policyService) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	if err := allow(ctx, "UpdateEvent", operatorRepository.RoleAdmin); err != nil {
+		return &UpdateEventResponse{}, err
+	}
+	return s.Service.UpdateEvent(ctx, req)
+}
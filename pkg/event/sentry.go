@@ -0,0 +1,80 @@
+package event
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+)
+
+func NewSentryService(s Service) Service {
+	return &sentryService{s}
+}
+
+type sentryService struct {
+	Service
+}
+
+type sentryLog interface {
+	SentryLog() []interface{}
+}
+
+func (s *sentryService) getSentryLog(req interface{}, resp interface{}) (out map[string][]interface{}) {
+	out = make(map[string][]interface{})
+	if sentry, ok := interface{}(req).(sentryLog); ok {
+		out["request"] = append(out["request"], sentry.SentryLog()...)
+	}
+
+	if sentry, ok := interface{}(resp).(sentryLog); ok {
+		out["response"] = append(out["response"], sentry.SentryLog()...)
+	}
+	return
+}
+
+func (s *sentryService) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "CreateEvent")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.CreateEvent(ctx, req)
+}
+
+func (s *sentryService) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "ListEvents")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.ListEvents(ctx, req)
+}
+
+func (s *sentryService) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "UpdateEvent")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.UpdateEvent(ctx, req)
+}
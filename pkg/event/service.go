@@ -0,0 +1,178 @@
+package event
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+type eventService struct {
+	repo eventRepository.Repository
+}
+
+// NewEventService returns a Service managing the events guests belong to.
+func NewEventService(repo eventRepository.Repository) Service {
+	return &eventService{repo: repo}
+}
+
+func (s *eventService) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	resp = &CreateEventResponse{}
+
+	event := eventRepository.Event{
+		Name:  strings.TrimSpace(req.Name),
+		Venue: strings.TrimSpace(req.Venue),
+	}
+	if event.StartsAt, err = parseTime("startsAt", req.StartsAt); err != nil {
+		return resp, err
+	}
+	if event.EndsAt, err = parseTime("endsAt", req.EndsAt); err != nil {
+		return resp, err
+	}
+	if event.CovidPassTypes, err = parsePassTypes(req.CovidPassTypes); err != nil {
+		return resp, err
+	}
+	if err := validateEvent(&event); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.CreateEvent(ctx, &event); err != nil {
+		return resp, err
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Event = EventFromRepo(&event)
+
+	return resp, nil
+}
+
+func (s *eventService) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	resp = &ListEventsResponse{}
+
+	events, err := s.repo.ListEvents(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	for _, e := range events {
+		resp.Data = append(resp.Data, *EventFromRepo(e))
+	}
+
+	return resp, nil
+}
+
+func (s *eventService) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	resp = &UpdateEventResponse{}
+
+	event, err := s.repo.GetEvent(ctx, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	changed := false
+	if req.Name != nil {
+		event.Name = strings.TrimSpace(*req.Name)
+		changed = true
+	}
+	if req.Venue != nil {
+		event.Venue = strings.TrimSpace(*req.Venue)
+		changed = true
+	}
+	if req.StartsAt != nil {
+		if event.StartsAt, err = parseTime("startsAt", *req.StartsAt); err != nil {
+			return resp, err
+		}
+		changed = true
+	}
+	if req.EndsAt != nil {
+		if event.EndsAt, err = parseTime("endsAt", *req.EndsAt); err != nil {
+			return resp, err
+		}
+		changed = true
+	}
+	if req.CovidPassTypes != nil {
+		if event.CovidPassTypes, err = parsePassTypes(*req.CovidPassTypes); err != nil {
+			return resp, err
+		}
+		changed = true
+	}
+	if !changed {
+		return resp, errors.Wrap(ErrInvalidArgument, "nothing to update")
+	}
+	if err := validateEvent(event); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.UpdateEvent(ctx, event); err != nil {
+		return resp, repoError(err)
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Event = EventFromRepo(event)
+
+	return resp, nil
+}
+
+func validateEvent(e *eventRepository.Event) error {
+	if e.Name == "" {
+		return errors.Wrap(ErrInvalidArgument, "missing name")
+	}
+	if e.EndsAt.Before(e.StartsAt) {
+		return errors.Wrap(ErrInvalidArgument, "event ends before it starts")
+	}
+	return nil
+}
+
+func parseTime(field, s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, errors.Wrapf(ErrInvalidArgument, "%s must be an RFC 3339 time", field)
+	}
+	return t, nil
+}
+
+// parsePassTypes accepts pass types or their labels, as guest lists do.
+func parsePassTypes(types []string) (eventRepository.PassTypes, error) {
+	var out eventRepository.PassTypes
+	for _, t := range types {
+		passType, ok := userRepository.ParsePassType(t)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidArgument, "unknown covid pass type %q", t)
+		}
+		out = append(out, passType)
+	}
+	return out, nil
+}
+
+// repoError maps repository errors onto service errors.
+func repoError(err error) error {
+	if errors.Is(err, eventRepository.ErrNotFound) {
+		return errors.Wrap(ErrNotFound, err.Error())
+	}
+	return err
+}
+
+func EventFromRepo(e *eventRepository.Event) *Event {
+	if e == nil {
+		return nil
+	}
+
+	return &Event{
+		Id:             e.ID,
+		Name:           e.Name,
+		Venue:          e.Venue,
+		StartsAt:       e.StartsAt.UTC().Format(time.RFC3339),
+		EndsAt:         e.EndsAt.UTC().Format(time.RFC3339),
+		CovidPassTypes: e.CovidPassTypes,
+	}
+}
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventService(t *testing.T) {
+	ctx := context.Background()
+	s := NewEventService(eventRepository.NewEventMemoryRepository())
+
+	created, err := s.CreateEvent(ctx, &CreateEventRequest{
+		Name:           " Осенний бал ",
+		Venue:          "Манеж",
+		StartsAt:       "2021-10-01T18:00:00+03:00",
+		EndsAt:         "2021-10-01T23:00:00+03:00",
+		CovidPassTypes: []string{"QR-код", "pcr"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Осенний бал", created.Event.Name)
+	assert.Equal(t, "2021-10-01T15:00:00Z", created.Event.StartsAt)
+	assert.Equal(t, []string{"qr", "pcr"}, created.Event.CovidPassTypes)
+
+	_, err = s.CreateEvent(ctx, &CreateEventRequest{Name: "Бал", StartsAt: "2021-10-02T18:00:00Z", EndsAt: "2021-10-01T18:00:00Z"})
+	assert.Equal(t, ErrInvalidArgument, errors.Cause(err))
+	_, err = s.CreateEvent(ctx, &CreateEventRequest{Name: "Бал", StartsAt: "завтра", EndsAt: "2021-10-01T18:00:00Z"})
+	assert.Equal(t, ErrInvalidArgument, errors.Cause(err))
+	_, err = s.CreateEvent(ctx, &CreateEventRequest{Name: "Бал", StartsAt: "2021-10-01T18:00:00Z", EndsAt: "2021-10-01T18:00:00Z", CovidPassTypes: []string{"справка"}})
+	assert.Equal(t, ErrInvalidArgument, errors.Cause(err))
+
+	venue, types := "Гостиный двор", []string{}
+	updated, err := s.UpdateEvent(ctx, &UpdateEventRequest{Id: created.Event.Id, Venue: &venue, CovidPassTypes: &types})
+	require.NoError(t, err)
+	assert.Equal(t, "Гостиный двор", updated.Event.Venue)
+	assert.Equal(t, "Осенний бал", updated.Event.Name)
+	assert.Empty(t, updated.Event.CovidPassTypes)
+
+	_, err = s.UpdateEvent(ctx, &UpdateEventRequest{Id: created.Event.Id})
+	assert.Equal(t, ErrInvalidArgument, errors.Cause(err))
+	_, err = s.UpdateEvent(ctx, &UpdateEventRequest{Id: 100, Venue: &venue})
+	assert.Equal(t, ErrNotFound, errors.Cause(err))
+
+	list, err := s.ListEvents(ctx, &ListEventsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 2)
+}
+
+func TestPolicy(t *testing.T) {
+	as := func(role string) context.Context {
+		return auth.WithOperator(context.Background(), auth.Operator{Login: role, Role: role})
+	}
+	s := NewPolicyService(NewEventService(eventRepository.NewEventMemoryRepository()))
+
+	_, err := s.ListEvents(as("door"), &ListEventsRequest{})
+	assert.NoError(t, err)
+	_, err = s.CreateEvent(as("coordinator"), &CreateEventRequest{Name: "Бал"})
+	assert.Equal(t, 403, getHTTPStatusCode(err))
+	name := "Бал"
+	_, err = s.UpdateEvent(as("door"), &UpdateEventRequest{Id: eventRepository.DefaultEventID, Name: &name})
+	assert.Equal(t, 403, getHTTPStatusCode(err))
+	_, err = s.UpdateEvent(as("admin"), &UpdateEventRequest{Id: eventRepository.DefaultEventID, Name: &name})
+	assert.NoError(t, err)
+	_, err = s.ListEvents(context.Background(), &ListEventsRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package event
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/nakiner/guestcovider/tools/tracing"
+)
+
+// NewTracingService returns an instance of an instrumenting Service.
+func NewTracingService(ctx context.Context, s Service) Service {
+	tracer := tracing.FromContext(ctx)
+	return &tracingService{tracer, s}
+}
+
+type tracingService struct {
+	tracer opentracing.Tracer
+	Service
+}
+
+func (s *tracingService) CreateEvent(ctx context.Context, req *CreateEventRequest) (resp *CreateEventResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "CreateEvent")
+	defer span.Finish()
+	return s.Service.CreateEvent(ctx, req)
+}
+
+func (s *tracingService) ListEvents(ctx context.Context, req *ListEventsRequest) (resp *ListEventsResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "ListEvents")
+	defer span.Finish()
+	return s.Service.ListEvents(ctx, req)
+}
+
+func (s *tracingService) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (resp *UpdateEventResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "UpdateEvent")
+	defer span.Finish()
+	return s.Service.UpdateEvent(ctx, req)
+}
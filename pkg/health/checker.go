@@ -0,0 +1,149 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrShuttingDown fails readiness once the service is shutting down.
+var ErrShuttingDown = errors.New("shutting down")
+
+// Checker probes a dependency, it returns nil when the dependency is usable.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is a function used as a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+// Registry holds the checkers readiness depends on. Each check is given
+// timeout to finish, zero means no limit.
+type Registry struct {
+	timeout  time.Duration
+	draining int32
+
+	mu       sync.RWMutex
+	checkers []namedChecker
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds a checker, components are reported in registration order.
+func (r *Registry) Register(name string, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers = append(r.checkers, namedChecker{name: name, checker: c})
+}
+
+// Drain makes readiness fail from now on, so that no new requests are
+// routed here while in-flight ones finish.
+func (r *Registry) Drain() {
+	atomic.StoreInt32(&r.draining, 1)
+}
+
+// Check runs every checker at once and returns their results. Once the
+// registry is drained the checkers are skipped.
+func (r *Registry) Check(ctx context.Context) []Component {
+	if atomic.LoadInt32(&r.draining) != 0 {
+		return []Component{{Name: "shutdown", Status: StatusFail, Error: ErrShuttingDown.Error()}}
+	}
+
+	r.mu.RLock()
+	checkers := append([]namedChecker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	components := make([]Component, len(checkers))
+	var wg sync.WaitGroup
+	for i, c := range checkers {
+		wg.Add(1)
+		go func(i int, c namedChecker) {
+			defer wg.Done()
+			components[i] = r.check(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+	return components
+}
+
+func (r *Registry) check(ctx context.Context, c namedChecker) Component {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := c.checker.Check(ctx)
+	component := Component{
+		Name:      c.name,
+		Status:    StatusOK,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		component.Status = StatusFail
+		component.Error = err.Error()
+	}
+	return component
+}
+
+// HTTPChecker checks that a downstream service answers GET url without a
+// server error.
+func HTTPChecker(client *http.Client, url string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return errors.Errorf("%s answered %s", url, resp.Status)
+		}
+		return nil
+	})
+}
+
+// NotReadyError is returned by Readiness when a component is down.
+type NotReadyError struct {
+	Components []Component
+}
+
+func (e *NotReadyError) Error() string {
+	var failed []string
+	for _, c := range e.Components {
+		if c.Status != StatusOK {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name, c.Error))
+		}
+	}
+	return "not ready, " + strings.Join(failed, "; ")
+}
+
+func (e *NotReadyError) Code() int {
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistryCheck(t *testing.T) {
+	checks := NewRegistry(10 * time.Millisecond)
+	checks.Register("postgres", CheckerFunc(func(context.Context) error { return nil }))
+	checks.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	components := checks.Check(context.Background())
+	require.Len(t, components, 2)
+	assert.Equal(t, Component{Name: "postgres", Status: StatusOK}, components[0])
+	assert.Equal(t, "slow", components[1].Name)
+	assert.Equal(t, StatusFail, components[1].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), components[1].Error)
+}
+
+func TestRegistryDrain(t *testing.T) {
+	checks := NewRegistry(0)
+	checks.Register("postgres", CheckerFunc(func(context.Context) error { return nil }))
+	checks.Drain()
+
+	resp, err := NewHealthService(checks).Readiness(context.Background(), &ReadinessRequest{})
+	require.Error(t, err)
+	assert.Equal(t, []Component{{Name: "shutdown", Status: StatusFail, Error: ErrShuttingDown.Error()}}, resp.Components)
+}
+
+func TestReadiness(t *testing.T) {
+	checks := NewRegistry(0)
+	s := NewHealthService(checks)
+	resp, err := s.Readiness(context.Background(), &ReadinessRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, StatusOK, resp.Status)
+
+	checks.Register("migrations", CheckerFunc(func(context.Context) error {
+		return errors.New("1 pending, next is 0013_create_companions")
+	}))
+	resp, err = s.Readiness(context.Background(), &ReadinessRequest{})
+	require.Error(t, err)
+	assert.Equal(t, StatusFail, resp.Status)
+	assert.Equal(t, "not ready, migrations: 1 pending, next is 0013_create_companions", err.Error())
+	assert.Equal(t, http.StatusServiceUnavailable, getHTTPStatusCode(err))
+
+	w := httptest.NewRecorder()
+	encodeError(context.Background(), err, w)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var body ReadinessResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, StatusFail, body.Status)
+	assert.Len(t, body.Components, 1)
+
+	st := status.Convert(grpcError(err))
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Len(t, st.Details(), 1)
+}
+
+func TestHTTPChecker(t *testing.T) {
+	code := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	check := HTTPChecker(srv.Client(), srv.URL)
+	assert.NoError(t, check.Check(context.Background()))
+	code = http.StatusNotFound
+	assert.NoError(t, check.Check(context.Background()))
+	code = http.StatusBadGateway
+	assert.Error(t, check.Check(context.Background()))
+}
+
+func TestVersion(t *testing.T) {
+	Version, Commit, BuildTime = "1.2.0", "0d25b0e", "2021-09-14T19:00:00"
+	resp, err := NewHealthService(nil).Version(context.Background(), &VersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &VersionResponse{BuildTime: "2021-09-14T19:00:00", Version: "1.2.0", Commit: "0d25b0e"}, resp)
+}
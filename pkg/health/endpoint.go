@@ -23,7 +23,16 @@ type ReadinessRequest struct {
 
 //easyjson:json
 type ReadinessResponse struct {
-	Status string `json:"status,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+//easyjson:json
+type Component struct {
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
 }
 
 //easyjson:json
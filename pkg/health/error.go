@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -52,3 +54,36 @@ func getHTTPStatusCode(err error) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// getGRPCCode returns grpc status code from error.
+func getGRPCCode(err error) codes.Code {
+	switch getHTTPStatusCode(err) {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcError turns an error from business-layer into a grpc status, the
+// components of a failed readiness check are attached to the details.
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	st := status.New(getGRPCCode(err), err.Error())
+	var notReady *NotReadyError
+	if errors.As(err, &notReady) {
+		resp := &ReadinessResponse{Status: StatusFail, Components: notReady.Components}
+		if detailed, derr := st.WithDetails(ReadinessResponseToPB(resp)); derr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
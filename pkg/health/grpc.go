@@ -91,7 +91,7 @@ func (s *grpcServer) Liveness(ctx context.Context, req *pb.LivenessRequest) (*pb
 func (s *grpcServer) Readiness(ctx context.Context, req *pb.ReadinessRequest) (*pb.ReadinessResponse, error) {
 	_, rep, err := s.readiness.ServeGRPC(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
 	return rep.(*pb.ReadinessResponse), nil
 }
@@ -242,6 +242,9 @@ func ReadinessResponseToPB(d *ReadinessResponse) *pb.ReadinessResponse {
 	resp := pb.ReadinessResponse{
 		Status: d.Status,
 	}
+	for i := range d.Components {
+		resp.Components = append(resp.Components, ComponentToPB(&d.Components[i]))
+	}
 
 	return &resp
 }
@@ -254,6 +257,9 @@ func PBToReadinessResponse(d *pb.ReadinessResponse) *ReadinessResponse {
 	resp := ReadinessResponse{
 		Status: d.Status,
 	}
+	for _, c := range d.Components {
+		resp.Components = append(resp.Components, *PBToComponent(c))
+	}
 
 	return &resp
 }
@@ -305,3 +311,33 @@ func PBToVersionResponse(d *pb.VersionResponse) *VersionResponse {
 
 	return &resp
 }
+
+func ComponentToPB(d *Component) *pb.Component {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Component{
+		Name:      d.Name,
+		Status:    d.Status,
+		LatencyMs: d.LatencyMs,
+		Error:     d.Error,
+	}
+
+	return &resp
+}
+
+func PBToComponent(d *pb.Component) *Component {
+	if d == nil {
+		return nil
+	}
+
+	resp := Component{
+		Name:      d.Name,
+		Status:    d.Status,
+		LatencyMs: d.LatencyMs,
+		Error:     d.Error,
+	}
+
+	return &resp
+}
@@ -115,6 +115,13 @@ func decodeHTTPLivenessLivenessResponse(_ context.Context, r *http.Response) (in
 }
 
 func decodeHTTPReadinessReadinessResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode == http.StatusServiceUnavailable {
+		var body ReadinessResponse
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, errors.New(r.Status)
+		}
+		return nil, &NotReadyError{Components: body.Components}
+	}
 	if r.StatusCode != http.StatusOK {
 		return nil, errors.New(r.Status)
 	}
@@ -52,7 +52,7 @@ func MakeHTTPHandler(ctx context.Context, s Service) http.Handler {
 		options...,
 	))
 
-	return accessControl(r)
+	return r
 }
 
 func httpToContext() httptransport.RequestFunc {
@@ -162,23 +162,15 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	w.Header().Set("X-Esp-Error", err.Error())
 	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
 
-	w.WriteHeader(getHTTPStatusCode(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"error": err.Error(),
-	})
-}
-
-// accessControl is CORS middleware.
-func accessControl(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE, UPDATE, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
-
-		if r.Method == "OPTIONS" {
-			return
-		}
+	}
+	var notReady *NotReadyError
+	if errors.As(err, &notReady) {
+		body["status"] = StatusFail
+		body["components"] = notReady.Components
+	}
 
-		h.ServeHTTP(w, r)
-	})
+	w.WriteHeader(getHTTPStatusCode(err))
+	json.NewEncoder(w).Encode(body)
 }
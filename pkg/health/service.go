@@ -2,10 +2,9 @@ package health
 
 import (
 	"context"
-
-	"github.com/bxcodec/faker"
 )
 
+// Build details injected by the Makefile with -ldflags.
 var (
 	BuildTime string
 	Commit    string
@@ -13,35 +12,39 @@ var (
 )
 
 type healthService struct {
+	checks *Registry
 }
 
-func NewHealthService() Service {
-	return &healthService{}
+// NewHealthService returns the health service, readiness depends on the
+// checkers of checks.
+func NewHealthService(checks *Registry) Service {
+	return &healthService{checks: checks}
 }
 
 func (s *healthService) Liveness(ctx context.Context, req *LivenessRequest) (resp *LivenessResponse, err error) {
-	a := LivenessResponse{}
-	err = faker.FakeData(&a)
-	if err != nil {
-		return &a, err
-	}
-	return &a, nil
+	return &LivenessResponse{Status: StatusOK}, nil
 }
 
 func (s *healthService) Readiness(ctx context.Context, req *ReadinessRequest) (resp *ReadinessResponse, err error) {
-	a := ReadinessResponse{}
-	err = faker.FakeData(&a)
-	if err != nil {
-		return &a, err
+	resp = &ReadinessResponse{Status: StatusOK}
+	if s.checks == nil {
+		return resp, nil
 	}
-	return &a, nil
+
+	resp.Components = s.checks.Check(ctx)
+	for _, c := range resp.Components {
+		if c.Status != StatusOK {
+			resp.Status = StatusFail
+			return resp, &NotReadyError{Components: resp.Components}
+		}
+	}
+	return resp, nil
 }
 
 func (s *healthService) Version(ctx context.Context, req *VersionRequest) (resp *VersionResponse, err error) {
-	a := VersionResponse{}
-	err = faker.FakeData(&a)
-	if err != nil {
-		return &a, err
-	}
-	return &a, nil
+	return &VersionResponse{
+		BuildTime: BuildTime,
+		Version:   Version,
+		Commit:    Commit,
+	}, nil
 }
@@ -0,0 +1,204 @@
+//go:generate easyjson -all endpoint.go
+package operator
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	_ "github.com/mailru/easyjson/gen"
+)
+
+//easyjson:json
+type Status struct {
+	Status  bool   `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+//easyjson:json
+type Operator struct {
+	Id       uint64 `json:"id,omitempty"`
+	Login    string `json:"login,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+//easyjson:json
+type LoginRequest struct {
+	Login    string `json:"login,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+//easyjson:json
+type LoginResponse struct {
+	Status    *Status   `json:"status,omitempty"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt string    `json:"expiresAt,omitempty"`
+	Operator  *Operator `json:"operator,omitempty"`
+}
+
+//easyjson:json
+type LogoutRequest struct {
+}
+
+//easyjson:json
+type LogoutResponse struct {
+	Status *Status `json:"status,omitempty"`
+}
+
+//easyjson:json
+type MeRequest struct {
+}
+
+//easyjson:json
+type MeResponse struct {
+	Status   *Status   `json:"status,omitempty"`
+	Operator *Operator `json:"operator,omitempty"`
+}
+
+//easyjson:json
+type CreateOperatorRequest struct {
+	Login    string `json:"login,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Password string `json:"password,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+//easyjson:json
+type CreateOperatorResponse struct {
+	Status   *Status   `json:"status,omitempty"`
+	Operator *Operator `json:"operator,omitempty"`
+}
+
+//easyjson:json
+type ListOperatorsRequest struct {
+}
+
+//easyjson:json
+type ListOperatorsResponse struct {
+	Status *Status    `json:"status,omitempty"`
+	Data   []Operator `json:"data,omitempty"`
+}
+
+// UpdateOperatorRequest holds operator fields to change, nil fields are left untouched.
+//
+//easyjson:json
+type UpdateOperatorRequest struct {
+	Id       uint64  `json:"id,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Password *string `json:"password,omitempty"`
+	Role     *string `json:"role,omitempty"`
+	Disabled *bool   `json:"disabled,omitempty"`
+}
+
+//easyjson:json
+type UpdateOperatorResponse struct {
+	Status   *Status   `json:"status,omitempty"`
+	Operator *Operator `json:"operator,omitempty"`
+}
+
+//easyjson:skip
+type endpoints struct {
+	LoginEndpoint          endpoint.Endpoint
+	LogoutEndpoint         endpoint.Endpoint
+	MeEndpoint             endpoint.Endpoint
+	CreateOperatorEndpoint endpoint.Endpoint
+	ListOperatorsEndpoint  endpoint.Endpoint
+	UpdateOperatorEndpoint endpoint.Endpoint
+}
+
+func (e endpoints) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	response, err := e.LoginEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(LoginResponse)
+	return &r, err
+}
+
+func (e endpoints) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	response, err := e.LogoutEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(LogoutResponse)
+	return &r, err
+}
+
+func (e endpoints) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	response, err := e.MeEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(MeResponse)
+	return &r, err
+}
+
+func (e endpoints) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	response, err := e.CreateOperatorEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(CreateOperatorResponse)
+	return &r, err
+}
+
+func (e endpoints) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	response, err := e.ListOperatorsEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(ListOperatorsResponse)
+	return &r, err
+}
+
+func (e endpoints) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	response, err := e.UpdateOperatorEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(UpdateOperatorResponse)
+	return &r, err
+}
+
+func makeLoginEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(LoginRequest)
+		return s.Login(ctx, &req)
+	}
+}
+
+func makeLogoutEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(LogoutRequest)
+		return s.Logout(ctx, &req)
+	}
+}
+
+func makeMeEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(MeRequest)
+		return s.Me(ctx, &req)
+	}
+}
+
+func makeCreateOperatorEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateOperatorRequest)
+		return s.CreateOperator(ctx, &req)
+	}
+}
+
+func makeListOperatorsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListOperatorsRequest)
+		return s.ListOperators(ctx, &req)
+	}
+}
+
+func makeUpdateOperatorEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateOperatorRequest)
+		return s.UpdateOperator(ctx, &req)
+	}
+}
@@ -0,0 +1,207 @@
+package operator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/nakiner/guestcovider/internal/auth"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"google.golang.org/grpc"
+)
+
+// NewGRPCClient returns an Service backed by a gRPC server at the other end
+// of the conn. The caller is responsible for constructing the conn, and
+// eventually closing the underlying transport. We bake-in certain middlewares,
+// implementing the client library pattern.
+func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger log.Logger) Service {
+	// global client middlewares
+	options := []grpctransport.ClientOption{
+		grpctransport.ClientBefore(opentracing.ContextToGRPC(tracer, logger)),
+		grpctransport.ClientBefore(auth.ContextToGRPC()),
+	}
+
+	return endpoints{
+		// Each individual endpoint is an grpc/transport.Client (which implements
+		// endpoint.Endpoint) that gets wrapped with various middlewares. If you
+		// made your own client library, you'd do this work there, so your server
+		// could rely on a consistent set of client behavior.
+		LoginEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"Login",
+			encodeGRPCLoginRequest,
+			decodeGRPCLoginResponse,
+			pb.LoginResponse{},
+			options...,
+		).Endpoint(),
+		LogoutEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"Logout",
+			encodeGRPCLogoutRequest,
+			decodeGRPCLogoutResponse,
+			pb.LogoutResponse{},
+			options...,
+		).Endpoint(),
+		MeEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"Me",
+			encodeGRPCMeRequest,
+			decodeGRPCMeResponse,
+			pb.MeResponse{},
+			options...,
+		).Endpoint(),
+		CreateOperatorEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"CreateOperator",
+			encodeGRPCCreateOperatorRequest,
+			decodeGRPCCreateOperatorResponse,
+			pb.CreateOperatorResponse{},
+			options...,
+		).Endpoint(),
+		ListOperatorsEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"ListOperators",
+			encodeGRPCListOperatorsRequest,
+			decodeGRPCListOperatorsResponse,
+			pb.ListOperatorsResponse{},
+			options...,
+		).Endpoint(),
+		UpdateOperatorEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.OperatorService",
+			"UpdateOperator",
+			encodeGRPCUpdateOperatorRequest,
+			decodeGRPCUpdateOperatorResponse,
+			pb.UpdateOperatorResponse{},
+			options...,
+		).Endpoint(),
+	}
+}
+
+func encodeGRPCLoginRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*LoginRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCLoginRequest wrong request")
+	}
+
+	return LoginRequestToPB(inReq), nil
+}
+
+func encodeGRPCLogoutRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*LogoutRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCLogoutRequest wrong request")
+	}
+
+	return LogoutRequestToPB(inReq), nil
+}
+
+func encodeGRPCMeRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*MeRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCMeRequest wrong request")
+	}
+
+	return MeRequestToPB(inReq), nil
+}
+
+func encodeGRPCCreateOperatorRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*CreateOperatorRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateOperatorRequest wrong request")
+	}
+
+	return CreateOperatorRequestToPB(inReq), nil
+}
+
+func encodeGRPCListOperatorsRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*ListOperatorsRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCListOperatorsRequest wrong request")
+	}
+
+	return ListOperatorsRequestToPB(inReq), nil
+}
+
+func encodeGRPCUpdateOperatorRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*UpdateOperatorRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCUpdateOperatorRequest wrong request")
+	}
+
+	return UpdateOperatorRequestToPB(inReq), nil
+}
+
+func decodeGRPCLoginResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.LoginResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCLoginResponse wrong response")
+	}
+
+	resp := PBToLoginResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCLogoutResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.LogoutResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCLogoutResponse wrong response")
+	}
+
+	resp := PBToLogoutResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCMeResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.MeResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCMeResponse wrong response")
+	}
+
+	resp := PBToMeResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCCreateOperatorResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.CreateOperatorResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateOperatorResponse wrong response")
+	}
+
+	resp := PBToCreateOperatorResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCListOperatorsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.ListOperatorsResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCListOperatorsResponse wrong response")
+	}
+
+	resp := PBToListOperatorsResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCUpdateOperatorResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.UpdateOperatorResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCUpdateOperatorResponse wrong response")
+	}
+
+	resp := PBToUpdateOperatorResponse(inResp)
+
+	return *resp, nil
+}
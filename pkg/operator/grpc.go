@@ -0,0 +1,661 @@
+package operator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	"github.com/go-kit/kit/transport/grpc"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"github.com/nakiner/guestcovider/tools/logging"
+	"github.com/nakiner/guestcovider/tools/tracing"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type grpcServer struct {
+	login          grpctransport.Handler
+	logout         grpctransport.Handler
+	me             grpctransport.Handler
+	createOperator grpctransport.Handler
+	listOperators  grpctransport.Handler
+	updateOperator grpctransport.Handler
+}
+
+type ContextGRPCKey struct{}
+
+type GRPCInfo struct {
+	From string
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC operatorServer.
+func NewGRPCServer(ctx context.Context, s Service) pb.OperatorServiceServer {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "grpc handler", "operator")
+	tracer := tracing.FromContext(ctx)
+
+	options := []grpctransport.ServerOption{
+		// grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(grpcToContext()),
+		grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "grpc server", logger)),
+		grpctransport.ServerFinalizer(closeGRPCTracer()),
+	}
+
+	return &grpcServer{
+		login: grpctransport.NewServer(
+			makeLoginEndpoint(s),
+			decodeGRPCLoginRequest,
+			encodeGRPCLoginResponse,
+			options...,
+		),
+		logout: grpctransport.NewServer(
+			makeLogoutEndpoint(s),
+			decodeGRPCLogoutRequest,
+			encodeGRPCLogoutResponse,
+			options...,
+		),
+		me: grpctransport.NewServer(
+			makeMeEndpoint(s),
+			decodeGRPCMeRequest,
+			encodeGRPCMeResponse,
+			options...,
+		),
+		createOperator: grpctransport.NewServer(
+			makeCreateOperatorEndpoint(s),
+			decodeGRPCCreateOperatorRequest,
+			encodeGRPCCreateOperatorResponse,
+			options...,
+		),
+		listOperators: grpctransport.NewServer(
+			makeListOperatorsEndpoint(s),
+			decodeGRPCListOperatorsRequest,
+			encodeGRPCListOperatorsResponse,
+			options...,
+		),
+		updateOperator: grpctransport.NewServer(
+			makeUpdateOperatorEndpoint(s),
+			decodeGRPCUpdateOperatorRequest,
+			encodeGRPCUpdateOperatorResponse,
+			options...,
+		),
+	}
+}
+
+func JoinGRPC(ctx context.Context, s Service) func(*googlegrpc.Server) {
+	return func(g *googlegrpc.Server) {
+		pb.RegisterOperatorServiceServer(g, NewGRPCServer(ctx, s))
+	}
+}
+
+func grpcToContext() grpc.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		var info GRPCInfo
+		if p, ok := peer.FromContext(ctx); ok {
+			info.From = p.Addr.String()
+		}
+		return context.WithValue(ctx, ContextGRPCKey{}, info)
+	}
+}
+
+func closeGRPCTracer() grpc.ServerFinalizerFunc {
+	return func(ctx context.Context, err error) {
+		span := stdopentracing.SpanFromContext(ctx)
+		span.Finish()
+	}
+}
+
+func (s *grpcServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	_, rep, err := s.login.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.LoginResponse), nil
+}
+
+func (s *grpcServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	_, rep, err := s.logout.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.LogoutResponse), nil
+}
+
+func (s *grpcServer) Me(ctx context.Context, req *pb.MeRequest) (*pb.MeResponse, error) {
+	_, rep, err := s.me.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.MeResponse), nil
+}
+
+func (s *grpcServer) CreateOperator(ctx context.Context, req *pb.CreateOperatorRequest) (*pb.CreateOperatorResponse, error) {
+	_, rep, err := s.createOperator.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.CreateOperatorResponse), nil
+}
+
+func (s *grpcServer) ListOperators(ctx context.Context, req *pb.ListOperatorsRequest) (*pb.ListOperatorsResponse, error) {
+	_, rep, err := s.listOperators.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.ListOperatorsResponse), nil
+}
+
+func (s *grpcServer) UpdateOperator(ctx context.Context, req *pb.UpdateOperatorRequest) (*pb.UpdateOperatorResponse, error) {
+	_, rep, err := s.updateOperator.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.UpdateOperatorResponse), nil
+}
+
+func decodeGRPCLoginRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.LoginRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCLoginRequest wrong request")
+	}
+
+	req := PBToLoginRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCLogoutRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.LogoutRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCLogoutRequest wrong request")
+	}
+
+	req := PBToLogoutRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCMeRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.MeRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCMeRequest wrong request")
+	}
+
+	req := PBToMeRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCCreateOperatorRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.CreateOperatorRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateOperatorRequest wrong request")
+	}
+
+	req := PBToCreateOperatorRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCListOperatorsRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.ListOperatorsRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCListOperatorsRequest wrong request")
+	}
+
+	req := PBToListOperatorsRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCUpdateOperatorRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.UpdateOperatorRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCUpdateOperatorRequest wrong request")
+	}
+
+	req := PBToUpdateOperatorRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func encodeGRPCLoginResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*LoginResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCLoginResponse wrong response")
+	}
+
+	return LoginResponseToPB(inResp), nil
+}
+
+func encodeGRPCLogoutResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*LogoutResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCLogoutResponse wrong response")
+	}
+
+	return LogoutResponseToPB(inResp), nil
+}
+
+func encodeGRPCMeResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*MeResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCMeResponse wrong response")
+	}
+
+	return MeResponseToPB(inResp), nil
+}
+
+func encodeGRPCCreateOperatorResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*CreateOperatorResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateOperatorResponse wrong response")
+	}
+
+	return CreateOperatorResponseToPB(inResp), nil
+}
+
+func encodeGRPCListOperatorsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*ListOperatorsResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCListOperatorsResponse wrong response")
+	}
+
+	return ListOperatorsResponseToPB(inResp), nil
+}
+
+func encodeGRPCUpdateOperatorResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*UpdateOperatorResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCUpdateOperatorResponse wrong response")
+	}
+
+	return UpdateOperatorResponseToPB(inResp), nil
+}
+
+func StatusToPB(d *Status) *pb.Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func PBToStatus(d *pb.Status) *Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func OperatorToPB(d *Operator) *pb.Operator {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Operator{
+		Id:       d.Id,
+		Login:    d.Login,
+		Name:     d.Name,
+		Role:     d.Role,
+		Disabled: d.Disabled,
+	}
+
+	return &resp
+}
+
+func PBToOperator(d *pb.Operator) *Operator {
+	if d == nil {
+		return nil
+	}
+
+	resp := Operator{
+		Id:       d.Id,
+		Login:    d.Login,
+		Name:     d.Name,
+		Role:     d.Role,
+		Disabled: d.Disabled,
+	}
+
+	return &resp
+}
+
+func LoginRequestToPB(d *LoginRequest) *pb.LoginRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.LoginRequest{
+		Login:    d.Login,
+		Password: d.Password,
+	}
+
+	return &resp
+}
+
+func PBToLoginRequest(d *pb.LoginRequest) *LoginRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := LoginRequest{
+		Login:    d.Login,
+		Password: d.Password,
+	}
+
+	return &resp
+}
+
+func LoginResponseToPB(d *LoginResponse) *pb.LoginResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.LoginResponse{
+		Status:    StatusToPB(d.Status),
+		Token:     d.Token,
+		ExpiresAt: d.ExpiresAt,
+		Operator:  OperatorToPB(d.Operator),
+	}
+
+	return &resp
+}
+
+func PBToLoginResponse(d *pb.LoginResponse) *LoginResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := LoginResponse{
+		Status:    PBToStatus(d.Status),
+		Token:     d.Token,
+		ExpiresAt: d.ExpiresAt,
+		Operator:  PBToOperator(d.Operator),
+	}
+
+	return &resp
+}
+
+func LogoutRequestToPB(d *LogoutRequest) *pb.LogoutRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.LogoutRequest{}
+
+	return &resp
+}
+
+func PBToLogoutRequest(d *pb.LogoutRequest) *LogoutRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := LogoutRequest{}
+
+	return &resp
+}
+
+func LogoutResponseToPB(d *LogoutResponse) *pb.LogoutResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.LogoutResponse{
+		Status: StatusToPB(d.Status),
+	}
+
+	return &resp
+}
+
+func PBToLogoutResponse(d *pb.LogoutResponse) *LogoutResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := LogoutResponse{
+		Status: PBToStatus(d.Status),
+	}
+
+	return &resp
+}
+
+func MeRequestToPB(d *MeRequest) *pb.MeRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.MeRequest{}
+
+	return &resp
+}
+
+func PBToMeRequest(d *pb.MeRequest) *MeRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := MeRequest{}
+
+	return &resp
+}
+
+func MeResponseToPB(d *MeResponse) *pb.MeResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.MeResponse{
+		Status:   StatusToPB(d.Status),
+		Operator: OperatorToPB(d.Operator),
+	}
+
+	return &resp
+}
+
+func PBToMeResponse(d *pb.MeResponse) *MeResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := MeResponse{
+		Status:   PBToStatus(d.Status),
+		Operator: PBToOperator(d.Operator),
+	}
+
+	return &resp
+}
+
+func CreateOperatorRequestToPB(d *CreateOperatorRequest) *pb.CreateOperatorRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateOperatorRequest{
+		Login:    d.Login,
+		Name:     d.Name,
+		Password: d.Password,
+		Role:     d.Role,
+	}
+
+	return &resp
+}
+
+func PBToCreateOperatorRequest(d *pb.CreateOperatorRequest) *CreateOperatorRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateOperatorRequest{
+		Login:    d.Login,
+		Name:     d.Name,
+		Password: d.Password,
+		Role:     d.Role,
+	}
+
+	return &resp
+}
+
+func CreateOperatorResponseToPB(d *CreateOperatorResponse) *pb.CreateOperatorResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateOperatorResponse{
+		Status:   StatusToPB(d.Status),
+		Operator: OperatorToPB(d.Operator),
+	}
+
+	return &resp
+}
+
+func PBToCreateOperatorResponse(d *pb.CreateOperatorResponse) *CreateOperatorResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateOperatorResponse{
+		Status:   PBToStatus(d.Status),
+		Operator: PBToOperator(d.Operator),
+	}
+
+	return &resp
+}
+
+func ListOperatorsRequestToPB(d *ListOperatorsRequest) *pb.ListOperatorsRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ListOperatorsRequest{}
+
+	return &resp
+}
+
+func PBToListOperatorsRequest(d *pb.ListOperatorsRequest) *ListOperatorsRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := ListOperatorsRequest{}
+
+	return &resp
+}
+
+func ListOperatorsResponseToPB(d *ListOperatorsResponse) *pb.ListOperatorsResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ListOperatorsResponse{
+		Status: StatusToPB(d.Status),
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, OperatorToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToListOperatorsResponse(d *pb.ListOperatorsResponse) *ListOperatorsResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := ListOperatorsResponse{
+		Status: PBToStatus(d.Status),
+	}
+
+	for _, v := range d.Data {
+		if op := PBToOperator(v); op != nil {
+			resp.Data = append(resp.Data, *op)
+		}
+	}
+
+	return &resp
+}
+
+func UpdateOperatorRequestToPB(d *UpdateOperatorRequest) *pb.UpdateOperatorRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateOperatorRequest{
+		Id:       d.Id,
+		Name:     d.Name,
+		Password: d.Password,
+		Role:     d.Role,
+		Disabled: d.Disabled,
+	}
+
+	return &resp
+}
+
+func PBToUpdateOperatorRequest(d *pb.UpdateOperatorRequest) *UpdateOperatorRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateOperatorRequest{
+		Id:       d.Id,
+		Name:     d.Name,
+		Password: d.Password,
+		Role:     d.Role,
+		Disabled: d.Disabled,
+	}
+
+	return &resp
+}
+
+func UpdateOperatorResponseToPB(d *UpdateOperatorResponse) *pb.UpdateOperatorResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateOperatorResponse{
+		Status:   StatusToPB(d.Status),
+		Operator: OperatorToPB(d.Operator),
+	}
+
+	return &resp
+}
+
+func PBToUpdateOperatorResponse(d *pb.UpdateOperatorResponse) *UpdateOperatorResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateOperatorResponse{
+		Status:   PBToStatus(d.Status),
+		Operator: PBToOperator(d.Operator),
+	}
+
+	return &resp
+}
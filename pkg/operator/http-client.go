@@ -0,0 +1,233 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/schema"
+	"github.com/nakiner/guestcovider/internal/auth"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// NewHTTPClient returns an Service backed by an HTTP server living at the
+// remote instance. We expect instance to come from a service discovery system,
+// so likely of the form "host:port". We bake-in certain middlewares,
+// implementing the client library pattern.
+func NewHTTPClient(instance string, tracer stdopentracing.Tracer, logger log.Logger) (Service, error) {
+	// Quickly sanitize the instance string.
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	u, err := url.Parse(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	// global client middlewares
+	options := []httptransport.ClientOption{
+		httptransport.ClientBefore(auth.ContextToHTTP()),
+	}
+	if tracer != nil {
+		options = append(
+			options,
+			httptransport.ClientBefore(opentracing.ContextToHTTP(tracer, logger)),
+		)
+	}
+
+	return endpoints{
+		LoginEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/operator/login"),
+			encodeHTTPLoginLoginRequest,
+			decodeHTTPLoginLoginResponse,
+			options...,
+		).Endpoint(),
+		LogoutEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/operator/logout"),
+			encodeHTTPLogoutLogoutRequest,
+			decodeHTTPLogoutLogoutResponse,
+			options...,
+		).Endpoint(),
+		MeEndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/operator/me"),
+			encodeHTTPMeMeRequest,
+			decodeHTTPMeMeResponse,
+			options...,
+		).Endpoint(),
+		CreateOperatorEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/operator"),
+			encodeHTTPCreateOperatorCreateOperatorRequest,
+			decodeHTTPCreateOperatorCreateOperatorResponse,
+			options...,
+		).Endpoint(),
+		ListOperatorsEndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/operator"),
+			encodeHTTPListOperatorsListOperatorsRequest,
+			decodeHTTPListOperatorsListOperatorsResponse,
+			options...,
+		).Endpoint(),
+		UpdateOperatorEndpoint: httptransport.NewClient(
+			"PATCH",
+			copyURL(u, "/operator"),
+			encodeHTTPUpdateOperatorUpdateOperatorRequest,
+			decodeHTTPUpdateOperatorUpdateOperatorResponse,
+			options...,
+		).Endpoint(),
+	}, nil
+}
+
+func copyURL(base *url.URL, path string) *url.URL {
+	next := *base
+	next.Path = path
+	return &next
+}
+
+func encodeHTTPLoginLoginRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPLogoutLogoutRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPMeMeRequest(_ context.Context, r *http.Request, request interface{}) error {
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPCreateOperatorCreateOperatorRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPListOperatorsListOperatorsRequest(_ context.Context, r *http.Request, request interface{}) error {
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPUpdateOperatorUpdateOperatorRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*UpdateOperatorRequest)
+	if !ok {
+		return errors.New("encodeHTTPUpdateOperatorUpdateOperatorRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/operator/%d", req.Id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func decodeHTTPLoginLoginResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request LoginResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPLogoutLogoutResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request LogoutResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPMeMeResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request MeResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPCreateOperatorCreateOperatorResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request CreateOperatorResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPListOperatorsListOperatorsResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request ListOperatorsResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPUpdateOperatorUpdateOperatorResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request UpdateOperatorResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
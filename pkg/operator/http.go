@@ -0,0 +1,267 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/tracing/opentracing"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/nakiner/guestcovider/tools/logging"
+	"github.com/nakiner/guestcovider/tools/tracing"
+)
+
+func MakeHTTPHandler(ctx context.Context, s Service) http.Handler {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "http handler", "operator")
+	tracer := tracing.FromContext(ctx)
+
+	r := mux.NewRouter()
+
+	options := []httptransport.ServerOption{
+		httptransport.ServerErrorEncoder(encodeError),
+		// httptransport.ServerErrorLogger(logger),
+		httptransport.ServerBefore(httpToContext()),
+		httptransport.ServerBefore(opentracing.HTTPToContext(tracer, "http server", logger)),
+		httptransport.ServerFinalizer(closeHTTPTracer()),
+	}
+
+	r.Methods("POST").Path("/operator/login").Handler(httptransport.NewServer(
+		makeLoginEndpoint(s),
+		decodePOSTLoginRequest,
+		encodeLoginResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/operator/logout").Handler(httptransport.NewServer(
+		makeLogoutEndpoint(s),
+		decodePOSTLogoutRequest,
+		encodeLogoutResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/operator/me").Handler(httptransport.NewServer(
+		makeMeEndpoint(s),
+		decodeGETMeRequest,
+		encodeMeResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/operator").Handler(httptransport.NewServer(
+		makeCreateOperatorEndpoint(s),
+		decodePOSTCreateOperatorRequest,
+		encodeCreateOperatorResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/operator").Handler(httptransport.NewServer(
+		makeListOperatorsEndpoint(s),
+		decodeGETListOperatorsRequest,
+		encodeListOperatorsResponse,
+		options...,
+	))
+
+	r.Methods("PATCH").Path("/operator/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makeUpdateOperatorEndpoint(s),
+		decodePATCHUpdateOperatorRequest,
+		encodeUpdateOperatorResponse,
+		options...,
+	))
+
+	return r
+}
+
+func httpToContext() httptransport.RequestFunc {
+	return func(ctx context.Context, req *http.Request) context.Context {
+		return context.WithValue(ctx, ContextHTTPKey{}, HTTPInfo{
+			Method:   req.Method,
+			URL:      req.RequestURI,
+			From:     req.RemoteAddr,
+			Protocol: req.Proto,
+		})
+	}
+}
+func closeHTTPTracer() httptransport.ServerFinalizerFunc {
+	return func(ctx context.Context, code int, r *http.Request) {
+		span := stdopentracing.SpanFromContext(ctx)
+		span.Finish()
+	}
+}
+
+func decodePOSTLoginRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request LoginRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTLogoutRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request LogoutRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETMeRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request MeRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTCreateOperatorRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request CreateOperatorRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETListOperatorsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request ListOperatorsRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePATCHUpdateOperatorRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request UpdateOperatorRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func encodeLoginResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeLogoutResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeMeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeCreateOperatorResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeListOperatorsResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeUpdateOperatorResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+type errorer interface {
+	error() error
+}
+
+// encodeError handles error from business-layer.
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.Header().Set("X-Esp-Error", err.Error())
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+
+	w.WriteHeader(getHTTPStatusCode(err))
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": err.Error(),
+	})
+}
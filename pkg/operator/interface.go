@@ -0,0 +1,27 @@
+//go:generate mockgen -destination service_mock.go -package operator  github.com/nakiner/guestcovider/pkg/operator Service
+package operator
+
+import (
+	"context"
+
+	_ "github.com/golang/mock/mockgen/model"
+)
+
+type Service interface {
+
+	// Login checks operator credentials and opens a session.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+
+	// Logout closes the session of the calling operator.
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+
+	// Me returns the calling operator.
+	Me(context.Context, *MeRequest) (*MeResponse, error)
+
+	CreateOperator(context.Context, *CreateOperatorRequest) (*CreateOperatorResponse, error)
+	ListOperators(context.Context, *ListOperatorsRequest) (*ListOperatorsResponse, error)
+
+	// UpdateOperator changes only the fields set in the request,
+	// disabling an operator or changing the password closes their sessions.
+	UpdateOperator(context.Context, *UpdateOperatorRequest) (*UpdateOperatorResponse, error)
+}
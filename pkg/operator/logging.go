@@ -0,0 +1,210 @@
+package operator
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/tools/logging"
+)
+
+// NewLoggingService returns a new instance of a logging Service.
+func NewLoggingService(ctx context.Context, s Service) Service {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "component", "operator")
+	return &loggingService{logger, s}
+}
+
+type logged interface {
+	Log() []interface{}
+}
+
+type loggingService struct {
+	logger log.Logger
+	Service
+}
+
+func (s *loggingService) getLog(req interface{}, resp interface{}) (out []interface{}) {
+	if logger, ok := interface{}(req).(logged); ok {
+		out = append(out, logger.Log()...)
+	}
+
+	if logger, ok := interface{}(resp).(logged); ok {
+		out = append(out, logger.Log()...)
+	}
+
+	return
+}
+
+func getInfoFromContext(ctx context.Context) []interface{} {
+	m := make([]interface{}, 0)
+	if op, ok := auth.OperatorFromContext(ctx); ok {
+		m = append(m, "operator", op.Login)
+	}
+	{
+		val := ctx.Value(ContextGRPCKey{})
+		if _, ok := val.(GRPCInfo); ok {
+			m = append(m, "protocol", "GRPC")
+		}
+	}
+
+	{
+		val := ctx.Value(ContextHTTPKey{})
+		if i, ok := val.(HTTPInfo); ok {
+			m = append(m,
+				// "protocol", i.Protocol,
+				// "http_method", i.Method,
+				// "from", i.From,
+				"url", i.URL,
+			)
+		}
+	}
+
+	return m
+}
+
+func (s *loggingService) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "Login",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.Login(ctx, req)
+}
+
+func (s *loggingService) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "Logout",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.Logout(ctx, req)
+}
+
+func (s *loggingService) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "Me",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.Me(ctx, req)
+}
+
+func (s *loggingService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "CreateOperator",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.CreateOperator(ctx, req)
+}
+
+func (s *loggingService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "ListOperators",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.ListOperators(ctx, req)
+}
+
+func (s *loggingService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "UpdateOperator",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.UpdateOperator(ctx, req)
+}
@@ -0,0 +1,82 @@
+package operator
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	tool "github.com/nakiner/guestcovider/tools/metrics"
+)
+
+// NewMetricService returns an instance of an instrumenting Service.
+func NewMetricsService(ctx context.Context, s Service) Service {
+	counter, latency := tool.FromContext(ctx)
+	return &metricService{counter, latency, s}
+}
+
+type metricService struct {
+	requestCount   metrics.Counter
+	requestLatency metrics.Histogram
+	Service
+}
+
+func (s *metricService) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "Login", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "Login", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.Login(ctx, req)
+}
+
+func (s *metricService) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "Logout", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "Logout", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.Logout(ctx, req)
+}
+
+func (s *metricService) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "Me", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "Me", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.Me(ctx, req)
+}
+
+func (s *metricService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "CreateOperator", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "CreateOperator", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.CreateOperator(ctx, req)
+}
+
+func (s *metricService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "ListOperators", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "ListOperators", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.ListOperators(ctx, req)
+}
+
+func (s *metricService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "operator", "handler", "UpdateOperator", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "operator", "handler", "UpdateOperator", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.UpdateOperator(ctx, req)
+}
@@ -0,0 +1,51 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+)
+
+// NewPolicyService returns a Service that lets only admins manage operators,
+// any operator may log in, log out and look up itself.
+func NewPolicyService(s Service) Service {
+	return &policyService{s}
+}
+
+type policyService struct {
+	Service
+}
+
+func allowAdmin(ctx context.Context) error {
+	op, ok := auth.OperatorFromContext(ctx)
+	if !ok {
+		return errors.Wrap(ErrPermissionDenied, "no operator")
+	}
+	if !op.HasRole(operatorRepository.RoleAdmin) {
+		return errors.Wrap(ErrPermissionDenied, "managing operators requires the admin role")
+	}
+	return nil
+}
+
+func (s *policyService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	if err := allowAdmin(ctx); err != nil {
+		return &CreateOperatorResponse{}, err
+	}
+	return s.Service.CreateOperator(ctx, req)
+}
+
+func (s *policyService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	if err := allowAdmin(ctx); err != nil {
+		return &ListOperatorsResponse{}, err
+	}
+	return s.Service.ListOperators(ctx, req)
+}
+
+func (s *policyService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	if err := allowAdmin(ctx); err != nil {
+		return &UpdateOperatorResponse{}, err
+	}
+	return s.Service.UpdateOperator(ctx, req)
+}
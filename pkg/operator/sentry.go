@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/getsentry/sentry-go"
+)
+
+func NewSentryService(s Service) Service {
+	return &sentryService{s}
+}
+
+type sentryService struct {
+	Service
+}
+
+type sentryLog interface {
+	SentryLog() []interface{}
+}
+
+func (s *sentryService) getSentryLog(req interface{}, resp interface{}) (out map[string][]interface{}) {
+	out = make(map[string][]interface{})
+	if sentry, ok := interface{}(req).(sentryLog); ok {
+		out["request"] = append(out["request"], sentry.SentryLog()...)
+	}
+
+	if sentry, ok := interface{}(resp).(sentryLog); ok {
+		out["response"] = append(out["response"], sentry.SentryLog()...)
+	}
+	return
+}
+
+func (s *sentryService) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "Login")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.Login(ctx, req)
+}
+
+func (s *sentryService) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "Logout")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.Logout(ctx, req)
+}
+
+func (s *sentryService) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "Me")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.Me(ctx, req)
+}
+
+func (s *sentryService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "CreateOperator")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.CreateOperator(ctx, req)
+}
+
+func (s *sentryService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "ListOperators")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.ListOperators(ctx, req)
+}
+
+func (s *sentryService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "UpdateOperator")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.UpdateOperator(ctx, req)
+}
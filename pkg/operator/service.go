@@ -0,0 +1,260 @@
+package operator
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+)
+
+// minPasswordLength is the shortest password accepted for an operator.
+const minPasswordLength = 8
+
+type operatorService struct {
+	repo operatorRepository.Repository
+	ttl  time.Duration
+}
+
+// NewOperatorService returns a Service issuing sessions that live for ttl.
+func NewOperatorService(repo operatorRepository.Repository, ttl time.Duration) Service {
+	return &operatorService{repo: repo, ttl: ttl}
+}
+
+// EnsureAdmin creates an admin with the given credentials when there are
+// no operators at all, so a fresh install can be logged into.
+func EnsureAdmin(ctx context.Context, repo operatorRepository.Repository, login, password string) (bool, error) {
+	if login == "" || password == "" {
+		return false, nil
+	}
+
+	count, err := repo.CountOperators(ctx)
+	if err != nil || count > 0 {
+		return false, err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return false, err
+	}
+
+	err = repo.CreateOperator(ctx, &operatorRepository.Operator{
+		Login:        login,
+		Name:         "Administrator",
+		PasswordHash: hash,
+		Role:         operatorRepository.RoleAdmin,
+	})
+	return err == nil, err
+}
+
+func (s *operatorService) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	resp = &LoginResponse{}
+
+	op, err := s.repo.GetOperatorByLogin(ctx, strings.TrimSpace(req.Login))
+	if errors.Is(err, operatorRepository.ErrNotFound) {
+		return resp, errors.Wrap(ErrUnauthenticated, "wrong login or password")
+	}
+	if err != nil {
+		return resp, err
+	}
+	if !auth.CheckPassword(op.PasswordHash, req.Password) {
+		return resp, errors.Wrap(ErrUnauthenticated, "wrong login or password")
+	}
+	if op.Disabled {
+		return resp, errors.Wrap(ErrUnauthenticated, "operator is disabled")
+	}
+
+	token, hash, err := auth.NewToken()
+	if err != nil {
+		return resp, err
+	}
+	session := operatorRepository.Session{
+		TokenHash:  hash,
+		OperatorID: op.ID,
+		ExpiresAt:  time.Now().Add(s.ttl),
+	}
+	if err := s.repo.CreateSession(ctx, &session); err != nil {
+		return resp, err
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Token = token
+	resp.ExpiresAt = session.ExpiresAt.UTC().Format(time.RFC3339)
+	resp.Operator = OperatorFromRepo(op)
+
+	return resp, nil
+}
+
+func (s *operatorService) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	resp = &LogoutResponse{}
+
+	token := auth.TokenFromContext(ctx)
+	if token == "" {
+		return resp, errors.Wrap(ErrUnauthenticated, "no session token")
+	}
+	if err := s.repo.DeleteSession(ctx, auth.HashToken(token)); err != nil {
+		return resp, err
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+
+	return resp, nil
+}
+
+func (s *operatorService) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	resp = &MeResponse{}
+
+	current, ok := auth.OperatorFromContext(ctx)
+	if !ok {
+		return resp, errors.Wrap(ErrUnauthenticated, "no operator in context")
+	}
+
+	op, err := s.repo.GetOperator(ctx, current.ID)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Operator = OperatorFromRepo(op)
+
+	return resp, nil
+}
+
+func (s *operatorService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	resp = &CreateOperatorResponse{}
+
+	op := operatorRepository.Operator{
+		Login: strings.TrimSpace(req.Login),
+		Name:  strings.TrimSpace(req.Name),
+		Role:  req.Role,
+	}
+	if op.Login == "" {
+		return resp, errors.Wrap(ErrInvalidArgument, "missing login")
+	}
+	if !operatorRepository.ValidRole(op.Role) {
+		return resp, errors.Wrapf(ErrInvalidArgument, "unknown role %q", op.Role)
+	}
+	if op.PasswordHash, err = hashPassword(req.Password); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.CreateOperator(ctx, &op); err != nil {
+		return resp, repoError(err)
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Operator = OperatorFromRepo(&op)
+
+	return resp, nil
+}
+
+func (s *operatorService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	resp = &ListOperatorsResponse{}
+
+	ops, err := s.repo.ListOperators(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	for _, op := range ops {
+		resp.Data = append(resp.Data, *OperatorFromRepo(op))
+	}
+
+	return resp, nil
+}
+
+func (s *operatorService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	resp = &UpdateOperatorResponse{}
+
+	op, err := s.repo.GetOperator(ctx, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	var columns []string
+	if req.Name != nil {
+		op.Name = strings.TrimSpace(*req.Name)
+		columns = append(columns, "name")
+	}
+	if req.Role != nil {
+		if !operatorRepository.ValidRole(*req.Role) {
+			return resp, errors.Wrapf(ErrInvalidArgument, "unknown role %q", *req.Role)
+		}
+		op.Role = *req.Role
+		columns = append(columns, "role")
+	}
+	if req.Password != nil {
+		if op.PasswordHash, err = hashPassword(*req.Password); err != nil {
+			return resp, err
+		}
+		columns = append(columns, "password_hash")
+	}
+	if req.Disabled != nil {
+		op.Disabled = *req.Disabled
+		columns = append(columns, "disabled")
+	}
+	if len(columns) == 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, "nothing to update")
+	}
+
+	if err := s.repo.UpdateOperator(ctx, op, columns); err != nil {
+		return resp, repoError(err)
+	}
+	if req.Password != nil || op.Disabled {
+		if err := s.repo.DeleteOperatorSessions(ctx, op.ID); err != nil {
+			return resp, err
+		}
+	}
+
+	resp.Status = &Status{
+		Status: true,
+	}
+	resp.Operator = OperatorFromRepo(op)
+
+	return resp, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", errors.Wrapf(ErrInvalidArgument, "password must be at least %d characters", minPasswordLength)
+	}
+	return auth.HashPassword(password)
+}
+
+// repoError maps repository errors onto service errors.
+func repoError(err error) error {
+	switch {
+	case errors.Is(err, operatorRepository.ErrNotFound):
+		return errors.Wrap(ErrNotFound, err.Error())
+	case errors.Is(err, operatorRepository.ErrAlreadyExists):
+		return errors.Wrap(ErrAlreadyExists, err.Error())
+	}
+	return err
+}
+
+func OperatorFromRepo(op *operatorRepository.Operator) *Operator {
+	if op == nil {
+		return nil
+	}
+
+	return &Operator{
+		Id:       op.ID,
+		Login:    op.Login,
+		Name:     op.Name,
+		Role:     op.Role,
+		Disabled: op.Disabled,
+	}
+}
@@ -0,0 +1,55 @@
+package operator
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/nakiner/guestcovider/tools/tracing"
+)
+
+// NewTracingService returns an instance of an instrumenting Service.
+func NewTracingService(ctx context.Context, s Service) Service {
+	tracer := tracing.FromContext(ctx)
+	return &tracingService{tracer, s}
+}
+
+type tracingService struct {
+	tracer opentracing.Tracer
+	Service
+}
+
+func (s *tracingService) Login(ctx context.Context, req *LoginRequest) (resp *LoginResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "Login")
+	defer span.Finish()
+	return s.Service.Login(ctx, req)
+}
+
+func (s *tracingService) Logout(ctx context.Context, req *LogoutRequest) (resp *LogoutResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "Logout")
+	defer span.Finish()
+	return s.Service.Logout(ctx, req)
+}
+
+func (s *tracingService) Me(ctx context.Context, req *MeRequest) (resp *MeResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "Me")
+	defer span.Finish()
+	return s.Service.Me(ctx, req)
+}
+
+func (s *tracingService) CreateOperator(ctx context.Context, req *CreateOperatorRequest) (resp *CreateOperatorResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "CreateOperator")
+	defer span.Finish()
+	return s.Service.CreateOperator(ctx, req)
+}
+
+func (s *tracingService) ListOperators(ctx context.Context, req *ListOperatorsRequest) (resp *ListOperatorsResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "ListOperators")
+	defer span.Finish()
+	return s.Service.ListOperators(ctx, req)
+}
+
+func (s *tracingService) UpdateOperator(ctx context.Context, req *UpdateOperatorRequest) (resp *UpdateOperatorResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "UpdateOperator")
+	defer span.Finish()
+	return s.Service.UpdateOperator(ctx, req)
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+// bulkLimit is the most guests a bulk update changes, larger groups are
+// split by the client.
+const bulkLimit = 500
+
+// bulkQuery picks the guests of req, either by ids or by a filter.
+func bulkQuery(req *BulkUpdateUsersRequest) (userRepository.BulkQuery, error) {
+	q := userRepository.BulkQuery{Limit: bulkLimit}
+
+	var filter BulkFilter
+	if req.Filter != nil {
+		filter = BulkFilter{
+			Company: strings.TrimSpace(req.Filter.Company),
+			Guest:   strings.TrimSpace(req.Filter.Guest),
+		}
+	}
+	hasFilter := filter.Company != "" || filter.Guest != ""
+
+	switch {
+	case len(req.Ids) > 0 && hasFilter:
+		return q, errors.Wrap(ErrInvalidArgument, "either ids or a filter, not both")
+	case len(req.Ids) > bulkLimit:
+		return q, errors.Wrapf(ErrInvalidArgument, "at most %d guests at once", bulkLimit)
+	case len(req.Ids) > 0:
+		q.IDs = req.Ids
+	case hasFilter:
+		q.Company = filter.Company
+		q.Guest = filter.Guest
+	default:
+		return q, errors.Wrap(ErrInvalidArgument, "no ids or filter, a company or host is required")
+	}
+	return q, nil
+}
+
+// patchData is the PatchData of the changes, so they are applied and
+// validated the way PatchUser does.
+func (d *BulkUpdateData) patchData() *PatchData {
+	return &PatchData{
+		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
+		CovidPass: d.CovidPass,
+	}
+}
+
+// bulkStatus tells whether every guest was updated.
+func bulkStatus(results []BulkResult, updated uint32) *Status {
+	if int(updated) == len(results) {
+		return &Status{Status: true, Message: "OK"}
+	}
+	return &Status{Message: fmt.Sprintf("%d of %d guests not updated", len(results)-int(updated), len(results))}
+}
+
+func BulkResultFromRepo(i *userRepository.BulkResult) BulkResult {
+	res := BulkResult{Id: i.ID, Ok: i.Err == nil}
+	if i.Err != nil {
+		res.Error = repoError(i.Err).Error()
+		return res
+	}
+	data := UserFromRepo(i.User)
+	res.Data = &data
+	return res
+}
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkQuery(t *testing.T) {
+	q, err := bulkQuery(&BulkUpdateUsersRequest{Ids: []uint64{3, 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 1}, q.IDs)
+	assert.Equal(t, bulkLimit, q.Limit)
+
+	q, err = bulkQuery(&BulkUpdateUsersRequest{Filter: &BulkFilter{Company: " Агима "}})
+	require.NoError(t, err)
+	assert.Equal(t, "Агима", q.Company)
+	assert.Empty(t, q.IDs)
+
+	for name, req := range map[string]*BulkUpdateUsersRequest{
+		"nothing":      {},
+		"blank filter": {Filter: &BulkFilter{Company: " "}},
+		"both":         {Ids: []uint64{1}, Filter: &BulkFilter{Guest: "Петров"}},
+		"too many":     {Ids: make([]uint64, bulkLimit+1)},
+	} {
+		_, err := bulkQuery(req)
+		assert.True(t, errors.Is(err, ErrInvalidArgument), name)
+	}
+}
+
+func TestBulkResultFromRepo(t *testing.T) {
+	res := BulkResultFromRepo(&userRepository.BulkResult{ID: 7, User: &userRepository.User{ID: 7, Surname: "Иванов", Version: 2}})
+	assert.True(t, res.Ok)
+	if assert.NotNil(t, res.Data) {
+		assert.Equal(t, uint64(2), res.Data.Version)
+	}
+
+	res = BulkResultFromRepo(&userRepository.BulkResult{ID: 8, Err: userRepository.ErrNotFound})
+	assert.False(t, res.Ok)
+	assert.Nil(t, res.Data)
+	assert.Contains(t, res.Error, "not found")
+
+	results := []BulkResult{{Ok: true}, {Ok: false}, {Ok: true}}
+	assert.Equal(t, &Status{Message: "1 of 3 guests not updated"}, bulkStatus(results, 2))
+	assert.True(t, bulkStatus(results[:1], 1).Status)
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"strings"
+
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+// apply copies set fields onto c and returns the changed columns. The pass
+// and check-in follow the rules of guests.
+func (d *CompanionData) apply(c *userRepository.Companion) ([]string, error) {
+	p := c.Person()
+	var columns []string
+	set := func(column string, dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+			columns = append(columns, column)
+		}
+	}
+
+	set("surname", &p.Surname, d.Surname)
+	set("name", &p.Name, d.Name)
+	set("entrance", &p.Entrance, d.Entrance)
+	if d.Checkin != nil {
+		p.Checkin = *d.Checkin
+		columns = append(columns, "checkin")
+	}
+	if d.CovidPass != nil {
+		if err := setCovidPass(p, d.CovidPass); err != nil {
+			return nil, err
+		}
+		columns = append(columns, "covid_pass", "covid_pass_test_date", "covid_pass_expires_at", "covid_pass_number")
+	}
+
+	c.SetPerson(p)
+	return columns, nil
+}
+
+// validateCompanion checks the data of a companion the way guests are.
+func validateCompanion(event *eventRepository.Event, c *userRepository.Companion, d *CompanionData) error {
+	if c.Surname == "" {
+		return errors.Wrap(ErrInvalidArgument, "missing surname")
+	}
+	if d.CovidPass != nil {
+		return validateCovidPass(event, c.Person())
+	}
+	return nil
+}
+
+// findCompanion returns the companion of the party, ErrNotFound when the
+// guest has no such companion.
+func findCompanion(u *userRepository.User, id uint64) (*userRepository.Companion, error) {
+	for _, c := range u.Companions {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, errors.Wrapf(ErrNotFound, "companion %d", id)
+}
+
+func CompanionFromRepo(i *userRepository.Companion) Companion {
+	p := i.Person()
+	return Companion{
+		Id:            i.ID,
+		Surname:       i.Surname,
+		Name:          i.Name,
+		CovidPass:     CovidPassFromRepo(p),
+		Checkin:       i.Checkin,
+		CheckinRecord: CheckinRecordFromRepo(p),
+	}
+}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompanionDataApply(t *testing.T) {
+	surname, entrance, checkin := " Иванова ", "B", true
+	c := &userRepository.Companion{ID: 3, Name: "Анна"}
+
+	d := &CompanionData{Surname: &surname, Checkin: &checkin, Entrance: &entrance, CovidPass: &CovidPass{Type: "ПЦР", TestDate: "2021-09-12"}}
+	columns, err := d.apply(c)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"surname", "entrance", "checkin", "covid_pass", "covid_pass_test_date", "covid_pass_expires_at", "covid_pass_number"}, columns)
+	assert.Equal(t, "Иванова", c.Surname)
+	assert.Equal(t, "Анна", c.Name)
+	assert.True(t, c.Checkin)
+	assert.Equal(t, "pcr", c.CovidPass)
+
+	assert.NoError(t, validateCompanion(&eventRepository.Event{}, c, &CompanionData{}))
+	c.Surname = ""
+	assert.True(t, errors.Is(validateCompanion(&eventRepository.Event{}, c, &CompanionData{}), ErrInvalidArgument))
+
+	_, err = (&CompanionData{CovidPass: &CovidPass{Type: "unknown"}}).apply(c)
+	assert.Error(t, err)
+}
+
+func TestCompanionFromRepo(t *testing.T) {
+	u := &userRepository.User{ID: 7, MaxCompanions: 2, Companions: []*userRepository.Companion{
+		{ID: 3, UserID: 7, Surname: "Иванова", CovidPass: "qr"},
+	}}
+
+	c, err := findCompanion(u, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Иванова", c.Surname)
+	_, err = findCompanion(u, 4)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	data := UserFromRepo(u)
+	assert.Equal(t, uint32(2), data.MaxCompanions)
+	if assert.Len(t, data.Companions, 1) {
+		assert.Equal(t, uint64(3), data.Companions[0].Id)
+		assert.Equal(t, "qr", data.Companions[0].CovidPass.Type)
+		assert.Nil(t, data.Companions[0].CheckinRecord)
+	}
+}
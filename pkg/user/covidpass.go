@@ -0,0 +1,230 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/covidcert"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+const dateLayout = "2006-01-02"
+
+var passTypes = map[string]pb.CovidPassType{
+	"":                            pb.CovidPassType_COVID_PASS_TYPE_UNSPECIFIED,
+	userRepository.PassPCR:        pb.CovidPassType_COVID_PASS_TYPE_PCR,
+	userRepository.PassQR:         pb.CovidPassType_COVID_PASS_TYPE_QR,
+	userRepository.PassExpress:    pb.CovidPassType_COVID_PASS_TYPE_EXPRESS,
+	userRepository.PassAntibodies: pb.CovidPassType_COVID_PASS_TYPE_ANTIBODIES,
+}
+
+func passTypeToPB(s string) pb.CovidPassType {
+	passType, _ := userRepository.ParsePassType(s)
+	return passTypes[passType]
+}
+
+// passTypeFromPB returns unknown values as their number for the service
+// to reject.
+func passTypeFromPB(t pb.CovidPassType) string {
+	for passType, v := range passTypes {
+		if v == t {
+			return passType
+		}
+	}
+	return t.String()
+}
+
+// CovidPassFromRepo returns the pass of a guest, nil when there is none.
+func CovidPassFromRepo(i *userRepository.User) *CovidPass {
+	if i.CovidPass == "" {
+		return nil
+	}
+
+	return &CovidPass{
+		Type:              i.CovidPass,
+		TestDate:          formatDate(i.CovidPassTestDate),
+		ExpiresAt:         formatDate(i.CovidPassExpiresAt),
+		CertificateNumber: i.CovidPassNumber,
+	}
+}
+
+// setCovidPass copies the pass onto u, nil or an empty type clears it.
+// The labels the old web UI sent are accepted as types.
+func setCovidPass(u *userRepository.User, p *CovidPass) error {
+	u.CovidPass = ""
+	u.CovidPassTestDate = nil
+	u.CovidPassExpiresAt = nil
+	u.CovidPassNumber = ""
+	if p == nil || p.Type == "" {
+		return nil
+	}
+
+	passType, ok := userRepository.ParsePassType(p.Type)
+	if !ok {
+		return errors.Wrapf(ErrInvalidArgument, "unknown covid pass type %q", p.Type)
+	}
+	testDate, err := parseDate("covid pass test date", p.TestDate)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := parseDate("covid pass expiry", p.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if testDate != nil && expiresAt != nil && expiresAt.Before(*testDate) {
+		return errors.Wrap(ErrInvalidArgument, "covid pass expires before the test date")
+	}
+
+	u.CovidPass = passType
+	u.CovidPassTestDate = testDate
+	u.CovidPassExpiresAt = expiresAt
+	u.CovidPassNumber = p.CertificateNumber
+	return nil
+}
+
+// validateCovidPass rejects a pass the event does not accept, a test taken
+// after the event or a pass that expires before the day the event starts.
+// Events without a date only check the type.
+func validateCovidPass(event *eventRepository.Event, u *userRepository.User) error {
+	if problem := passProblem(event, u); problem != "" {
+		return errors.Wrap(ErrInvalidArgument, problem)
+	}
+	return nil
+}
+
+// passProblem returns why the pass of u is not valid for the event, see
+// validateCovidPass.
+func passProblem(event *eventRepository.Event, u *userRepository.User) string {
+	if u.CovidPass == "" {
+		return ""
+	}
+	if !event.CovidPassTypes.Allows(u.CovidPass) {
+		return fmt.Sprintf("covid pass %q is not accepted at %s", u.CovidPass, event.Name)
+	}
+	if event.StartsAt.IsZero() {
+		return ""
+	}
+
+	day := eventDay(event.StartsAt)
+	if u.CovidPassTestDate != nil && u.CovidPassTestDate.After(day) {
+		return fmt.Sprintf("covid pass test date %s is after %s", formatDate(u.CovidPassTestDate), event.Name)
+	}
+	if expiry := userRepository.PassExpiry(u); expiry != nil && expiry.Before(day) {
+		return fmt.Sprintf("covid pass expires on %s before %s", formatDate(expiry), event.Name)
+	}
+	return ""
+}
+
+// applyPassCheck stores the outcome of a scanned certificate on u. A valid
+// certificate of the guest that is good for the event becomes the pass of
+// u, the returned columns tell what changed.
+func applyPassCheck(event *eventRepository.Event, u *userRepository.User, result *covidcert.Result, now time.Time) []string {
+	check := &userRepository.PassCheck{Status: userRepository.CheckValid, CheckedAt: now}
+	c := result.Certificate
+	if c != nil {
+		check.Source = c.Source
+		check.CertificateID = c.ID
+		check.Holder = c.Holder()
+		check.BirthDate = c.BirthDate
+		check.ExpiresAt = c.ExpiresAt
+	}
+	u.CovidPassCheck = check
+	columns := []string{"covid_pass_check"}
+
+	switch {
+	case !result.Valid:
+		check.Status = userRepository.CheckInvalid
+		check.Reason = result.Reason
+		return columns
+	case !covidcert.MatchHolder(c, u):
+		check.Status = userRepository.CheckNameMismatch
+		check.Reason = fmt.Sprintf("certificate holder %s is not %s %s", c.Holder(), u.Surname, u.Name)
+		return columns
+	}
+
+	pass := *u
+	pass.CovidPass = c.PassType
+	pass.CovidPassNumber = c.ID
+	pass.CovidPassTestDate = nil
+	pass.CovidPassExpiresAt = nil
+	if c.TestedAt != nil {
+		pass.CovidPassTestDate = dateOf(*c.TestedAt)
+	} else if c.ExpiresAt != nil {
+		// the certificate expires at the start of a day, the pass is valid
+		// through the day before
+		pass.CovidPassExpiresAt = dateOf(c.ExpiresAt.Add(-time.Nanosecond))
+	}
+	if problem := passProblem(event, &pass); problem != "" {
+		check.Status = userRepository.CheckInvalid
+		check.Reason = problem
+		return columns
+	}
+
+	*u = pass
+	return append(columns, "covid_pass", "covid_pass_test_date", "covid_pass_expires_at", "covid_pass_number")
+}
+
+// PassCheckFromRepo returns the last certificate check of a guest, nil when
+// none was scanned.
+func PassCheckFromRepo(c *userRepository.PassCheck) *PassCheck {
+	if c == nil {
+		return nil
+	}
+
+	check := PassCheck{
+		Status:        c.Status,
+		Reason:        c.Reason,
+		Source:        c.Source,
+		CertificateId: c.CertificateID,
+		Holder:        c.Holder,
+		BirthDate:     c.BirthDate,
+		CheckedAt:     c.CheckedAt.Format(time.RFC3339),
+		CheckedBy:     c.CheckedBy,
+	}
+	if c.ExpiresAt != nil {
+		check.ExpiresAt = c.ExpiresAt.Format(time.RFC3339)
+	}
+	return &check
+}
+
+// passError maps verifier errors onto service errors.
+func passError(err error) error {
+	switch errors.Cause(err) {
+	case covidcert.ErrUnsupported, covidcert.ErrMalformed, covidcert.ErrManualCheck:
+		return errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+	return err
+}
+
+func dateOf(t time.Time) *time.Time {
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// eventDay is the calendar day an event starts on, dates of a pass have
+// no time zone and are compared as days.
+func eventDay(t time.Time) time.Time {
+	return *dateOf(t)
+}
+
+func parseDate(field, s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidArgument, "%s %q is not a YYYY-MM-DD date", field, s)
+	}
+	return &t, nil
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
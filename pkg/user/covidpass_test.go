@@ -0,0 +1,118 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/covidcert"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCovidPass(t *testing.T) {
+	var u userRepository.User
+	require.NoError(t, setCovidPass(&u, &CovidPass{Type: "ПЦР", TestDate: "2021-09-12", CertificateNumber: "77-123"}))
+	assert.Equal(t, userRepository.PassPCR, u.CovidPass)
+	assert.Equal(t, "2021-09-12", formatDate(u.CovidPassTestDate))
+	assert.Equal(t, &CovidPass{Type: "pcr", TestDate: "2021-09-12", CertificateNumber: "77-123"}, CovidPassFromRepo(&u))
+
+	require.NoError(t, setCovidPass(&u, nil))
+	assert.Equal(t, userRepository.User{}, u)
+	assert.Nil(t, CovidPassFromRepo(&u))
+
+	for _, bad := range []*CovidPass{
+		{Type: "vaccine"},
+		{Type: "qr", ExpiresAt: "14.09.2021"},
+		{Type: "pcr", TestDate: "2021-09-12", ExpiresAt: "2021-09-11"},
+	} {
+		assert.Equal(t, ErrInvalidArgument, errors.Cause(setCovidPass(&u, bad)), bad)
+	}
+}
+
+func TestValidateCovidPass(t *testing.T) {
+	event := &eventRepository.Event{
+		Name:           "Forum",
+		StartsAt:       time.Date(2021, 9, 14, 19, 0, 0, 0, time.FixedZone("MSK", 3*3600)),
+		CovidPassTypes: eventRepository.PassTypes{"pcr", "qr"},
+	}
+	check := func(p *CovidPass) error {
+		var u userRepository.User
+		require.NoError(t, setCovidPass(&u, p))
+		return validateCovidPass(event, &u)
+	}
+
+	assert.NoError(t, check(nil))
+	assert.NoError(t, check(&CovidPass{Type: "pcr", TestDate: "2021-09-11"}))
+	assert.NoError(t, check(&CovidPass{Type: "qr", ExpiresAt: "2021-09-14"}))
+	assert.Error(t, check(&CovidPass{Type: "pcr", TestDate: "2021-09-10"}), "expired")
+	assert.Error(t, check(&CovidPass{Type: "pcr", TestDate: "2021-09-15"}), "tested after the event")
+	assert.Error(t, check(&CovidPass{Type: "qr", ExpiresAt: "2021-09-13"}), "expired")
+	assert.Error(t, check(&CovidPass{Type: "express"}), "not accepted")
+
+	event.StartsAt = time.Time{}
+	assert.NoError(t, check(&CovidPass{Type: "pcr", TestDate: "2021-09-10"}))
+}
+
+func TestApplyPassCheck(t *testing.T) {
+	event := &eventRepository.Event{
+		Name:           "Forum",
+		StartsAt:       time.Date(2021, 9, 14, 19, 0, 0, 0, time.UTC),
+		CovidPassTypes: eventRepository.PassTypes{"pcr", "qr"},
+	}
+	now := time.Date(2021, 9, 14, 18, 0, 0, 0, time.UTC)
+	expires := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := &covidcert.Certificate{
+		Source:          covidcert.SourceGosuslugi,
+		ID:              "9160000012345678",
+		Kind:            covidcert.KindVaccination,
+		FamilyName:      "И*****",
+		GivenName:       "И****",
+		FamilyNameLatin: "I*****",
+		GivenNameLatin:  "I****",
+		PassType:        userRepository.PassQR,
+		ExpiresAt:       &expires,
+	}
+
+	u := userRepository.User{Surname: "Иванов", Name: "Иван"}
+	columns := applyPassCheck(event, &u, &covidcert.Result{Certificate: cert, Valid: true}, now)
+	assert.Equal(t, []string{"covid_pass_check", "covid_pass", "covid_pass_test_date", "covid_pass_expires_at", "covid_pass_number"}, columns)
+	assert.Equal(t, userRepository.CheckValid, u.CovidPassCheck.Status)
+	assert.Equal(t, &CovidPass{Type: "qr", ExpiresAt: "2022-05-31", CertificateNumber: "9160000012345678"}, CovidPassFromRepo(&u))
+
+	u = userRepository.User{Surname: "Петров", Name: "Иван"}
+	columns = applyPassCheck(event, &u, &covidcert.Result{Certificate: cert, Valid: true}, now)
+	assert.Equal(t, []string{"covid_pass_check"}, columns)
+	assert.Equal(t, userRepository.CheckNameMismatch, u.CovidPassCheck.Status)
+	assert.Empty(t, u.CovidPass)
+
+	u = userRepository.User{Surname: "Иванов", Name: "Иван"}
+	applyPassCheck(event, &u, &covidcert.Result{Certificate: cert, Reason: "certificate has expired"}, now)
+	assert.Equal(t, &PassCheck{
+		Status:        "invalid",
+		Reason:        "certificate has expired",
+		Source:        "gosuslugi",
+		CertificateId: "9160000012345678",
+		Holder:        cert.Holder(),
+		ExpiresAt:     "2022-06-01T00:00:00Z",
+		CheckedAt:     "2021-09-14T18:00:00Z",
+	}, PassCheckFromRepo(u.CovidPassCheck))
+
+	tested := time.Date(2021, 9, 10, 9, 0, 0, 0, time.UTC)
+	test := &covidcert.Certificate{Source: covidcert.SourceDCC, Kind: covidcert.KindTest, FamilyName: "Иванов", PassType: userRepository.PassExpress, TestedAt: &tested}
+	u = userRepository.User{Surname: "Иванов"}
+	applyPassCheck(event, &u, &covidcert.Result{Certificate: test, Valid: true}, now)
+	assert.Equal(t, userRepository.CheckInvalid, u.CovidPassCheck.Status, "express tests are not accepted")
+	assert.Empty(t, u.CovidPass)
+}
+
+func TestCovidPassPB(t *testing.T) {
+	p := &CovidPass{Type: "Антитела", TestDate: "2021-08-01"}
+	assert.Equal(t, pb.CovidPassType_COVID_PASS_TYPE_ANTIBODIES, CovidPassToPB(p).Type)
+	assert.Equal(t, "antibodies", PBToCovidPass(CovidPassToPB(p)).Type)
+	assert.Equal(t, "", PBToCovidPass(&pb.CovidPass{}).Type)
+	assert.Equal(t, "42", PBToCovidPass(&pb.CovidPass{Type: 42}).Type)
+}
@@ -0,0 +1,356 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/edgeRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+// edgeService serves the door of one event from the local copy kept by
+// EdgeSync, so check-ins go on while the central instance is out of reach.
+// Changes are queued as ops for EdgeSync to send, methods that need the
+// whole guest list or its history are left to the central instance.
+type edgeService struct {
+	repo    edgeRepository.Repository
+	eventID uint64
+	// name stands for the actor of changes made without one
+	name     string
+	onChange func()
+}
+
+// NewEdgeService returns the Service of an edge keeping the event. onChange
+// is called after every change queued for the central instance.
+func NewEdgeService(repo edgeRepository.Repository, eventID uint64, name string, onChange func()) Service {
+	return &edgeService{repo: repo, eventID: eventID, name: name, onChange: onChange}
+}
+
+func (s *edgeService) checkEvent(id uint64) error {
+	if id != 0 && id != s.eventID {
+		return errors.Wrapf(ErrNotFound, "event %d is not kept at this door", id)
+	}
+	return nil
+}
+
+func (s *edgeService) actor(ctx context.Context) string {
+	if name := userRepository.ActorFromContext(auditContext(ctx)).Name; name != "" {
+		return name
+	}
+	return s.name
+}
+
+func (s *edgeService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (resp *UpdateUserResponse, err error) {
+	resp = &UpdateUserResponse{}
+
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "no update data")
+	}
+	if req.Version == 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, "no version of the user")
+	}
+	if err := s.checkEvent(req.EventId); err != nil {
+		return resp, err
+	}
+
+	// parsed the way the central instance does, which checks the pass
+	// against the event once the change is sent
+	var pass userRepository.User
+	if err := setCovidPass(&pass, req.Data.CovidPass); err != nil {
+		return resp, err
+	}
+	data := UpdateData{
+		CovidPass: CovidPassFromRepo(&pass),
+		Checkin:   req.Data.Checkin,
+		Entrance:  strings.TrimSpace(req.Data.Entrance),
+	}
+	actor := s.actor(ctx)
+
+	var user User
+	err = s.repo.ChangeGuest(ctx, s.eventID, req.Id, func(g *edgeRepository.Guest) (*edgeRepository.Op, error) {
+		if err := guestData(g, &user); err != nil {
+			return nil, err
+		}
+		if g.LocalVersion != req.Version {
+			return nil, &ConflictError{Current: &user}
+		}
+		base := user
+
+		if !reflect.DeepEqual(user.CovidPass, data.CovidPass) {
+			user.CovidPass = data.CovidPass
+			// a hand picked pass no longer is the one checked
+			user.PassCheck = nil
+		}
+		stampCheckinRecord(&user, data.Checkin, data.Entrance, actor, time.Now())
+		user.Version = g.LocalVersion + 1
+
+		return queueOp(g, &base, &user, edgeRepository.OpUpdate, &data, actor)
+	})
+	if err != nil {
+		return resp, edgeError(err)
+	}
+	s.onChange()
+
+	resp.Data = &user
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *edgeService) SearchUser(ctx context.Context, req *SearchUserRequest) (resp *SearchUserResponse, err error) {
+	resp = &SearchUserResponse{}
+
+	query := req.Query
+	if query == "" {
+		query = req.Surname
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return resp, err
+	}
+	if err := s.checkEvent(req.EventId); err != nil {
+		return resp, err
+	}
+
+	result, err := s.repo.SearchGuests(ctx, userRepository.SearchQuery{
+		EventID: s.eventID,
+		Terms:   searchTerms(query),
+		Limit:   searchLimit(req.Limit),
+		After:   after,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, g := range result.Guests {
+		var user User
+		if err := guestData(g, &user); err != nil {
+			return resp, err
+		}
+		resp.Data = append(resp.Data, user)
+	}
+	resp.Total = uint64(result.Total)
+	resp.NextCursor = encodeCursor(result.Next)
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *edgeService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	resp = &GetUserResponse{}
+
+	if err := s.checkEvent(req.EventId); err != nil {
+		return resp, err
+	}
+
+	g, err := s.repo.GetGuest(ctx, s.eventID, req.Id)
+	if err != nil {
+		return resp, edgeError(err)
+	}
+	var user User
+	if err := guestData(g, &user); err != nil {
+		return resp, err
+	}
+
+	resp.Data = &user
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+// CheckinByToken checks in the guest holding a scanned invitation, a guest
+// checked in at another door while the link was down is only found out
+// once the check-in is sent.
+func (s *edgeService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	resp = &CheckinByTokenResponse{}
+
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return resp, errors.Wrap(ErrInvalidArgument, "no token")
+	}
+	if err := s.checkEvent(req.EventId); err != nil {
+		return resp, err
+	}
+
+	g, err := s.repo.GetGuestByToken(ctx, s.eventID, token)
+	if err != nil {
+		return resp, edgeError(err)
+	}
+
+	checkin := CheckinByTokenRequest{Token: token, EventId: s.eventID, Entrance: strings.TrimSpace(req.Entrance)}
+	actor := s.actor(ctx)
+
+	var user User
+	err = s.repo.ChangeGuest(ctx, s.eventID, g.ID, func(g *edgeRepository.Guest) (*edgeRepository.Op, error) {
+		if err := guestData(g, &user); err != nil {
+			return nil, err
+		}
+		if user.Checkin {
+			return nil, userRepository.ErrAlreadyCheckedIn
+		}
+		base := user
+
+		stampCheckinRecord(&user, true, checkin.Entrance, actor, time.Now())
+		user.Version = g.LocalVersion + 1
+
+		return queueOp(g, &base, &user, edgeRepository.OpCheckin, &checkin, actor)
+	})
+	if err != nil && !errors.Is(err, userRepository.ErrAlreadyCheckedIn) {
+		return resp, edgeError(err)
+	}
+
+	resp.Data = &user
+	if user.CheckinRecord != nil {
+		resp.CheckedInAt = user.CheckinRecord.CheckedInAt
+	}
+
+	if err != nil {
+		resp.AlreadyCheckedIn = true
+		resp.Status = &Status{
+			Status:  false,
+			Message: "already checked in",
+		}
+		if resp.CheckedInAt != "" {
+			resp.Status.Message += " at " + resp.CheckedInAt
+		}
+		return resp, nil
+	}
+	s.onChange()
+
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *edgeService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	return &ImportUsersResponse{}, centralOnly("ImportUsers")
+}
+
+func (s *edgeService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	return centralOnly("ExportUsers")
+}
+
+func (s *edgeService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	return &CreateUserResponse{}, centralOnly("CreateUser")
+}
+
+func (s *edgeService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	return &PatchUserResponse{}, centralOnly("PatchUser")
+}
+
+func (s *edgeService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	return &DeleteUserResponse{}, centralOnly("DeleteUser")
+}
+
+func (s *edgeService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	return &GetUserHistoryResponse{}, centralOnly("GetUserHistory")
+}
+
+func (s *edgeService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	return &GetUserQRResponse{}, centralOnly("GetUserQR")
+}
+
+func (s *edgeService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	return &VerifyPassResponse{}, centralOnly("VerifyPass")
+}
+
+func (s *edgeService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	return centralOnly("WatchUsers")
+}
+
+func (s *edgeService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	return &BulkUpdateUsersResponse{}, centralOnly("BulkUpdateUsers")
+}
+
+func (s *edgeService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	return &AddCompanionResponse{}, centralOnly("AddCompanion")
+}
+
+func (s *edgeService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	return &PatchCompanionResponse{}, centralOnly("PatchCompanion")
+}
+
+func (s *edgeService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	return &DeleteCompanionResponse{}, centralOnly("DeleteCompanion")
+}
+
+func centralOnly(method string) error {
+	return errors.Wrapf(ErrNotImplemented, "%s is served by the central instance only", method)
+}
+
+// edgeError maps errors of the edge repository to service errors.
+func edgeError(err error) error {
+	if errors.Is(err, edgeRepository.ErrNotFound) {
+		return errors.Wrap(ErrNotFound, err.Error())
+	}
+	return err
+}
+
+// guestData decodes the guest as door clients see it into u.
+func guestData(g *edgeRepository.Guest, u *User) error {
+	if err := json.Unmarshal(g.Data, u); err != nil {
+		return errors.Wrapf(err, "decode guest %d", g.ID)
+	}
+	u.Version = g.LocalVersion
+	return nil
+}
+
+// queueOp stores u, changed from base, as the door copy of g and returns
+// the op sending req.
+func queueOp(g *edgeRepository.Guest, base, u *User, kind string, req interface{}, actor string) (*edgeRepository.Op, error) {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	baseData, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	g.Data = data
+	return &edgeRepository.Op{Kind: kind, Request: request, Base: baseData, Actor: actor}, nil
+}
+
+// stampCheckinRecord sets the check-in state of u like the repository of
+// the central instance does: checking in starts a new record, checking out
+// closes the current one.
+func stampCheckinRecord(u *User, checkin bool, entrance, actor string, at time.Time) {
+	if u.Checkin == checkin {
+		return
+	}
+	u.Checkin = checkin
+
+	if !checkin {
+		if u.CheckinRecord != nil {
+			record := *u.CheckinRecord
+			record.CheckedOutAt = at.Format(time.RFC3339)
+			record.CheckedOutBy = actor
+			u.CheckinRecord = &record
+		}
+		return
+	}
+	u.CheckinRecord = &CheckinRecord{
+		CheckedInAt: at.Format(time.RFC3339),
+		CheckedInBy: actor,
+		Entrance:    entrance,
+	}
+}
@@ -0,0 +1,227 @@
+//go:build edge
+// +build edge
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/edgeRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCentral answers the calls EdgeSync sends with the guests it holds.
+type fakeCentral struct {
+	Service
+	users map[uint64]*User
+	// acting lists the operators changes were sent for
+	acting []string
+}
+
+func (c *fakeCentral) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	return send(UserChange{Action: ChangeReady, Seq: 1})
+}
+
+func (c *fakeCentral) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	for _, u := range c.users {
+		current := *u
+		if req.WithTokens {
+			current.InviteToken = "token"
+		}
+		if err := send(current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fakeCentral) GetUser(ctx context.Context, req *GetUserRequest) (*GetUserResponse, error) {
+	u, ok := c.users[req.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &GetUserResponse{Data: u}, nil
+}
+
+func (c *fakeCentral) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (*CheckinByTokenResponse, error) {
+	c.acting = append(c.acting, auth.ActingFromContext(ctx))
+	u := c.users[1]
+	if u.Checkin {
+		return &CheckinByTokenResponse{Data: u, AlreadyCheckedIn: true, CheckedInAt: u.CheckinRecord.CheckedInAt}, nil
+	}
+	u.Checkin, u.Version = true, u.Version+1
+	u.CheckinRecord = &CheckinRecord{CheckedInAt: "2021-09-14T19:05:00Z", Entrance: req.Entrance}
+	return &CheckinByTokenResponse{Data: u}, nil
+}
+
+func (c *fakeCentral) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*UpdateUserResponse, error) {
+	c.acting = append(c.acting, auth.ActingFromContext(ctx))
+	u := c.users[req.Id]
+	if u.Version != req.Version {
+		current := *u
+		return nil, &ConflictError{Current: &current}
+	}
+	u.Checkin, u.CovidPass, u.Version = req.Data.Checkin, req.Data.CovidPass, u.Version+1
+	return &UpdateUserResponse{Data: u}, nil
+}
+
+func newTestEdge(t *testing.T, central *fakeCentral) (Service, *EdgeSync, edgeRepository.Repository) {
+	db, err := edgeRepository.Open(filepath.Join(t.TempDir(), "edge.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if pool, err := db.DB(); err == nil {
+			pool.Close()
+		}
+	})
+	repo := edgeRepository.NewEdgeDBRepository(db)
+
+	sync := NewEdgeSync(central, repo, 1, nil, 0)
+	require.NoError(t, sync.load(context.Background()))
+	return NewEdgeService(repo, 1, "door1", sync.Notify), sync, repo
+}
+
+func TestEdgeCheckinConflict(t *testing.T) {
+	ctx := context.Background()
+	central := &fakeCentral{users: map[uint64]*User{
+		1: {Id: 1, EventId: 1, Surname: "Иванов", Version: 3},
+	}}
+	edge, sync, repo := newTestEdge(t, central)
+
+	resp, err := edge.CheckinByToken(ctx, &CheckinByTokenRequest{Token: "token", EventId: 1, Entrance: "north"})
+	require.NoError(t, err)
+	assert.False(t, resp.AlreadyCheckedIn)
+	assert.True(t, resp.Data.Checkin)
+	assert.Equal(t, "door1", resp.Data.CheckinRecord.CheckedInBy)
+
+	resp, err = edge.CheckinByToken(ctx, &CheckinByTokenRequest{Token: "token", EventId: 1})
+	require.NoError(t, err)
+	assert.True(t, resp.AlreadyCheckedIn)
+
+	_, err = edge.CheckinByToken(ctx, &CheckinByTokenRequest{Token: "other", EventId: 1})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// another door checked the guest in at the central instance first
+	central.users[1].Checkin = true
+	central.users[1].Version = 4
+	central.users[1].CheckinRecord = &CheckinRecord{CheckedInAt: "2021-09-14T19:00:00Z", Entrance: "south"}
+
+	require.NoError(t, sync.pushPending(ctx))
+	conflicts, err := sync.Conflicts(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, conflicts, 1) {
+		assert.Equal(t, edgeRepository.OpCheckin, conflicts[0].Kind)
+		assert.Contains(t, conflicts[0].Reason, "2021-09-14T19:00:00Z")
+		assert.Equal(t, "door1", conflicts[0].Actor)
+	}
+
+	got, err := edge.GetUser(ctx, &GetUserRequest{Id: 1, EventId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "south", got.Data.CheckinRecord.Entrance)
+	// the token is kept for check-in only, door clients never read it
+	assert.Empty(t, got.Data.InviteToken)
+	pending, err := repo.CountPendingOps(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+}
+
+func TestEdgeUpdateMerge(t *testing.T) {
+	ctx := context.Background()
+	central := &fakeCentral{users: map[uint64]*User{
+		1: {Id: 1, EventId: 1, Surname: "Иванов", Version: 3},
+	}}
+	edge, sync, _ := newTestEdge(t, central)
+
+	got, err := edge.GetUser(ctx, &GetUserRequest{Id: 1, EventId: 1})
+	require.NoError(t, err)
+
+	_, err = edge.UpdateUser(ctx, &UpdateUserRequest{Id: 1, EventId: 1, Version: got.Data.Version + 1, Data: &UpdateData{Checkin: true}})
+	var conflict *ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, got.Data.Version, conflict.Current.Version)
+
+	doorCtx := auth.WithOperator(ctx, auth.Operator{Login: "anna", Role: "door"})
+	updated, err := edge.UpdateUser(doorCtx, &UpdateUserRequest{Id: 1, EventId: 1, Version: got.Data.Version, Data: &UpdateData{Checkin: true}})
+	require.NoError(t, err)
+	assert.True(t, updated.Data.Checkin)
+	assert.Equal(t, "anna", updated.Data.CheckinRecord.CheckedInBy)
+
+	// the pass changed at the central instance meanwhile, the check-in is
+	// merged onto it
+	central.users[1].CovidPass = &CovidPass{Type: "pcr", TestDate: "2021-09-13"}
+	central.users[1].Version = 4
+
+	require.NoError(t, sync.pushPending(ctx))
+	assert.True(t, central.users[1].Checkin)
+	assert.Equal(t, "pcr", central.users[1].CovidPass.Type)
+	// sent again once merged onto the changed version
+	assert.Equal(t, []string{"anna", "anna"}, central.acting)
+	conflicts, err := sync.Conflicts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	got, err = edge.GetUser(ctx, &GetUserRequest{Id: 1, EventId: 1})
+	require.NoError(t, err)
+	assert.True(t, got.Data.Checkin)
+	assert.Equal(t, "pcr", got.Data.CovidPass.Type)
+
+	_, err = edge.DeleteUser(ctx, &DeleteUserRequest{Id: 1})
+	assert.ErrorIs(t, err, ErrNotImplemented)
+}
+
+func TestMergeUpdate(t *testing.T) {
+	pcr := &CovidPass{Type: "pcr", TestDate: "2021-09-13"}
+	qr := &CovidPass{Type: "qr", CertificateNumber: "9000"}
+	north := &CheckinRecord{CheckedInAt: "2021-09-14T19:00:00Z", CheckedInBy: "central", Entrance: "north"}
+	for _, c := range []struct {
+		base    User
+		data    UpdateData
+		current User
+		merged  *UpdateData
+		reason  bool
+	}{
+		// nothing left to change
+		{User{}, UpdateData{Checkin: true}, User{Checkin: true}, nil, true},
+		{User{}, UpdateData{}, User{Checkin: true, CovidPass: pcr}, nil, false},
+		// the check-in goes onto the pass changed at the central instance
+		{User{}, UpdateData{Checkin: true}, User{CovidPass: pcr}, &UpdateData{Checkin: true, CovidPass: pcr}, false},
+		// the pass goes onto the check-in made at the central instance
+		{User{}, UpdateData{CovidPass: qr}, User{Checkin: true}, &UpdateData{Checkin: true, CovidPass: qr}, false},
+		// both changed the pass, the central one is kept
+		{User{}, UpdateData{Checkin: true, CovidPass: qr}, User{CovidPass: pcr}, &UpdateData{Checkin: true, CovidPass: pcr}, true},
+		// a check-out
+		{User{Checkin: true}, UpdateData{}, User{Checkin: true, CovidPass: pcr}, &UpdateData{CovidPass: pcr}, false},
+		// the door check-in brings its entrance
+		{User{}, UpdateData{Checkin: true, Entrance: "south"}, User{}, &UpdateData{Checkin: true, Entrance: "south"}, false},
+		// the central check-in won, its entrance stays with the door pass
+		{User{}, UpdateData{Checkin: true, Entrance: "south", CovidPass: qr}, User{Checkin: true, CheckinRecord: north},
+			&UpdateData{Checkin: true, Entrance: "north", CovidPass: qr}, true},
+		// an entrance changed on its own is not dropped silently
+		{User{Checkin: true, CheckinRecord: north}, UpdateData{Checkin: true, Entrance: "south"}, User{Checkin: true, CheckinRecord: north}, nil, true},
+	} {
+		merged, reason := mergeUpdate(&c.base, &c.data, &c.current)
+		assert.Equal(t, c.merged, merged, "%+v", c)
+		assert.Equal(t, c.reason, reason != "", "%+v", c)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	assert.True(t, rejected(status.Error(codes.InvalidArgument, "bad pass")))
+	assert.True(t, rejected(errors.Wrap(status.Error(codes.NotFound, "gone"), "op 1")))
+	assert.True(t, rejected(errors.Wrap(ErrNotFound, "gone")))
+	assert.False(t, rejected(status.Error(codes.Unavailable, "down")))
+	assert.False(t, rejected(errors.New("reset")))
+}
+
+func TestEdgeConflictJSON(t *testing.T) {
+	b, err := json.Marshal(EdgeConflict{Id: 1, Kind: edgeRepository.OpCheckin, Request: json.RawMessage(`{"token":"t"}`)})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"request":{"token":"t"}`)
+}
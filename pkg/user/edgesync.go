@@ -0,0 +1,648 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/gorilla/mux"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/edgeRepository"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// edgePushBatch is how many queued ops are read at once.
+const edgePushBatch = 100
+
+// edgeMergeAttempts limits how many times an update is merged with a guest
+// that keeps changing at the central instance.
+const edgeMergeAttempts = 3
+
+// errWatchReady stops the change stream once the last seq is known.
+var errWatchReady = errors.New("change stream is ready")
+
+type edgeMetrics struct {
+	online     metrics.Gauge
+	pendingOps metrics.Gauge
+	syncedOps  metrics.Counter
+	conflicts  metrics.Counter
+}
+
+var (
+	edgeMetricsOnce sync.Once
+	edgeMetricsSet  *edgeMetrics
+)
+
+func getEdgeMetrics() *edgeMetrics {
+	edgeMetricsOnce.Do(func() {
+		edgeMetricsSet = &edgeMetrics{
+			online: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "guest_covider",
+				Name:      "edge_online",
+				Help:      "1 while the edge follows the central instance.",
+			}, nil),
+			pendingOps: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "guest_covider",
+				Name:      "edge_pending_ops",
+				Help:      "Number of changes made at the door waiting to be sent to the central instance.",
+			}, nil),
+			syncedOps: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "edge_synced_ops_total",
+				Help:      "Number of changes made at the door sent to the central instance.",
+			}, []string{"kind"}),
+			conflicts: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "guest_covider",
+				Name:      "edge_conflicts_total",
+				Help:      "Number of changes made at the door the central instance did not take as they were.",
+			}, []string{"kind"}),
+		}
+	})
+	return edgeMetricsSet
+}
+
+// EdgeSync keeps the copy of an event at an edge in step with the central
+// instance over its UserService. It follows the central changes and sends
+// the changes made at the door in the order they were made.
+//
+// Conflicts are settled the same way every time: the check-in the central
+// instance accepted first wins, and an update made at the door is merged
+// field by field with the guest changed meanwhile, keeping the central
+// covid pass when both sides changed it. Every change not taken as it was
+// is recorded as a conflict.
+type EdgeSync struct {
+	central Service
+	repo    edgeRepository.Repository
+	eventID uint64
+	// login opens a session at the central instance and returns its token.
+	login func(ctx context.Context) (string, error)
+	retry time.Duration
+	wake  chan struct{}
+
+	mu      sync.Mutex
+	token   string
+	online  bool
+	lastErr error
+}
+
+// NewEdgeSync returns the sync of the event with central, sessions lost
+// are opened again after retry.
+func NewEdgeSync(central Service, repo edgeRepository.Repository, eventID uint64, login func(ctx context.Context) (string, error), retry time.Duration) *EdgeSync {
+	return &EdgeSync{
+		central: central,
+		repo:    repo,
+		eventID: eventID,
+		login:   login,
+		retry:   retry,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Notify makes the sync send queued changes, it never blocks.
+func (s *EdgeSync) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run syncs the event until ctx is done, errors ending a session are
+// passed to onError and the session is opened again.
+func (s *EdgeSync) Run(ctx context.Context, onError func(error)) {
+	for {
+		err := s.session(ctx)
+		s.setOnline(false, err)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retry):
+		}
+	}
+}
+
+func (s *EdgeSync) setOnline(online bool, err error) {
+	s.mu.Lock()
+	s.online = online
+	if err != nil {
+		s.lastErr = err
+	}
+	s.mu.Unlock()
+
+	if online {
+		getEdgeMetrics().online.Set(1)
+	} else {
+		getEdgeMetrics().online.Set(0)
+	}
+}
+
+func (s *EdgeSync) session(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	token, err := s.sessionToken(ctx)
+	if err != nil {
+		return errors.Wrap(err, "log in to the central instance")
+	}
+	ctx = auth.WithToken(ctx, token)
+
+	state, err := s.repo.GetSyncState(ctx, s.eventID)
+	if err != nil {
+		return err
+	}
+	if state.SyncedAt.IsZero() {
+		if err := s.load(ctx); err != nil {
+			return s.sessionError(errors.Wrap(err, "load guests"))
+		}
+		if state, err = s.repo.GetSyncState(ctx, s.eventID); err != nil {
+			return err
+		}
+	}
+	s.setOnline(true, nil)
+
+	errc := make(chan error, 2)
+	go func() {
+		errc <- s.follow(ctx, state.Seq)
+	}()
+	go func() {
+		errc <- s.push(ctx)
+	}()
+	err = <-errc
+	cancel()
+	<-errc
+	return s.sessionError(err)
+}
+
+func (s *EdgeSync) sessionToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+	if token != "" {
+		return token, nil
+	}
+
+	token, err := s.login(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	s.token = token
+	s.mu.Unlock()
+	return token, nil
+}
+
+// sessionError forgets the session token once the central instance no
+// longer takes it.
+func (s *EdgeSync) sessionError(err error) error {
+	if status.Code(errors.Cause(err)) == codes.Unauthenticated {
+		s.mu.Lock()
+		s.token = ""
+		s.mu.Unlock()
+	}
+	return err
+}
+
+// load copies the guests of the event. The seq is taken before the guests,
+// so changes made while they are read are followed afterwards.
+func (s *EdgeSync) load(ctx context.Context) error {
+	var seq uint64
+	watchCtx, stop := context.WithCancel(ctx)
+	err := s.central.WatchUsers(watchCtx, &WatchUsersRequest{EventId: s.eventID}, func(c UserChange) error {
+		if c.Action != ChangeReady {
+			return nil
+		}
+		seq = c.Seq
+		return errWatchReady
+	})
+	stop()
+	if err != errWatchReady {
+		if err == nil {
+			err = errors.New("change stream closed before it was ready")
+		}
+		return err
+	}
+
+	err = s.central.ExportUsers(ctx, &ExportUsersRequest{EventId: s.eventID, WithTokens: true}, func(u User) error {
+		g, err := centralGuest(s.eventID, &u)
+		if err != nil {
+			return err
+		}
+		return s.repo.StoreCentral(ctx, g)
+	})
+	if err != nil {
+		return err
+	}
+	return s.repo.SetSyncSeq(ctx, s.eventID, seq)
+}
+
+// follow applies central changes after seq until the stream ends.
+func (s *EdgeSync) follow(ctx context.Context, seq uint64) error {
+	err := s.central.WatchUsers(ctx, &WatchUsersRequest{EventId: s.eventID, AfterSeq: seq, WithTokens: true}, func(c UserChange) error {
+		if c.Action == ChangeReady {
+			return nil
+		}
+		if err := s.apply(ctx, c); err != nil {
+			return err
+		}
+		return s.repo.SetSyncSeq(ctx, s.eventID, c.Seq)
+	})
+	if err == nil {
+		err = errors.New("central instance closed the change stream")
+	}
+	return errors.Wrap(err, "follow central changes")
+}
+
+func (s *EdgeSync) apply(ctx context.Context, c UserChange) error {
+	if c.EventId != s.eventID {
+		return nil
+	}
+	if c.Data == nil {
+		return s.repo.DeleteGuest(ctx, s.eventID, c.UserId)
+	}
+	g, err := centralGuest(s.eventID, c.Data)
+	if err != nil {
+		return err
+	}
+	return s.repo.StoreCentral(ctx, g)
+}
+
+// centralGuest returns the door copy of a guest sent by the central
+// instance. The invitation token is kept out of the copy door clients read,
+// replies without one leave the stored token as it is.
+func centralGuest(eventID uint64, u *User) (*edgeRepository.Guest, error) {
+	data := *u
+	data.InviteToken = ""
+	central, err := json.Marshal(&data)
+	if err != nil {
+		return nil, err
+	}
+	return &edgeRepository.Guest{
+		ID:          u.Id,
+		EventID:     eventID,
+		SearchText:  userRepository.NormalizeSearch(strings.Join([]string{u.Surname, u.Name, u.Company, u.Guest, u.ContactPhone}, " ")),
+		InviteToken: u.InviteToken,
+		Version:     u.Version,
+		Central:     central,
+	}, nil
+}
+
+// push sends queued ops whenever Notify is called and every retry period,
+// an op the central instance could not take for now ends the session.
+func (s *EdgeSync) push(ctx context.Context) error {
+	ticker := time.NewTicker(s.retry)
+	defer ticker.Stop()
+	for {
+		if err := s.pushPending(ctx); err != nil {
+			return errors.Wrap(err, "send door changes")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *EdgeSync) pushPending(ctx context.Context) error {
+	defer func() {
+		if count, err := s.repo.CountPendingOps(ctx, s.eventID); err == nil {
+			getEdgeMetrics().pendingOps.Set(float64(count))
+		}
+	}()
+
+	for {
+		ops, err := s.repo.PendingOps(ctx, s.eventID, edgePushBatch)
+		if err != nil {
+			return err
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+		for _, op := range ops {
+			if err := s.send(ctx, op); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends an op for the operator who made it at the door and stores the
+// guest the central instance answers with.
+func (s *EdgeSync) send(ctx context.Context, op *edgeRepository.Op) error {
+	if op.Actor != "" {
+		ctx = auth.WithActing(ctx, op.Actor)
+	}
+	var central *User
+	var reason string
+	var err error
+	switch op.Kind {
+	case edgeRepository.OpCheckin:
+		central, reason, err = s.sendCheckin(ctx, op)
+	case edgeRepository.OpUpdate:
+		central, reason, err = s.sendUpdate(ctx, op)
+	default:
+		err = errors.Wrapf(ErrInvalidArgument, "unknown op %q", op.Kind)
+	}
+	if err != nil {
+		if !rejected(err) {
+			return errors.Wrapf(err, "op %d", op.ID)
+		}
+		reason = fmt.Sprintf("rejected by the central instance: %s", err)
+		if central, err = s.currentGuest(ctx, op.UserID); err != nil {
+			return errors.Wrapf(err, "op %d", op.ID)
+		}
+	}
+
+	var conflict *edgeRepository.Conflict
+	if reason != "" {
+		conflict = &edgeRepository.Conflict{Reason: reason}
+		if central != nil {
+			if conflict.Central, err = json.Marshal(central); err != nil {
+				return err
+			}
+		}
+	}
+	var g *edgeRepository.Guest
+	if central != nil {
+		if g, err = centralGuest(s.eventID, central); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.ResolveOp(ctx, op, conflict, g); err != nil {
+		return err
+	}
+
+	getEdgeMetrics().syncedOps.With("kind", op.Kind).Add(1)
+	if conflict != nil {
+		getEdgeMetrics().conflicts.With("kind", op.Kind).Add(1)
+	}
+	return nil
+}
+
+func (s *EdgeSync) sendCheckin(ctx context.Context, op *edgeRepository.Op) (*User, string, error) {
+	var req CheckinByTokenRequest
+	if err := json.Unmarshal(op.Request, &req); err != nil {
+		return nil, "", errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+
+	resp, err := s.central.CheckinByToken(ctx, &req)
+	if err != nil {
+		return nil, "", err
+	}
+	if !resp.AlreadyCheckedIn {
+		return resp.Data, "", nil
+	}
+	reason := "checked in at the central instance first"
+	if resp.CheckedInAt != "" {
+		reason += " at " + resp.CheckedInAt
+	}
+	return resp.Data, reason, nil
+}
+
+// sendUpdate merges an update made at the door with the guest as the
+// central instance has it and sends the result based on that version.
+func (s *EdgeSync) sendUpdate(ctx context.Context, op *edgeRepository.Op) (*User, string, error) {
+	var data UpdateData
+	if err := json.Unmarshal(op.Request, &data); err != nil {
+		return nil, "", errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+	var base User
+	if err := json.Unmarshal(op.Base, &base); err != nil {
+		return nil, "", errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+
+	current := &User{}
+	g, err := s.repo.GetGuest(ctx, s.eventID, op.UserID)
+	if err == nil {
+		err = json.Unmarshal(g.Central, current)
+		current.Version = g.Version
+	}
+	if err != nil {
+		if current, err = s.currentGuest(ctx, op.UserID); err != nil {
+			return nil, "", err
+		}
+		if current == nil {
+			return nil, "", errors.Wrap(ErrNotFound, "guest is deleted at the central instance")
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		merged, reason := mergeUpdate(&base, &data, current)
+		if merged == nil {
+			return current, reason, nil
+		}
+		if attempt == edgeMergeAttempts {
+			return current, "kept changing at the central instance while merging", nil
+		}
+
+		resp, err := s.central.UpdateUser(ctx, &UpdateUserRequest{Id: op.UserID, EventId: s.eventID, Version: current.Version, Data: merged})
+		if err == nil {
+			return resp.Data, reason, nil
+		}
+		var conflict *ConflictError
+		if !errors.As(err, &conflict) {
+			return nil, "", err
+		}
+		if current = conflict.Current; current == nil {
+			if current, err = s.currentGuest(ctx, op.UserID); err != nil {
+				return nil, "", err
+			}
+			if current == nil {
+				return nil, "", errors.Wrap(ErrNotFound, "guest is deleted at the central instance")
+			}
+		}
+	}
+}
+
+// mergeUpdate applies the fields an update made at the door changed from
+// base onto the guest as the central instance has it. It returns the update
+// to send, nil when there is nothing left to change, and why a part of the
+// door update was dropped: a check-in the central instance has already, a
+// covid pass changed there as well, or an entrance changed on its own.
+// The entrance of the door goes out only with its check-in.
+func mergeUpdate(base *User, data *UpdateData, current *User) (*UpdateData, string) {
+	merged := UpdateData{CovidPass: current.CovidPass, Checkin: current.Checkin, Entrance: checkinEntrance(current)}
+	var reasons []string
+
+	if data.Checkin != base.Checkin {
+		if data.Checkin && current.Checkin {
+			reasons = append(reasons, "checked in at the central instance first")
+		} else {
+			merged.Checkin = data.Checkin
+			if data.Checkin {
+				merged.Entrance = data.Entrance
+			}
+		}
+	} else if data.Checkin && data.Entrance != checkinEntrance(base) && reflect.DeepEqual(data.CovidPass, base.CovidPass) {
+		reasons = append(reasons, "entrance is only recorded on check-in, the central entrance is kept")
+	}
+	if !reflect.DeepEqual(data.CovidPass, base.CovidPass) && !reflect.DeepEqual(data.CovidPass, current.CovidPass) {
+		if reflect.DeepEqual(current.CovidPass, base.CovidPass) {
+			merged.CovidPass = data.CovidPass
+		} else {
+			reasons = append(reasons, "covid pass was changed at the central instance as well, its pass is kept")
+		}
+	}
+
+	reason := strings.Join(reasons, "; ")
+	if merged.Checkin == current.Checkin && merged.Entrance == checkinEntrance(current) && reflect.DeepEqual(merged.CovidPass, current.CovidPass) {
+		return nil, reason
+	}
+	return &merged, reason
+}
+
+// checkinEntrance returns the entrance u is checked in at, empty when u is
+// not checked in.
+func checkinEntrance(u *User) string {
+	if !u.Checkin || u.CheckinRecord == nil {
+		return ""
+	}
+	return u.CheckinRecord.Entrance
+}
+
+// currentGuest reads the guest at the central instance, nil when it is gone.
+func (s *EdgeSync) currentGuest(ctx context.Context, id uint64) (*User, error) {
+	resp, err := s.central.GetUser(ctx, &GetUserRequest{Id: id, EventId: s.eventID})
+	if status.Code(errors.Cause(err)) == codes.NotFound || errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// rejected tells whether the central instance refused an op for good, other
+// errors are sent again later.
+func rejected(err error) bool {
+	code := getGRPCCode(err)
+	if st, ok := status.FromError(errors.Cause(err)); ok {
+		code = st.Code()
+	}
+	switch code {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied, codes.FailedPrecondition, codes.AlreadyExists, codes.OutOfRange:
+		return true
+	default:
+		return false
+	}
+}
+
+// EdgeStatus is how the edge keeps up with the central instance.
+type EdgeStatus struct {
+	EventId    uint64 `json:"eventId"`
+	Online     bool   `json:"online"`
+	LastSeq    uint64 `json:"lastSeq"`
+	SyncedAt   string `json:"syncedAt,omitempty"`
+	PendingOps int64  `json:"pendingOps"`
+	LastError  string `json:"lastError,omitempty"`
+}
+
+// EdgeConflict is a change made at the door the central instance did not
+// take as it was, Central is the guest it kept.
+type EdgeConflict struct {
+	Id         uint64          `json:"id"`
+	UserId     uint64          `json:"userId"`
+	Kind       string          `json:"kind"`
+	Reason     string          `json:"reason"`
+	Request    json.RawMessage `json:"request"`
+	Actor      string          `json:"actor,omitempty"`
+	DoneAt     string          `json:"doneAt"`
+	ResolvedAt string          `json:"resolvedAt"`
+	Central    *User           `json:"central,omitempty"`
+}
+
+func (s *EdgeSync) Status(ctx context.Context) (*EdgeStatus, error) {
+	state, err := s.repo.GetSyncState(ctx, s.eventID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.CountPendingOps(ctx, s.eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &EdgeStatus{EventId: s.eventID, LastSeq: state.Seq, PendingOps: pending}
+	if !state.SyncedAt.IsZero() {
+		st.SyncedAt = state.SyncedAt.Format(time.RFC3339)
+	}
+	s.mu.Lock()
+	st.Online = s.online
+	if s.lastErr != nil {
+		st.LastError = s.lastErr.Error()
+	}
+	s.mu.Unlock()
+	return st, nil
+}
+
+// Conflicts returns the conflicts of the event, latest first.
+func (s *EdgeSync) Conflicts(ctx context.Context) ([]EdgeConflict, error) {
+	records, err := s.repo.ListConflicts(ctx, s.eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]EdgeConflict, 0, len(records))
+	for _, c := range records {
+		conflict := EdgeConflict{
+			Id:         c.ID,
+			UserId:     c.UserID,
+			Kind:       c.Kind,
+			Reason:     c.Reason,
+			Request:    c.Request,
+			Actor:      c.Actor,
+			DoneAt:     c.DoneAt.Format(time.RFC3339),
+			ResolvedAt: c.CreatedAt.Format(time.RFC3339),
+		}
+		if len(c.Central) > 0 {
+			conflict.Central = &User{}
+			if err := json.Unmarshal(c.Central, conflict.Central); err != nil {
+				return nil, errors.Wrapf(err, "decode conflict %d", c.ID)
+			}
+		}
+		out = append(out, conflict)
+	}
+	return out, nil
+}
+
+// MakeEdgeHTTPHandler reports the sync of an edge: GET /edge/status and the
+// conflict report at GET /edge/conflicts.
+func MakeEdgeHTTPHandler(s *EdgeSync) http.Handler {
+	r := mux.NewRouter()
+
+	r.Methods("GET").Path("/edge/status").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st, err := s.Status(r.Context())
+		writeEdgeResponse(r.Context(), w, st, err)
+	})
+	r.Methods("GET").Path("/edge/conflicts").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conflicts, err := s.Conflicts(r.Context())
+		writeEdgeResponse(r.Context(), w, conflicts, err)
+	})
+
+	return r
+}
+
+func writeEdgeResponse(ctx context.Context, w http.ResponseWriter, response interface{}, err error) {
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(response)
+}
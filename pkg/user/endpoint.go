@@ -10,13 +10,19 @@ import (
 
 //easyjson:json
 type SearchUserRequest struct {
-	Surname string `json:"surname,omitempty"`
+	Surname string `json:"surname,omitempty" schema:"surname,omitempty"`
+	EventId uint64 `json:"eventId,omitempty" schema:"eventId,omitempty"`
+	Query   string `json:"query,omitempty" schema:"query,omitempty"`
+	Limit   uint32 `json:"limit,omitempty" schema:"limit,omitempty"`
+	Cursor  string `json:"cursor,omitempty" schema:"cursor,omitempty"`
 }
 
 //easyjson:json
 type SearchUserResponse struct {
-	Status *Status `json:"status,omitempty"`
-	Data   []User  `json:"data,omitempty"`
+	Status     *Status `json:"status,omitempty"`
+	Data       []User  `json:"data,omitempty"`
+	Total      uint64  `json:"total,omitempty"`
+	NextCursor string  `json:"nextCursor,omitempty"`
 }
 
 //easyjson:json
@@ -27,40 +33,415 @@ type Status struct {
 
 //easyjson:json
 type UpdateData struct {
-	CovidPass string `json:"covidPass,omitempty"`
-	Checkin   bool   `json:"checkin,omitempty"`
+	CovidPass *CovidPass `json:"covidPass,omitempty"`
+	Checkin   bool       `json:"checkin,omitempty"`
+	Entrance  string     `json:"entrance,omitempty"`
 }
 
 //easyjson:json
 type UpdateUserRequest struct {
-	Id   uint64      `json:"id,omitempty"`
-	Data *UpdateData `json:"data,omitempty"`
+	Id      uint64      `json:"id,omitempty"`
+	Data    *UpdateData `json:"data,omitempty"`
+	EventId uint64      `json:"eventId,omitempty"`
+	Version uint64      `json:"version,omitempty"`
 }
 
 //easyjson:json
 type UpdateUserResponse struct {
 	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
 }
 
 //easyjson:json
 type User struct {
-	Id           uint64 `json:"id"`
-	Status       string `json:"status"`
-	Company      string `json:"company"`
-	Surname      string `json:"surname"`
-	Name         string `json:"name"`
-	Guest        string `json:"guest"`
-	CovidPass    string `json:"covidPass"`
-	Rank         string `json:"rank,omitempty"`
-	ContactPhone string `json:"contactPhone"`
-	ContactMail  string `json:"contactMail"`
-	Checkin      bool   `json:"checkin"`
+	Id            uint64         `json:"id"`
+	Status        string         `json:"status"`
+	Company       string         `json:"company"`
+	Surname       string         `json:"surname"`
+	Name          string         `json:"name"`
+	Guest         string         `json:"guest"`
+	CovidPass     *CovidPass     `json:"covidPass"`
+	Rank          string         `json:"rank,omitempty"`
+	ContactPhone  string         `json:"contactPhone"`
+	ContactMail   string         `json:"contactMail"`
+	Checkin       bool           `json:"checkin"`
+	EventId       uint64         `json:"eventId"`
+	CheckinRecord *CheckinRecord `json:"checkinRecord,omitempty"`
+	PassCheck     *PassCheck     `json:"passCheck,omitempty"`
+	Version       uint64         `json:"version"`
+	MaxCompanions uint32         `json:"maxCompanions"`
+	Companions    []Companion    `json:"companions,omitempty"`
+	// InviteToken is only sent to edge sync accounts asking WithTokens.
+	InviteToken string `json:"inviteToken,omitempty"`
+}
+
+// Companion is a person coming with a guest.
+//
+//easyjson:json
+type Companion struct {
+	Id            uint64         `json:"id"`
+	Surname       string         `json:"surname"`
+	Name          string         `json:"name"`
+	CovidPass     *CovidPass     `json:"covidPass"`
+	Checkin       bool           `json:"checkin"`
+	CheckinRecord *CheckinRecord `json:"checkinRecord,omitempty"`
+}
+
+// CheckinRecord is the last check-in of a guest, times are RFC 3339.
+//
+//easyjson:json
+type CheckinRecord struct {
+	CheckedInAt  string `json:"checkedInAt"`
+	CheckedInBy  string `json:"checkedInBy"`
+	Entrance     string `json:"entrance"`
+	CheckedOutAt string `json:"checkedOutAt,omitempty"`
+	CheckedOutBy string `json:"checkedOutBy,omitempty"`
+}
+
+// CovidPass is the covid pass of a guest, Type is one of the pass types
+// (pcr, qr, express, antibodies) and dates are YYYY-MM-DD.
+//
+//easyjson:json
+type CovidPass struct {
+	Type              string `json:"type"`
+	TestDate          string `json:"testDate,omitempty"`
+	ExpiresAt         string `json:"expiresAt,omitempty"`
+	CertificateNumber string `json:"certificateNumber,omitempty"`
+}
+
+//easyjson:json
+type CreateUserRequest struct {
+	EventId uint64 `json:"eventId,omitempty"`
+	Data    *User  `json:"data,omitempty"`
+}
+
+//easyjson:json
+type CreateUserResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+//easyjson:json
+type GetUserRequest struct {
+	Id      uint64 `json:"id,omitempty"`
+	EventId uint64 `json:"eventId,omitempty"`
+}
+
+//easyjson:json
+type GetUserResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+//easyjson:json
+type DeleteUserRequest struct {
+	Id      uint64 `json:"id,omitempty"`
+	EventId uint64 `json:"eventId,omitempty"`
+}
+
+//easyjson:json
+type DeleteUserResponse struct {
+	Status *Status `json:"status,omitempty"`
+}
+
+// PatchData holds guest fields to change, nil fields are left untouched.
+//
+//easyjson:json
+type PatchData struct {
+	Status        *string    `json:"status,omitempty"`
+	Company       *string    `json:"company,omitempty"`
+	Surname       *string    `json:"surname,omitempty"`
+	Name          *string    `json:"name,omitempty"`
+	Guest         *string    `json:"guest,omitempty"`
+	CovidPass     *CovidPass `json:"covidPass,omitempty"`
+	Rank          *string    `json:"rank,omitempty"`
+	ContactPhone  *string    `json:"contactPhone,omitempty"`
+	ContactMail   *string    `json:"contactMail,omitempty"`
+	Checkin       *bool      `json:"checkin,omitempty"`
+	Entrance      *string    `json:"entrance,omitempty"`
+	MaxCompanions *uint32    `json:"maxCompanions,omitempty"`
+}
+
+//easyjson:json
+type PatchUserRequest struct {
+	Id      uint64     `json:"id,omitempty"`
+	EventId uint64     `json:"eventId,omitempty"`
+	Data    *PatchData `json:"data,omitempty"`
+}
+
+//easyjson:json
+type PatchUserResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+//easyjson:json
+type GetUserHistoryRequest struct {
+	Id      uint64 `json:"id,omitempty"`
+	EventId uint64 `json:"eventId,omitempty"`
+}
+
+//easyjson:json
+type AuditRecord struct {
+	Id        uint64            `json:"id"`
+	UserId    uint64            `json:"userId"`
+	Action    string            `json:"action"`
+	Actor     string            `json:"actor"`
+	Transport string            `json:"transport"`
+	OldValues map[string]string `json:"oldValues,omitempty"`
+	NewValues map[string]string `json:"newValues,omitempty"`
+	CreatedAt string            `json:"createdAt"`
+}
+
+//easyjson:json
+type GetUserHistoryResponse struct {
+	Status *Status       `json:"status,omitempty"`
+	Data   []AuditRecord `json:"data,omitempty"`
+}
+
+//easyjson:json
+type ImportUsersRequest struct {
+	Format  string `json:"format,omitempty" schema:"format"`
+	DryRun  bool   `json:"dryRun,omitempty" schema:"dryRun"`
+	Content []byte `json:"content,omitempty" schema:"-"`
+	EventId uint64 `json:"eventId,omitempty" schema:"eventId"`
+}
+
+//easyjson:json
+type ImportRowReport struct {
+	Row     uint32   `json:"row"`
+	Surname string   `json:"surname"`
+	Name    string   `json:"name"`
+	Errors  []string `json:"errors"`
+}
+
+//easyjson:json
+type ImportUsersResponse struct {
+	Status  *Status           `json:"status,omitempty"`
+	DryRun  bool              `json:"dryRun"`
+	Total   uint32            `json:"total"`
+	Valid   uint32            `json:"valid"`
+	Created uint32            `json:"created"`
+	Updated uint32            `json:"updated"`
+	Rows    []ImportRowReport `json:"rows,omitempty"`
+}
+
+//easyjson:json
+type ExportUsersRequest struct {
+	Format  string `json:"format,omitempty" schema:"format,omitempty"`
+	Checkin *bool  `json:"checkin,omitempty" schema:"checkin,omitempty"`
+	Company string `json:"company,omitempty" schema:"company,omitempty"`
+	Status  string `json:"status,omitempty" schema:"status,omitempty"`
+	EventId uint64 `json:"eventId,omitempty" schema:"eventId,omitempty"`
+	// WithTokens sends invitation tokens along, edge sync accounts only.
+	WithTokens bool `json:"withTokens,omitempty" schema:"withTokens,omitempty"`
+}
+
+//easyjson:json
+type GetUserQRRequest struct {
+	Id      uint64 `json:"id,omitempty" schema:"-"`
+	EventId uint64 `json:"eventId,omitempty" schema:"eventId,omitempty"`
+	Format  string `json:"format,omitempty" schema:"format,omitempty"`
+	Scale   uint32 `json:"scale,omitempty" schema:"scale,omitempty"`
+}
+
+// GetUserQRResponse is written as the bare image by the HTTP transport.
+//
+//easyjson:json
+type GetUserQRResponse struct {
+	Status      *Status `json:"status,omitempty"`
+	Token       string  `json:"token"`
+	ContentType string  `json:"contentType"`
+	Image       []byte  `json:"image"`
+}
+
+//easyjson:json
+type CheckinByTokenRequest struct {
+	Token    string `json:"token,omitempty"`
+	EventId  uint64 `json:"eventId,omitempty"`
+	Entrance string `json:"entrance,omitempty"`
+}
+
+//easyjson:json
+type CheckinByTokenResponse struct {
+	Status           *Status `json:"status,omitempty"`
+	Data             *User   `json:"data,omitempty"`
+	AlreadyCheckedIn bool    `json:"alreadyCheckedIn"`
+	CheckedInAt      string  `json:"checkedInAt,omitempty"`
+}
+
+//easyjson:json
+type VerifyPassRequest struct {
+	Id      uint64 `json:"id,omitempty"`
+	EventId uint64 `json:"eventId,omitempty"`
+	Payload string `json:"payload,omitempty"`
+}
+
+//easyjson:json
+type VerifyPassResponse struct {
+	Status *Status    `json:"status,omitempty"`
+	Check  *PassCheck `json:"check,omitempty"`
+	Data   *User      `json:"data,omitempty"`
+}
+
+// CompanionData holds the fields of a companion to set, nil ones are left
+// as they are.
+//
+//easyjson:json
+type CompanionData struct {
+	Surname   *string    `json:"surname,omitempty"`
+	Name      *string    `json:"name,omitempty"`
+	CovidPass *CovidPass `json:"covidPass,omitempty"`
+	Checkin   *bool      `json:"checkin,omitempty"`
+	Entrance  *string    `json:"entrance,omitempty"`
+}
+
+//easyjson:json
+type AddCompanionRequest struct {
+	UserId  uint64         `json:"userId,omitempty"`
+	EventId uint64         `json:"eventId,omitempty"`
+	Data    *CompanionData `json:"data,omitempty"`
+}
+
+//easyjson:json
+type AddCompanionResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+//easyjson:json
+type PatchCompanionRequest struct {
+	Id      uint64         `json:"id,omitempty"`
+	UserId  uint64         `json:"userId,omitempty"`
+	EventId uint64         `json:"eventId,omitempty"`
+	Data    *CompanionData `json:"data,omitempty"`
+}
+
+//easyjson:json
+type PatchCompanionResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+//easyjson:json
+type DeleteCompanionRequest struct {
+	Id      uint64 `json:"id,omitempty"`
+	UserId  uint64 `json:"userId,omitempty"`
+	EventId uint64 `json:"eventId,omitempty"`
+}
+
+//easyjson:json
+type DeleteCompanionResponse struct {
+	Status *Status `json:"status,omitempty"`
+	Data   *User   `json:"data,omitempty"`
+}
+
+// BulkFilter picks guests by company and host ignoring case.
+//
+//easyjson:json
+type BulkFilter struct {
+	Company string `json:"company,omitempty"`
+	Guest   string `json:"guest,omitempty"`
+}
+
+// BulkUpdateData holds the changes applied to every picked guest, nil
+// fields are left as they are.
+//
+//easyjson:json
+type BulkUpdateData struct {
+	Checkin   *bool      `json:"checkin,omitempty"`
+	Entrance  *string    `json:"entrance,omitempty"`
+	CovidPass *CovidPass `json:"covidPass,omitempty"`
+}
+
+//easyjson:json
+type BulkUpdateUsersRequest struct {
+	EventId      uint64          `json:"eventId,omitempty"`
+	Ids          []uint64        `json:"ids,omitempty"`
+	Filter       *BulkFilter     `json:"filter,omitempty"`
+	Data         *BulkUpdateData `json:"data,omitempty"`
+	AllOrNothing bool            `json:"allOrNothing,omitempty"`
+}
+
+//easyjson:json
+type BulkResult struct {
+	Id    uint64 `json:"id"`
+	Ok    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	Data  *User  `json:"data,omitempty"`
 }
 
+//easyjson:json
+type BulkUpdateUsersResponse struct {
+	Status  *Status      `json:"status,omitempty"`
+	Results []BulkResult `json:"results,omitempty"`
+	Updated uint32       `json:"updated"`
+}
+
+// PassCheck is the outcome of a scanned certificate, Status is valid,
+// invalid or name_mismatch and times are RFC 3339.
+//
+//easyjson:json
+type PassCheck struct {
+	Status        string `json:"status"`
+	Reason        string `json:"reason,omitempty"`
+	Source        string `json:"source"`
+	CertificateId string `json:"certificateId,omitempty"`
+	Holder        string `json:"holder,omitempty"`
+	BirthDate     string `json:"birthDate,omitempty"`
+	ExpiresAt     string `json:"expiresAt,omitempty"`
+	CheckedAt     string `json:"checkedAt"`
+	CheckedBy     string `json:"checkedBy,omitempty"`
+}
+
+//easyjson:json
+type WatchUsersRequest struct {
+	EventId  uint64 `json:"eventId,omitempty" schema:"eventId,omitempty"`
+	AfterSeq uint64 `json:"afterSeq,omitempty" schema:"afterSeq,omitempty"`
+	// WithTokens sends invitation tokens along, edge sync accounts only.
+	WithTokens bool `json:"withTokens,omitempty" schema:"withTokens,omitempty"`
+}
+
+// UserChange is a committed change of a guest, Data is nil once the guest
+// is deleted.
+//
+//easyjson:json
+type UserChange struct {
+	Seq       uint64 `json:"seq"`
+	Action    string `json:"action"`
+	UserId    uint64 `json:"userId,omitempty"`
+	EventId   uint64 `json:"eventId"`
+	Data      *User  `json:"data,omitempty"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// exportFunc streams guests to send, transports provide their own
+// implementation since go-kit endpoints are request-response only.
+type exportFunc func(ctx context.Context, req *ExportUsersRequest, send func(User) error) error
+
+// watchFunc streams guest changes to send like exportFunc.
+type watchFunc func(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error
+
 //easyjson:skip
 type endpoints struct {
-	UpdateUserEndpoint endpoint.Endpoint
-	SearchUserEndpoint endpoint.Endpoint
+	UpdateUserEndpoint      endpoint.Endpoint
+	SearchUserEndpoint      endpoint.Endpoint
+	ImportUsersEndpoint     endpoint.Endpoint
+	CreateUserEndpoint      endpoint.Endpoint
+	GetUserEndpoint         endpoint.Endpoint
+	PatchUserEndpoint       endpoint.Endpoint
+	DeleteUserEndpoint      endpoint.Endpoint
+	GetUserHistoryEndpoint  endpoint.Endpoint
+	GetUserQREndpoint       endpoint.Endpoint
+	CheckinByTokenEndpoint  endpoint.Endpoint
+	VerifyPassEndpoint      endpoint.Endpoint
+	BulkUpdateUsersEndpoint endpoint.Endpoint
+	AddCompanionEndpoint    endpoint.Endpoint
+	PatchCompanionEndpoint  endpoint.Endpoint
+	DeleteCompanionEndpoint endpoint.Endpoint
+	ExportUsersStream       exportFunc
+	WatchUsersStream        watchFunc
 }
 
 func (e endpoints) UpdateUser(ctx context.Context, req *UpdateUserRequest) (resp *UpdateUserResponse, err error) {
@@ -81,6 +462,131 @@ func (e endpoints) SearchUser(ctx context.Context, req *SearchUserRequest) (resp
 	return &r, err
 }
 
+func (e endpoints) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	response, err := e.ImportUsersEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(ImportUsersResponse)
+	return &r, err
+}
+
+func (e endpoints) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	return e.ExportUsersStream(ctx, req, send)
+}
+
+func (e endpoints) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	return e.WatchUsersStream(ctx, req, send)
+}
+
+func (e endpoints) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	response, err := e.CreateUserEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(CreateUserResponse)
+	return &r, err
+}
+
+func (e endpoints) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	response, err := e.GetUserEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(GetUserResponse)
+	return &r, err
+}
+
+func (e endpoints) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	response, err := e.PatchUserEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(PatchUserResponse)
+	return &r, err
+}
+
+func (e endpoints) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	response, err := e.DeleteUserEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(DeleteUserResponse)
+	return &r, err
+}
+
+func (e endpoints) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	response, err := e.GetUserHistoryEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(GetUserHistoryResponse)
+	return &r, err
+}
+
+func (e endpoints) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	response, err := e.GetUserQREndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(GetUserQRResponse)
+	return &r, err
+}
+
+func (e endpoints) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	response, err := e.CheckinByTokenEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(CheckinByTokenResponse)
+	return &r, err
+}
+
+func (e endpoints) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	response, err := e.VerifyPassEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(VerifyPassResponse)
+	return &r, err
+}
+
+func (e endpoints) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	response, err := e.BulkUpdateUsersEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(BulkUpdateUsersResponse)
+	return &r, err
+}
+
+func (e endpoints) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	response, err := e.AddCompanionEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(AddCompanionResponse)
+	return &r, err
+}
+
+func (e endpoints) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	response, err := e.PatchCompanionEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(PatchCompanionResponse)
+	return &r, err
+}
+
+func (e endpoints) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	response, err := e.DeleteCompanionEndpoint(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r := response.(DeleteCompanionResponse)
+	return &r, err
+}
+
 func makeUpdateUserEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(UpdateUserRequest)
@@ -94,3 +600,94 @@ func makeSearchUserEndpoint(s Service) endpoint.Endpoint {
 		return s.SearchUser(ctx, &req)
 	}
 }
+
+func makeImportUsersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ImportUsersRequest)
+		return s.ImportUsers(ctx, &req)
+	}
+}
+
+func makeCreateUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CreateUserRequest)
+		return s.CreateUser(ctx, &req)
+	}
+}
+
+func makeGetUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetUserRequest)
+		return s.GetUser(ctx, &req)
+	}
+}
+
+func makePatchUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PatchUserRequest)
+		return s.PatchUser(ctx, &req)
+	}
+}
+
+func makeDeleteUserEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteUserRequest)
+		return s.DeleteUser(ctx, &req)
+	}
+}
+
+func makeGetUserHistoryEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetUserHistoryRequest)
+		return s.GetUserHistory(ctx, &req)
+	}
+}
+
+func makeGetUserQREndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetUserQRRequest)
+		return s.GetUserQR(ctx, &req)
+	}
+}
+
+func makeCheckinByTokenEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(CheckinByTokenRequest)
+		return s.CheckinByToken(ctx, &req)
+	}
+}
+
+func makeVerifyPassEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(VerifyPassRequest)
+		return s.VerifyPass(ctx, &req)
+	}
+}
+
+func makeBulkUpdateUsersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(BulkUpdateUsersRequest)
+		return s.BulkUpdateUsers(ctx, &req)
+	}
+}
+
+func makeAddCompanionEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(AddCompanionRequest)
+		return s.AddCompanion(ctx, &req)
+	}
+}
+
+func makePatchCompanionEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(PatchCompanionRequest)
+		return s.PatchCompanion(ctx, &req)
+	}
+}
+
+func makeDeleteCompanionEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeleteCompanionRequest)
+		return s.DeleteCompanion(ctx, &req)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
 )
 
 var (
@@ -14,8 +18,37 @@ var (
 	ErrNotFound        = errors.New("not found")
 	errBadRoute        = errors.New("bad route")
 	ErrInvalidRequest  = errors.New("invalid params in request")
+	// ErrPermissionDenied is returned when the role of the operator does not allow the call.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrConflict is returned when the guest was changed since the version
+	// the update is based on.
+	ErrConflict = errors.New("conflict")
+	// ErrUnavailable is returned when the service is shutting down, the call
+	// should be retried on another replica.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrNotImplemented is returned by an edge for methods only the central
+	// instance serves.
+	ErrNotImplemented = errors.New("not implemented")
 )
 
+// ConflictError is an ErrConflict carrying the guest as it is now, so the
+// client can merge and retry without reading it again.
+type ConflictError struct {
+	Current *User
+}
+
+func (e *ConflictError) Error() string {
+	return "user was changed since it was read"
+}
+
+func (e *ConflictError) Cause() error {
+	return ErrConflict
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
 type ContextHTTPKey struct{}
 
 type HTTPInfo struct {
@@ -48,7 +81,71 @@ func getHTTPStatusCode(err error) int {
 		return http.StatusBadRequest
 	case ErrNotFound:
 		return http.StatusNotFound
+	case ErrPermissionDenied:
+		return http.StatusForbidden
+	case ErrConflict:
+		return http.StatusConflict
+	case ErrUnavailable:
+		return http.StatusServiceUnavailable
+	case ErrNotImplemented:
+		return http.StatusNotImplemented
 	default:
 		return http.StatusInternalServerError
 	}
 }
+
+// getGRPCCode returns grpc status code from error.
+func getGRPCCode(err error) codes.Code {
+	switch getHTTPStatusCode(err) {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcError turns an error from business-layer into a grpc status,
+// errors that already carry a status are returned as is. The current guest
+// of a conflict is attached to the status details.
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	st := status.New(getGRPCCode(err), err.Error())
+	var conflict *ConflictError
+	if errors.As(err, &conflict) && conflict.Current != nil {
+		if detailed, derr := st.WithDetails(UserToPB(conflict.Current)); derr == nil {
+			st = detailed
+		}
+	}
+	return st.Err()
+}
+
+// conflictFromStatus restores the ConflictError of an ABORTED status.
+func conflictFromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return err
+	}
+	conflict := &ConflictError{}
+	for _, d := range st.Details() {
+		if u, ok := d.(*pb.User); ok {
+			conflict.Current = PBToUser(u)
+		}
+	}
+	return conflict
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConflictError(t *testing.T) {
+	err := repoError(&userRepository.ConflictError{Current: &userRepository.User{ID: 7, Surname: "Иванов", Version: 3}})
+	assert.Equal(t, http.StatusConflict, getHTTPStatusCode(err))
+	assert.True(t, errors.Is(err, ErrConflict))
+
+	w := httptest.NewRecorder()
+	encodeError(context.Background(), err, w)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var body struct {
+		Current *User `json:"current"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	if assert.NotNil(t, body.Current) {
+		assert.Equal(t, uint64(3), body.Current.Version)
+	}
+
+	st := grpcError(err)
+	assert.Equal(t, codes.Aborted, status.Code(st))
+	var conflict *ConflictError
+	if assert.True(t, errors.As(conflictFromStatus(st), &conflict)) && assert.NotNil(t, conflict.Current) {
+		assert.Equal(t, "Иванов", conflict.Current.Surname)
+		assert.Equal(t, uint64(3), conflict.Current.Version)
+	}
+
+	assert.Equal(t, ErrNotFound, errors.Cause(repoError(userRepository.ErrNotFound)))
+}
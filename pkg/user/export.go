@@ -0,0 +1,223 @@
+package user
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+)
+
+const FormatJSON = "json"
+
+var exportColumns = []string{
+	"ID",
+	"Status",
+	"Company",
+	"Surname",
+	"Name",
+	"Guest",
+	"Rank",
+	"ContactPhone",
+	"ContactMail",
+	"CovidPass",
+	"CovidPassTestDate",
+	"CovidPassExpiresAt",
+	"CovidPassNumber",
+	"Checkin",
+	"CheckinAt",
+	"CheckinBy",
+	"Entrance",
+	"CheckoutAt",
+}
+
+func exportRecord(u User) []string {
+	var record CheckinRecord
+	if u.CheckinRecord != nil {
+		record = *u.CheckinRecord
+	}
+	var pass CovidPass
+	if u.CovidPass != nil {
+		pass = *u.CovidPass
+	}
+
+	return []string{
+		strconv.FormatUint(u.Id, 10),
+		u.Status,
+		u.Company,
+		u.Surname,
+		u.Name,
+		u.Guest,
+		u.Rank,
+		u.ContactPhone,
+		u.ContactMail,
+		pass.Type,
+		pass.TestDate,
+		pass.ExpiresAt,
+		pass.CertificateNumber,
+		strconv.FormatBool(u.Checkin),
+		record.CheckedInAt,
+		record.CheckedInBy,
+		record.Entrance,
+		record.CheckedOutAt,
+	}
+}
+
+// exportWriter encodes a stream of guests, the header is written
+// together with the first row or on Close for empty lists.
+type exportWriter interface {
+	Write(User) error
+	Close() error
+	ContentType() string
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV, "":
+		return &csvExport{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return &xlsxExport{out: w}, nil
+	case FormatJSON:
+		return &jsonExport{w: w}, nil
+	default:
+		return nil, errors.Wrapf(ErrInvalidArgument, "unknown export format %q", format)
+	}
+}
+
+type csvExport struct {
+	w      *csv.Writer
+	header bool
+	rows   int
+}
+
+func (e *csvExport) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (e *csvExport) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(exportColumns)
+}
+
+func (e *csvExport) Write(u User) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	if err := e.w.Write(exportRecord(u)); err != nil {
+		return err
+	}
+	if e.rows++; e.rows%100 == 0 {
+		e.w.Flush()
+		return e.w.Error()
+	}
+	return nil
+}
+
+func (e *csvExport) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// xlsxExport streams rows into a single sheet, excelize keeps large sheets
+// in a temporary file until the workbook is written out on Close.
+type xlsxExport struct {
+	out  io.Writer
+	f    *excelize.File
+	w    *excelize.StreamWriter
+	rows int
+}
+
+func (e *xlsxExport) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (e *xlsxExport) open() (err error) {
+	if e.w != nil {
+		return nil
+	}
+	e.f = excelize.NewFile()
+	if err := e.f.SetSheetName(e.f.GetSheetName(0), "Guests"); err != nil {
+		return err
+	}
+	if e.w, err = e.f.NewStreamWriter("Guests"); err != nil {
+		return err
+	}
+	return e.writeRow(exportColumns)
+}
+
+// writeRow writes cells as text, so phones and ids are not turned into numbers.
+func (e *xlsxExport) writeRow(cells []string) error {
+	e.rows++
+	cell, err := excelize.CoordinatesToCellName(1, e.rows)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(cells))
+	for i, c := range cells {
+		row[i] = c
+	}
+	return e.w.SetRow(cell, row)
+}
+
+func (e *xlsxExport) Write(u User) error {
+	if err := e.open(); err != nil {
+		return err
+	}
+	return e.writeRow(exportRecord(u))
+}
+
+func (e *xlsxExport) Close() error {
+	if err := e.open(); err != nil {
+		return err
+	}
+	defer e.f.Close()
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	return e.f.Write(e.out)
+}
+
+type jsonExport struct {
+	w    io.Writer
+	rows int
+}
+
+func (e *jsonExport) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (e *jsonExport) Write(u User) error {
+	sep := ","
+	if e.rows == 0 {
+		sep = "["
+	}
+	e.rows++
+
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonExport) Close() error {
+	end := "]\n"
+	if e.rows == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
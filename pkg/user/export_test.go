@@ -0,0 +1,59 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	guests := []User{
+		{Id: 1, Surname: "Иванов", Name: "Иван", Company: "Рога и копыта", ContactPhone: "+79161234567", Checkin: true,
+			CovidPass:     &CovidPass{Type: "pcr", TestDate: "2021-09-12"},
+			CheckinRecord: &CheckinRecord{CheckedInAt: "2021-09-14T19:42:00+03:00", CheckedInBy: "door1", Entrance: "A"}},
+		{Id: 2, Surname: "Петров", Name: "Пётр", ContactMail: "petrov@example.com"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		var buf bytes.Buffer
+		w, err := newExportWriter(format, &buf)
+		require.NoError(t, err)
+		for _, u := range guests {
+			require.NoError(t, w.Write(u))
+		}
+		require.NoError(t, w.Close())
+		if format == FormatCSV {
+			assert.Contains(t, buf.String(), ",pcr,2021-09-12,,,true,2021-09-14T19:42:00+03:00,door1,A,\n")
+		}
+
+		rows, err := parseGuestList(format, buf.Bytes())
+		require.NoError(t, err, format)
+		require.Len(t, rows, 2, format)
+		assert.Equal(t, "Рога и копыта", rows[0].user.Company, format)
+		assert.Equal(t, "petrov@example.com", rows[1].user.ContactMail, format)
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newExportWriter(FormatJSON, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "[]\n", buf.String())
+
+	buf.Reset()
+	w, _ = newExportWriter(FormatJSON, &buf)
+	require.NoError(t, w.Write(User{Id: 1}))
+	require.NoError(t, w.Write(User{Id: 2}))
+	require.NoError(t, w.Close())
+
+	var out []User
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Len(t, out, 2)
+
+	_, err = newExportWriter("ods", &buf)
+	assert.Equal(t, 400, getHTTPStatusCode(err))
+}
@@ -3,11 +3,14 @@ package user
 import (
 	"context"
 	"errors"
+	"io"
 
+	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/tracing/opentracing"
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	stdopentracing "github.com/opentracing/opentracing-go"
+	"github.com/nakiner/guestcovider/internal/auth"
 	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
 	"google.golang.org/grpc"
 )
@@ -20,6 +23,7 @@ func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger l
 	// global client middlewares
 	options := []grpctransport.ClientOption{
 		grpctransport.ClientBefore(opentracing.ContextToGRPC(tracer, logger)),
+		grpctransport.ClientBefore(auth.ContextToGRPC()),
 	}
 
 	return endpoints{
@@ -27,7 +31,7 @@ func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger l
 		// endpoint.Endpoint) that gets wrapped with various middlewares. If you
 		// made your own client library, you'd do this work there, so your server
 		// could rely on a consistent set of client behavior.
-		UpdateUserEndpoint: grpctransport.NewClient(
+		UpdateUserEndpoint: restoreConflict(grpctransport.NewClient(
 			conn,
 			"guestcoviderpb.UserService",
 			"UpdateUser",
@@ -35,7 +39,7 @@ func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger l
 			decodeGRPCUpdateUserResponse,
 			pb.UpdateUserResponse{},
 			options...,
-		).Endpoint(),
+		).Endpoint()),
 		SearchUserEndpoint: grpctransport.NewClient(
 			conn,
 			"guestcoviderpb.UserService",
@@ -45,6 +49,208 @@ func NewGRPCClient(conn *grpc.ClientConn, tracer stdopentracing.Tracer, logger l
 			pb.SearchUserResponse{},
 			options...,
 		).Endpoint(),
+		CreateUserEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"CreateUser",
+			encodeGRPCCreateUserRequest,
+			decodeGRPCCreateUserResponse,
+			pb.CreateUserResponse{},
+			options...,
+		).Endpoint(),
+		GetUserEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"GetUser",
+			encodeGRPCGetUserRequest,
+			decodeGRPCGetUserResponse,
+			pb.GetUserResponse{},
+			options...,
+		).Endpoint(),
+		PatchUserEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"PatchUser",
+			encodeGRPCPatchUserRequest,
+			decodeGRPCPatchUserResponse,
+			pb.PatchUserResponse{},
+			options...,
+		).Endpoint(),
+		DeleteUserEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"DeleteUser",
+			encodeGRPCDeleteUserRequest,
+			decodeGRPCDeleteUserResponse,
+			pb.DeleteUserResponse{},
+			options...,
+		).Endpoint(),
+		GetUserHistoryEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"GetUserHistory",
+			encodeGRPCGetUserHistoryRequest,
+			decodeGRPCGetUserHistoryResponse,
+			pb.GetUserHistoryResponse{},
+			options...,
+		).Endpoint(),
+		GetUserQREndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"GetUserQR",
+			encodeGRPCGetUserQRRequest,
+			decodeGRPCGetUserQRResponse,
+			pb.GetUserQRResponse{},
+			options...,
+		).Endpoint(),
+		CheckinByTokenEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"CheckinByToken",
+			encodeGRPCCheckinByTokenRequest,
+			decodeGRPCCheckinByTokenResponse,
+			pb.CheckinByTokenResponse{},
+			options...,
+		).Endpoint(),
+		VerifyPassEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"VerifyPass",
+			encodeGRPCVerifyPassRequest,
+			decodeGRPCVerifyPassResponse,
+			pb.VerifyPassResponse{},
+			options...,
+		).Endpoint(),
+		BulkUpdateUsersEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"BulkUpdateUsers",
+			encodeGRPCBulkUpdateUsersRequest,
+			decodeGRPCBulkUpdateUsersResponse,
+			pb.BulkUpdateUsersResponse{},
+			options...,
+		).Endpoint(),
+		AddCompanionEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"AddCompanion",
+			encodeGRPCAddCompanionRequest,
+			decodeGRPCAddCompanionResponse,
+			pb.AddCompanionResponse{},
+			options...,
+		).Endpoint(),
+		PatchCompanionEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"PatchCompanion",
+			encodeGRPCPatchCompanionRequest,
+			decodeGRPCPatchCompanionResponse,
+			pb.PatchCompanionResponse{},
+			options...,
+		).Endpoint(),
+		DeleteCompanionEndpoint: grpctransport.NewClient(
+			conn,
+			"guestcoviderpb.UserService",
+			"DeleteCompanion",
+			encodeGRPCDeleteCompanionRequest,
+			decodeGRPCDeleteCompanionResponse,
+			pb.DeleteCompanionResponse{},
+			options...,
+		).Endpoint(),
+		ImportUsersEndpoint: makeGRPCImportUsersEndpoint(conn),
+		ExportUsersStream:   makeGRPCExportUsersStream(conn),
+		WatchUsersStream:    makeGRPCWatchUsersStream(conn),
+	}
+}
+
+func makeGRPCExportUsersStream(conn *grpc.ClientConn) exportFunc {
+	client := pb.NewUserServiceClient(conn)
+	return func(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+		stream, err := client.ExportUsers(auth.OutgoingContext(ctx), ExportUsersRequestToPB(req))
+		if err != nil {
+			return err
+		}
+		for {
+			u, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := send(*PBToUser(u)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func makeGRPCWatchUsersStream(conn *grpc.ClientConn) watchFunc {
+	client := pb.NewUserServiceClient(conn)
+	return func(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+		stream, err := client.WatchUsers(auth.OutgoingContext(ctx), WatchUsersRequestToPB(req))
+		if err != nil {
+			return err
+		}
+		for {
+			c, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := send(*PBToUserChange(c)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// importChunkSize is the size of file chunks sent over the ImportUsers stream.
+const importChunkSize = 64 << 10
+
+// makeGRPCImportUsersEndpoint sends the file as a client stream,
+// format and dry run go in the first message only.
+func makeGRPCImportUsersEndpoint(conn *grpc.ClientConn) endpoint.Endpoint {
+	client := pb.NewUserServiceClient(conn)
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, err := encodeGRPCImportUsersRequest(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		inReq := req.(*pb.ImportUsersRequest)
+
+		stream, err := client.ImportUsers(auth.OutgoingContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		content := inReq.Content
+		first := true
+		for first || len(content) > 0 {
+			n := len(content)
+			if n > importChunkSize {
+				n = importChunkSize
+			}
+			chunk := &pb.ImportUsersRequest{Content: content[:n]}
+			if first {
+				chunk.Format = inReq.Format
+				chunk.DryRun = inReq.DryRun
+				chunk.EventId = inReq.EventId
+				first = false
+			}
+			if err := stream.Send(chunk); err != nil {
+				return nil, err
+			}
+			content = content[n:]
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			return nil, err
+		}
+		return decodeGRPCImportUsersResponse(ctx, resp)
 	}
 }
 
@@ -57,6 +263,18 @@ func encodeGRPCSearchUserRequest(_ context.Context, request interface{}) (interf
 	return SearchUserRequestToPB(inReq), nil
 }
 
+// restoreConflict turns an ABORTED status back into the ConflictError the
+// server returned.
+func restoreConflict(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		response, err := next(ctx, request)
+		if err != nil {
+			return nil, conflictFromStatus(err)
+		}
+		return response, nil
+	}
+}
+
 func encodeGRPCUpdateUserRequest(_ context.Context, request interface{}) (interface{}, error) {
 	inReq, ok := request.(*UpdateUserRequest)
 	if !ok {
@@ -66,6 +284,123 @@ func encodeGRPCUpdateUserRequest(_ context.Context, request interface{}) (interf
 	return UpdateUserRequestToPB(inReq), nil
 }
 
+func encodeGRPCImportUsersRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*ImportUsersRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCImportUsersRequest wrong request")
+	}
+
+	return ImportUsersRequestToPB(inReq), nil
+}
+
+func encodeGRPCCreateUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*CreateUserRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateUserRequest wrong request")
+	}
+
+	return CreateUserRequestToPB(inReq), nil
+}
+
+func encodeGRPCGetUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*GetUserRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserRequest wrong request")
+	}
+
+	return GetUserRequestToPB(inReq), nil
+}
+
+func encodeGRPCPatchUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*PatchUserRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCPatchUserRequest wrong request")
+	}
+
+	return PatchUserRequestToPB(inReq), nil
+}
+
+func encodeGRPCDeleteUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*DeleteUserRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCDeleteUserRequest wrong request")
+	}
+
+	return DeleteUserRequestToPB(inReq), nil
+}
+
+func encodeGRPCGetUserHistoryRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*GetUserHistoryRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserHistoryRequest wrong request")
+	}
+
+	return GetUserHistoryRequestToPB(inReq), nil
+}
+
+func encodeGRPCGetUserQRRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*GetUserQRRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserQRRequest wrong request")
+	}
+
+	return GetUserQRRequestToPB(inReq), nil
+}
+
+func encodeGRPCCheckinByTokenRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*CheckinByTokenRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCCheckinByTokenRequest wrong request")
+	}
+
+	return CheckinByTokenRequestToPB(inReq), nil
+}
+
+func encodeGRPCVerifyPassRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*VerifyPassRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCVerifyPassRequest wrong request")
+	}
+
+	return VerifyPassRequestToPB(inReq), nil
+}
+
+func encodeGRPCBulkUpdateUsersRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*BulkUpdateUsersRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCBulkUpdateUsersRequest wrong request")
+	}
+
+	return BulkUpdateUsersRequestToPB(inReq), nil
+}
+
+func encodeGRPCAddCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*AddCompanionRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCAddCompanionRequest wrong request")
+	}
+
+	return AddCompanionRequestToPB(inReq), nil
+}
+
+func encodeGRPCPatchCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*PatchCompanionRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCPatchCompanionRequest wrong request")
+	}
+
+	return PatchCompanionRequestToPB(inReq), nil
+}
+
+func encodeGRPCDeleteCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*DeleteCompanionRequest)
+	if !ok {
+		return nil, errors.New("encodeGRPCDeleteCompanionRequest wrong request")
+	}
+
+	return DeleteCompanionRequestToPB(inReq), nil
+}
+
 func decodeGRPCSearchUserResponse(_ context.Context, response interface{}) (interface{}, error) {
 	inResp, ok := response.(*pb.SearchUserResponse)
 	if !ok {
@@ -87,3 +422,146 @@ func decodeGRPCUpdateUserResponse(_ context.Context, response interface{}) (inte
 
 	return *resp, nil
 }
+
+func decodeGRPCImportUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.ImportUsersResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCImportUsersResponse wrong response")
+	}
+
+	resp := PBToImportUsersResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCCreateUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.CreateUserResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateUserResponse wrong response")
+	}
+
+	resp := PBToCreateUserResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCGetUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.GetUserResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserResponse wrong response")
+	}
+
+	resp := PBToGetUserResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCPatchUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.PatchUserResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCPatchUserResponse wrong response")
+	}
+
+	resp := PBToPatchUserResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCDeleteUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.DeleteUserResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCDeleteUserResponse wrong response")
+	}
+
+	resp := PBToDeleteUserResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCGetUserHistoryResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.GetUserHistoryResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserHistoryResponse wrong response")
+	}
+
+	resp := PBToGetUserHistoryResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCGetUserQRResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.GetUserQRResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserQRResponse wrong response")
+	}
+
+	resp := PBToGetUserQRResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCCheckinByTokenResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.CheckinByTokenResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCCheckinByTokenResponse wrong response")
+	}
+
+	resp := PBToCheckinByTokenResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCVerifyPassResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.VerifyPassResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCVerifyPassResponse wrong response")
+	}
+
+	resp := PBToVerifyPassResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCBulkUpdateUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.BulkUpdateUsersResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCBulkUpdateUsersResponse wrong response")
+	}
+
+	resp := PBToBulkUpdateUsersResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCAddCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.AddCompanionResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCAddCompanionResponse wrong response")
+	}
+
+	resp := PBToAddCompanionResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCPatchCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.PatchCompanionResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCPatchCompanionResponse wrong response")
+	}
+
+	resp := PBToPatchCompanionResponse(inResp)
+
+	return *resp, nil
+}
+
+func decodeGRPCDeleteCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*pb.DeleteCompanionResponse)
+	if !ok {
+		return nil, errors.New("decodeGRPCDeleteCompanionResponse wrong response")
+	}
+
+	resp := PBToDeleteCompanionResponse(inResp)
+
+	return *resp, nil
+}
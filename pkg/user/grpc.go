@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"io"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/tracing/opentracing"
@@ -13,323 +14,2138 @@ import (
 	"github.com/nakiner/guestcovider/tools/logging"
 	"github.com/nakiner/guestcovider/tools/tracing"
 	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type grpcServer struct {
-	updateUser grpctransport.Handler
-	searchUser grpctransport.Handler
+	updateUser      grpctransport.Handler
+	searchUser      grpctransport.Handler
+	importUsers     grpctransport.Handler
+	createUser      grpctransport.Handler
+	getUser         grpctransport.Handler
+	patchUser       grpctransport.Handler
+	deleteUser      grpctransport.Handler
+	getUserHistory  grpctransport.Handler
+	getUserQR       grpctransport.Handler
+	checkinByToken  grpctransport.Handler
+	verifyPass      grpctransport.Handler
+	bulkUpdateUsers grpctransport.Handler
+	addCompanion    grpctransport.Handler
+	patchCompanion  grpctransport.Handler
+	deleteCompanion grpctransport.Handler
+
+	// server streams are served by the service directly
+	service   Service
+	before    []grpctransport.ServerRequestFunc
+	finalizer grpctransport.ServerFinalizerFunc
 }
 
 type ContextGRPCKey struct{}
 
-type GRPCInfo struct{}
+type GRPCInfo struct {
+	From string
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC userServer.
+func NewGRPCServer(ctx context.Context, s Service) pb.UserServiceServer {
+	logger := logging.FromContext(ctx)
+	logger = log.With(logger, "grpc handler", "user")
+	tracer := tracing.FromContext(ctx)
+
+	options := []grpctransport.ServerOption{
+		// grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(grpcToContext()),
+		grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "grpc server", logger)),
+		grpctransport.ServerFinalizer(closeGRPCTracer()),
+	}
+
+	return &grpcServer{
+		updateUser: grpctransport.NewServer(
+			makeUpdateUserEndpoint(s),
+			decodeGRPCUpdateUserRequest,
+			encodeGRPCUpdateUserResponse,
+			options...,
+		),
+		searchUser: grpctransport.NewServer(
+			makeSearchUserEndpoint(s),
+			decodeGRPCSearchUserRequest,
+			encodeGRPCSearchUserResponse,
+			options...,
+		),
+		importUsers: grpctransport.NewServer(
+			makeImportUsersEndpoint(s),
+			decodeGRPCImportUsersRequest,
+			encodeGRPCImportUsersResponse,
+			options...,
+		),
+		createUser: grpctransport.NewServer(
+			makeCreateUserEndpoint(s),
+			decodeGRPCCreateUserRequest,
+			encodeGRPCCreateUserResponse,
+			options...,
+		),
+		getUser: grpctransport.NewServer(
+			makeGetUserEndpoint(s),
+			decodeGRPCGetUserRequest,
+			encodeGRPCGetUserResponse,
+			options...,
+		),
+		patchUser: grpctransport.NewServer(
+			makePatchUserEndpoint(s),
+			decodeGRPCPatchUserRequest,
+			encodeGRPCPatchUserResponse,
+			options...,
+		),
+		deleteUser: grpctransport.NewServer(
+			makeDeleteUserEndpoint(s),
+			decodeGRPCDeleteUserRequest,
+			encodeGRPCDeleteUserResponse,
+			options...,
+		),
+		getUserHistory: grpctransport.NewServer(
+			makeGetUserHistoryEndpoint(s),
+			decodeGRPCGetUserHistoryRequest,
+			encodeGRPCGetUserHistoryResponse,
+			options...,
+		),
+		getUserQR: grpctransport.NewServer(
+			makeGetUserQREndpoint(s),
+			decodeGRPCGetUserQRRequest,
+			encodeGRPCGetUserQRResponse,
+			options...,
+		),
+		checkinByToken: grpctransport.NewServer(
+			makeCheckinByTokenEndpoint(s),
+			decodeGRPCCheckinByTokenRequest,
+			encodeGRPCCheckinByTokenResponse,
+			options...,
+		),
+		verifyPass: grpctransport.NewServer(
+			makeVerifyPassEndpoint(s),
+			decodeGRPCVerifyPassRequest,
+			encodeGRPCVerifyPassResponse,
+			options...,
+		),
+		bulkUpdateUsers: grpctransport.NewServer(
+			makeBulkUpdateUsersEndpoint(s),
+			decodeGRPCBulkUpdateUsersRequest,
+			encodeGRPCBulkUpdateUsersResponse,
+			options...,
+		),
+		addCompanion: grpctransport.NewServer(
+			makeAddCompanionEndpoint(s),
+			decodeGRPCAddCompanionRequest,
+			encodeGRPCAddCompanionResponse,
+			options...,
+		),
+		patchCompanion: grpctransport.NewServer(
+			makePatchCompanionEndpoint(s),
+			decodeGRPCPatchCompanionRequest,
+			encodeGRPCPatchCompanionResponse,
+			options...,
+		),
+		deleteCompanion: grpctransport.NewServer(
+			makeDeleteCompanionEndpoint(s),
+			decodeGRPCDeleteCompanionRequest,
+			encodeGRPCDeleteCompanionResponse,
+			options...,
+		),
+		service: s,
+		before: []grpctransport.ServerRequestFunc{
+			grpcToContext(),
+			opentracing.GRPCToContext(tracer, "grpc server", logger),
+		},
+		finalizer: closeGRPCTracer(),
+	}
+}
+
+// streamContext applies the same request funcs go-kit handlers run for unary calls.
+func (s *grpcServer) streamContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	for _, f := range s.before {
+		ctx = f(ctx, md)
+	}
+	return ctx
+}
+
+func JoinGRPC(ctx context.Context, s Service) func(*googlegrpc.Server) {
+	return func(g *googlegrpc.Server) {
+		pb.RegisterUserServiceServer(g, NewGRPCServer(ctx, s))
+	}
+}
+
+func grpcToContext() grpc.ServerRequestFunc {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		var info GRPCInfo
+		if p, ok := peer.FromContext(ctx); ok {
+			info.From = p.Addr.String()
+		}
+		return context.WithValue(ctx, ContextGRPCKey{}, info)
+	}
+}
+
+func closeGRPCTracer() grpc.ServerFinalizerFunc {
+	return func(ctx context.Context, err error) {
+		span := stdopentracing.SpanFromContext(ctx)
+		span.Finish()
+	}
+}
+
+func (s *grpcServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	_, rep, err := s.updateUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.UpdateUserResponse), nil
+}
+
+func (s *grpcServer) SearchUser(ctx context.Context, req *pb.SearchUserRequest) (*pb.SearchUserResponse, error) {
+	_, rep, err := s.searchUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.SearchUserResponse), nil
+}
+
+// ImportUsers collects the file chunks of the stream and imports them as one request.
+// Files larger than maxImportSize are refused like HTTP uploads.
+func (s *grpcServer) ImportUsers(stream pb.UserService_ImportUsersServer) error {
+	var req *pb.ImportUsersRequest
+	size := 0
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		size += len(chunk.Content)
+		if size > maxImportSize {
+			return status.Errorf(codes.ResourceExhausted, "guest list is larger than %d bytes", maxImportSize)
+		}
+		if req == nil {
+			req = chunk
+			continue
+		}
+		req.Content = append(req.Content, chunk.Content...)
+	}
+	if req == nil {
+		req = &pb.ImportUsersRequest{}
+	}
+
+	_, rep, err := s.importUsers.ServeGRPC(stream.Context(), req)
+	if err != nil {
+		return grpcError(err)
+	}
+	return stream.SendAndClose(rep.(*pb.ImportUsersResponse))
+}
+
+func (s *grpcServer) ExportUsers(req *pb.ExportUsersRequest, stream pb.UserService_ExportUsersServer) (err error) {
+	ctx := s.streamContext(stream.Context())
+	defer func() { s.finalizer(ctx, err) }()
+
+	in := PBToExportUsersRequest(req)
+	if err := validate(in); err != nil {
+		return grpcError(err)
+	}
+
+	err = s.service.ExportUsers(ctx, in, func(u User) error {
+		return stream.Send(UserToPB(&u))
+	})
+	return grpcError(err)
+}
+
+func (s *grpcServer) WatchUsers(req *pb.WatchUsersRequest, stream pb.UserService_WatchUsersServer) (err error) {
+	ctx := s.streamContext(stream.Context())
+	defer func() { s.finalizer(ctx, err) }()
+
+	in := PBToWatchUsersRequest(req)
+	if err := validate(in); err != nil {
+		return grpcError(err)
+	}
+
+	err = s.service.WatchUsers(ctx, in, func(c UserChange) error {
+		return stream.Send(UserChangeToPB(&c))
+	})
+	return grpcError(err)
+}
+
+func (s *grpcServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	_, rep, err := s.createUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.CreateUserResponse), nil
+}
+
+func (s *grpcServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	_, rep, err := s.getUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.GetUserResponse), nil
+}
+
+func (s *grpcServer) PatchUser(ctx context.Context, req *pb.PatchUserRequest) (*pb.PatchUserResponse, error) {
+	_, rep, err := s.patchUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.PatchUserResponse), nil
+}
+
+func (s *grpcServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	_, rep, err := s.deleteUser.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.DeleteUserResponse), nil
+}
+
+func (s *grpcServer) GetUserHistory(ctx context.Context, req *pb.GetUserHistoryRequest) (*pb.GetUserHistoryResponse, error) {
+	_, rep, err := s.getUserHistory.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.GetUserHistoryResponse), nil
+}
+
+func (s *grpcServer) GetUserQR(ctx context.Context, req *pb.GetUserQRRequest) (*pb.GetUserQRResponse, error) {
+	_, rep, err := s.getUserQR.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.GetUserQRResponse), nil
+}
+
+func (s *grpcServer) CheckinByToken(ctx context.Context, req *pb.CheckinByTokenRequest) (*pb.CheckinByTokenResponse, error) {
+	_, rep, err := s.checkinByToken.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.CheckinByTokenResponse), nil
+}
+
+func (s *grpcServer) VerifyPass(ctx context.Context, req *pb.VerifyPassRequest) (*pb.VerifyPassResponse, error) {
+	_, rep, err := s.verifyPass.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.VerifyPassResponse), nil
+}
+
+func (s *grpcServer) BulkUpdateUsers(ctx context.Context, req *pb.BulkUpdateUsersRequest) (*pb.BulkUpdateUsersResponse, error) {
+	_, rep, err := s.bulkUpdateUsers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.BulkUpdateUsersResponse), nil
+}
+
+func (s *grpcServer) AddCompanion(ctx context.Context, req *pb.AddCompanionRequest) (*pb.AddCompanionResponse, error) {
+	_, rep, err := s.addCompanion.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.AddCompanionResponse), nil
+}
+
+func (s *grpcServer) PatchCompanion(ctx context.Context, req *pb.PatchCompanionRequest) (*pb.PatchCompanionResponse, error) {
+	_, rep, err := s.patchCompanion.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.PatchCompanionResponse), nil
+}
+
+func (s *grpcServer) DeleteCompanion(ctx context.Context, req *pb.DeleteCompanionRequest) (*pb.DeleteCompanionResponse, error) {
+	_, rep, err := s.deleteCompanion.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return rep.(*pb.DeleteCompanionResponse), nil
+}
+
+func decodeGRPCUpdateUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.UpdateUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCUpdateUserRequest wrong request")
+	}
+
+	req := PBToUpdateUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCSearchUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.SearchUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCSearchUserRequest wrong request")
+	}
+
+	req := PBToSearchUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCImportUsersRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.ImportUsersRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCImportUsersRequest wrong request")
+	}
+
+	req := PBToImportUsersRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCCreateUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.CreateUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCCreateUserRequest wrong request")
+	}
+
+	req := PBToCreateUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCGetUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.GetUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserRequest wrong request")
+	}
+
+	req := PBToGetUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCPatchUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.PatchUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCPatchUserRequest wrong request")
+	}
+
+	req := PBToPatchUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCDeleteUserRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.DeleteUserRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCDeleteUserRequest wrong request")
+	}
+
+	req := PBToDeleteUserRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCGetUserHistoryRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.GetUserHistoryRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserHistoryRequest wrong request")
+	}
+
+	req := PBToGetUserHistoryRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCGetUserQRRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.GetUserQRRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCGetUserQRRequest wrong request")
+	}
+
+	req := PBToGetUserQRRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCCheckinByTokenRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.CheckinByTokenRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCCheckinByTokenRequest wrong request")
+	}
+
+	req := PBToCheckinByTokenRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCVerifyPassRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.VerifyPassRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCVerifyPassRequest wrong request")
+	}
+
+	req := PBToVerifyPassRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCBulkUpdateUsersRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.BulkUpdateUsersRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCBulkUpdateUsersRequest wrong request")
+	}
+
+	req := PBToBulkUpdateUsersRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCAddCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.AddCompanionRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCAddCompanionRequest wrong request")
+	}
+
+	req := PBToAddCompanionRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCPatchCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.PatchCompanionRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCPatchCompanionRequest wrong request")
+	}
+
+	req := PBToPatchCompanionRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func decodeGRPCDeleteCompanionRequest(_ context.Context, request interface{}) (interface{}, error) {
+	inReq, ok := request.(*pb.DeleteCompanionRequest)
+	if !ok {
+		return nil, errors.New("decodeGRPCDeleteCompanionRequest wrong request")
+	}
+
+	req := PBToDeleteCompanionRequest(inReq)
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	return *req, nil
+}
+
+func encodeGRPCUpdateUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*UpdateUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCUpdateUserResponse wrong response")
+	}
+
+	return UpdateUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCSearchUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*SearchUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCSearchUserResponse wrong response")
+	}
+
+	return SearchUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCImportUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*ImportUsersResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCImportUsersResponse wrong response")
+	}
+
+	return ImportUsersResponseToPB(inResp), nil
+}
+
+func encodeGRPCCreateUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*CreateUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCCreateUserResponse wrong response")
+	}
+
+	return CreateUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCGetUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*GetUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserResponse wrong response")
+	}
+
+	return GetUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCPatchUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*PatchUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCPatchUserResponse wrong response")
+	}
+
+	return PatchUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCDeleteUserResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*DeleteUserResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCDeleteUserResponse wrong response")
+	}
+
+	return DeleteUserResponseToPB(inResp), nil
+}
+
+func encodeGRPCGetUserHistoryResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*GetUserHistoryResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserHistoryResponse wrong response")
+	}
+
+	return GetUserHistoryResponseToPB(inResp), nil
+}
+
+func encodeGRPCGetUserQRResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*GetUserQRResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCGetUserQRResponse wrong response")
+	}
+
+	return GetUserQRResponseToPB(inResp), nil
+}
+
+func encodeGRPCCheckinByTokenResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*CheckinByTokenResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCCheckinByTokenResponse wrong response")
+	}
+
+	return CheckinByTokenResponseToPB(inResp), nil
+}
+
+func encodeGRPCVerifyPassResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*VerifyPassResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCVerifyPassResponse wrong response")
+	}
+
+	return VerifyPassResponseToPB(inResp), nil
+}
+
+func encodeGRPCBulkUpdateUsersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*BulkUpdateUsersResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCBulkUpdateUsersResponse wrong response")
+	}
+
+	return BulkUpdateUsersResponseToPB(inResp), nil
+}
+
+func encodeGRPCAddCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*AddCompanionResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCAddCompanionResponse wrong response")
+	}
+
+	return AddCompanionResponseToPB(inResp), nil
+}
+
+func encodeGRPCPatchCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*PatchCompanionResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCPatchCompanionResponse wrong response")
+	}
+
+	return PatchCompanionResponseToPB(inResp), nil
+}
+
+func encodeGRPCDeleteCompanionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	inResp, ok := response.(*DeleteCompanionResponse)
+	if !ok {
+		return nil, errors.New("encodeGRPCDeleteCompanionResponse wrong response")
+	}
+
+	return DeleteCompanionResponseToPB(inResp), nil
+}
+
+func SearchUserRequestToPB(d *SearchUserRequest) *pb.SearchUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.SearchUserRequest{
+		Surname: d.Surname,
+		EventId: d.EventId,
+		Query:   d.Query,
+		Limit:   d.Limit,
+		Cursor:  d.Cursor,
+	}
+
+	return &resp
+}
+
+func PBToSearchUserRequest(d *pb.SearchUserRequest) *SearchUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := SearchUserRequest{
+		Surname: d.Surname,
+		EventId: d.EventId,
+		Query:   d.Query,
+		Limit:   d.Limit,
+		Cursor:  d.Cursor,
+	}
+
+	return &resp
+}
+
+func SearchUserResponseToPB(d *SearchUserResponse) *pb.SearchUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.SearchUserResponse{
+		Status:     StatusToPB(d.Status),
+		Total:      d.Total,
+		NextCursor: d.NextCursor,
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, UserToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToSearchUserResponse(d *pb.SearchUserResponse) *SearchUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := SearchUserResponse{
+		Status:     PBToStatus(d.Status),
+		Total:      d.Total,
+		NextCursor: d.NextCursor,
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, *PBToUser(v))
+	}
+
+	return &resp
+}
+
+func StatusToPB(d *Status) *pb.Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func PBToStatus(d *pb.Status) *Status {
+	if d == nil {
+		return nil
+	}
+
+	resp := Status{
+		Status:  d.Status,
+		Message: d.Message,
+	}
+
+	return &resp
+}
+
+func UpdateDataToPB(d *UpdateData) *pb.UpdateData {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateData{
+		CovidPass: CovidPassToPB(d.CovidPass),
+		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
+	}
+
+	return &resp
+}
+
+func PBToUpdateData(d *pb.UpdateData) *UpdateData {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateData{
+		CovidPass: PBToCovidPass(d.CovidPass),
+		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
+	}
+
+	return &resp
+}
+
+func UpdateUserRequestToPB(d *UpdateUserRequest) *pb.UpdateUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateUserRequest{
+		Id:      d.Id,
+		Data:    UpdateDataToPB(d.Data),
+		EventId: d.EventId,
+		Version: d.Version,
+	}
+
+	return &resp
+}
+
+func PBToUpdateUserRequest(d *pb.UpdateUserRequest) *UpdateUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateUserRequest{
+		Id:      d.Id,
+		Data:    PBToUpdateData(d.Data),
+		EventId: d.EventId,
+		Version: d.Version,
+	}
+
+	return &resp
+}
+
+func UpdateUserResponseToPB(d *UpdateUserResponse) *pb.UpdateUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.UpdateUserResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToUpdateUserResponse(d *pb.UpdateUserResponse) *UpdateUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := UpdateUserResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func UserToPB(d *User) *pb.User {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.User{
+		Id:            d.Id,
+		Status:        d.Status,
+		Company:       d.Company,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		Guest:         d.Guest,
+		CovidPass:     CovidPassToPB(d.CovidPass),
+		Rank:          d.Rank,
+		ContactPhone:  d.ContactPhone,
+		ContactMail:   d.ContactMail,
+		Checkin:       d.Checkin,
+		EventId:       d.EventId,
+		CheckinRecord: CheckinRecordToPB(d.CheckinRecord),
+		PassCheck:     PassCheckToPB(d.PassCheck),
+		Version:       d.Version,
+		MaxCompanions: d.MaxCompanions,
+		InviteToken:   d.InviteToken,
+	}
+
+	for _, v := range d.Companions {
+		resp.Companions = append(resp.Companions, CompanionToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToUser(d *pb.User) *User {
+	if d == nil {
+		return nil
+	}
+
+	resp := User{
+		Id:            d.Id,
+		Status:        d.Status,
+		Company:       d.Company,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		Guest:         d.Guest,
+		CovidPass:     PBToCovidPass(d.CovidPass),
+		Rank:          d.Rank,
+		ContactPhone:  d.ContactPhone,
+		ContactMail:   d.ContactMail,
+		Checkin:       d.Checkin,
+		EventId:       d.EventId,
+		CheckinRecord: PBToCheckinRecord(d.CheckinRecord),
+		PassCheck:     PBToPassCheck(d.PassCheck),
+		Version:       d.Version,
+		MaxCompanions: d.MaxCompanions,
+		InviteToken:   d.InviteToken,
+	}
+
+	for _, v := range d.Companions {
+		resp.Companions = append(resp.Companions, *PBToCompanion(v))
+	}
+
+	return &resp
+}
+
+func CheckinRecordToPB(d *CheckinRecord) *pb.CheckinRecord {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CheckinRecord{
+		CheckedInAt:  d.CheckedInAt,
+		CheckedInBy:  d.CheckedInBy,
+		Entrance:     d.Entrance,
+		CheckedOutAt: d.CheckedOutAt,
+		CheckedOutBy: d.CheckedOutBy,
+	}
+
+	return &resp
+}
+
+func PBToCheckinRecord(d *pb.CheckinRecord) *CheckinRecord {
+	if d == nil {
+		return nil
+	}
+
+	resp := CheckinRecord{
+		CheckedInAt:  d.CheckedInAt,
+		CheckedInBy:  d.CheckedInBy,
+		Entrance:     d.Entrance,
+		CheckedOutAt: d.CheckedOutAt,
+		CheckedOutBy: d.CheckedOutBy,
+	}
+
+	return &resp
+}
+
+func CovidPassToPB(d *CovidPass) *pb.CovidPass {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CovidPass{
+		Type:              passTypeToPB(d.Type),
+		TestDate:          d.TestDate,
+		ExpiresAt:         d.ExpiresAt,
+		CertificateNumber: d.CertificateNumber,
+	}
+
+	return &resp
+}
+
+func PBToCovidPass(d *pb.CovidPass) *CovidPass {
+	if d == nil {
+		return nil
+	}
+
+	resp := CovidPass{
+		Type:              passTypeFromPB(d.Type),
+		TestDate:          d.TestDate,
+		ExpiresAt:         d.ExpiresAt,
+		CertificateNumber: d.CertificateNumber,
+	}
+
+	return &resp
+}
+
+func ImportUsersRequestToPB(d *ImportUsersRequest) *pb.ImportUsersRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ImportUsersRequest{
+		Format:  d.Format,
+		DryRun:  d.DryRun,
+		Content: d.Content,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func PBToImportUsersRequest(d *pb.ImportUsersRequest) *ImportUsersRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := ImportUsersRequest{
+		Format:  d.Format,
+		DryRun:  d.DryRun,
+		Content: d.Content,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func ImportRowReportToPB(d *ImportRowReport) *pb.ImportRowReport {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ImportRowReport{
+		Row:     d.Row,
+		Surname: d.Surname,
+		Name:    d.Name,
+		Errors:  d.Errors,
+	}
+
+	return &resp
+}
+
+func PBToImportRowReport(d *pb.ImportRowReport) *ImportRowReport {
+	if d == nil {
+		return nil
+	}
+
+	resp := ImportRowReport{
+		Row:     d.Row,
+		Surname: d.Surname,
+		Name:    d.Name,
+		Errors:  d.Errors,
+	}
+
+	return &resp
+}
+
+func ImportUsersResponseToPB(d *ImportUsersResponse) *pb.ImportUsersResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ImportUsersResponse{
+		Status:  StatusToPB(d.Status),
+		DryRun:  d.DryRun,
+		Total:   d.Total,
+		Valid:   d.Valid,
+		Created: d.Created,
+		Updated: d.Updated,
+	}
+
+	for _, v := range d.Rows {
+		resp.Rows = append(resp.Rows, ImportRowReportToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToImportUsersResponse(d *pb.ImportUsersResponse) *ImportUsersResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := ImportUsersResponse{
+		Status:  PBToStatus(d.Status),
+		DryRun:  d.DryRun,
+		Total:   d.Total,
+		Valid:   d.Valid,
+		Created: d.Created,
+		Updated: d.Updated,
+	}
+
+	for _, v := range d.Rows {
+		resp.Rows = append(resp.Rows, *PBToImportRowReport(v))
+	}
+
+	return &resp
+}
+
+func ExportUsersRequestToPB(d *ExportUsersRequest) *pb.ExportUsersRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.ExportUsersRequest{
+		Format:  d.Format,
+		Checkin: d.Checkin,
+		Company: d.Company,
+		Status:     d.Status,
+		EventId:    d.EventId,
+		WithTokens: d.WithTokens,
+	}
+
+	return &resp
+}
+
+func PBToExportUsersRequest(d *pb.ExportUsersRequest) *ExportUsersRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := ExportUsersRequest{
+		Format:  d.Format,
+		Checkin: d.Checkin,
+		Company: d.Company,
+		Status:     d.Status,
+		EventId:    d.EventId,
+		WithTokens: d.WithTokens,
+	}
+
+	return &resp
+}
+
+func CreateUserRequestToPB(d *CreateUserRequest) *pb.CreateUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateUserRequest{
+		EventId: d.EventId,
+		Data:    UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToCreateUserRequest(d *pb.CreateUserRequest) *CreateUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateUserRequest{
+		EventId: d.EventId,
+		Data:    PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func CreateUserResponseToPB(d *CreateUserResponse) *pb.CreateUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CreateUserResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToCreateUserResponse(d *pb.CreateUserResponse) *CreateUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := CreateUserResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func GetUserRequestToPB(d *GetUserRequest) *pb.GetUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func PBToGetUserRequest(d *pb.GetUserRequest) *GetUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func GetUserResponseToPB(d *GetUserResponse) *pb.GetUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToGetUserResponse(d *pb.GetUserResponse) *GetUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func DeleteUserRequestToPB(d *DeleteUserRequest) *pb.DeleteUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.DeleteUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func PBToDeleteUserRequest(d *pb.DeleteUserRequest) *DeleteUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := DeleteUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func DeleteUserResponseToPB(d *DeleteUserResponse) *pb.DeleteUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.DeleteUserResponse{
+		Status: StatusToPB(d.Status),
+	}
+
+	return &resp
+}
+
+func PBToDeleteUserResponse(d *pb.DeleteUserResponse) *DeleteUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := DeleteUserResponse{
+		Status: PBToStatus(d.Status),
+	}
+
+	return &resp
+}
+
+func PatchDataToPB(d *PatchData) *pb.PatchData {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.PatchData{
+		Status:        d.Status,
+		Company:       d.Company,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		Guest:         d.Guest,
+		CovidPass:     CovidPassToPB(d.CovidPass),
+		Rank:          d.Rank,
+		ContactPhone:  d.ContactPhone,
+		ContactMail:   d.ContactMail,
+		Checkin:       d.Checkin,
+		Entrance:      d.Entrance,
+		MaxCompanions: d.MaxCompanions,
+	}
+
+	return &resp
+}
+
+func PBToPatchData(d *pb.PatchData) *PatchData {
+	if d == nil {
+		return nil
+	}
+
+	resp := PatchData{
+		Status:        d.Status,
+		Company:       d.Company,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		Guest:         d.Guest,
+		CovidPass:     PBToCovidPass(d.CovidPass),
+		Rank:          d.Rank,
+		ContactPhone:  d.ContactPhone,
+		ContactMail:   d.ContactMail,
+		Checkin:       d.Checkin,
+		Entrance:      d.Entrance,
+		MaxCompanions: d.MaxCompanions,
+	}
+
+	return &resp
+}
+
+func PatchUserRequestToPB(d *PatchUserRequest) *pb.PatchUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.PatchUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Data:    PatchDataToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToPatchUserRequest(d *pb.PatchUserRequest) *PatchUserRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := PatchUserRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Data:    PBToPatchData(d.Data),
+	}
+
+	return &resp
+}
+
+func PatchUserResponseToPB(d *PatchUserResponse) *pb.PatchUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.PatchUserResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToPatchUserResponse(d *pb.PatchUserResponse) *PatchUserResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := PatchUserResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func GetUserHistoryRequestToPB(d *GetUserHistoryRequest) *pb.GetUserHistoryRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserHistoryRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func PBToGetUserHistoryRequest(d *pb.GetUserHistoryRequest) *GetUserHistoryRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserHistoryRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func AuditRecordToPB(d *AuditRecord) *pb.AuditRecord {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.AuditRecord{
+		Id:        d.Id,
+		UserId:    d.UserId,
+		Action:    d.Action,
+		Actor:     d.Actor,
+		Transport: d.Transport,
+		OldValues: d.OldValues,
+		NewValues: d.NewValues,
+		CreatedAt: d.CreatedAt,
+	}
+
+	return &resp
+}
+
+func PBToAuditRecord(d *pb.AuditRecord) *AuditRecord {
+	if d == nil {
+		return nil
+	}
+
+	resp := AuditRecord{
+		Id:        d.Id,
+		UserId:    d.UserId,
+		Action:    d.Action,
+		Actor:     d.Actor,
+		Transport: d.Transport,
+		OldValues: d.OldValues,
+		NewValues: d.NewValues,
+		CreatedAt: d.CreatedAt,
+	}
+
+	return &resp
+}
+
+func GetUserHistoryResponseToPB(d *GetUserHistoryResponse) *pb.GetUserHistoryResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserHistoryResponse{
+		Status: StatusToPB(d.Status),
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, AuditRecordToPB(&v))
+	}
+
+	return &resp
+}
+
+func PBToGetUserHistoryResponse(d *pb.GetUserHistoryResponse) *GetUserHistoryResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserHistoryResponse{
+		Status: PBToStatus(d.Status),
+	}
+
+	for _, v := range d.Data {
+		resp.Data = append(resp.Data, *PBToAuditRecord(v))
+	}
+
+	return &resp
+}
+
+func GetUserQRRequestToPB(d *GetUserQRRequest) *pb.GetUserQRRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserQRRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Format:  d.Format,
+		Scale:   d.Scale,
+	}
+
+	return &resp
+}
+
+func PBToGetUserQRRequest(d *pb.GetUserQRRequest) *GetUserQRRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserQRRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Format:  d.Format,
+		Scale:   d.Scale,
+	}
+
+	return &resp
+}
+
+func GetUserQRResponseToPB(d *GetUserQRResponse) *pb.GetUserQRResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.GetUserQRResponse{
+		Status:      StatusToPB(d.Status),
+		Token:       d.Token,
+		ContentType: d.ContentType,
+		Image:       d.Image,
+	}
+
+	return &resp
+}
+
+func PBToGetUserQRResponse(d *pb.GetUserQRResponse) *GetUserQRResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := GetUserQRResponse{
+		Status:      PBToStatus(d.Status),
+		Token:       d.Token,
+		ContentType: d.ContentType,
+		Image:       d.Image,
+	}
+
+	return &resp
+}
+
+func CheckinByTokenRequestToPB(d *CheckinByTokenRequest) *pb.CheckinByTokenRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CheckinByTokenRequest{
+		Token:    d.Token,
+		EventId:  d.EventId,
+		Entrance: d.Entrance,
+	}
+
+	return &resp
+}
+
+func PBToCheckinByTokenRequest(d *pb.CheckinByTokenRequest) *CheckinByTokenRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := CheckinByTokenRequest{
+		Token:    d.Token,
+		EventId:  d.EventId,
+		Entrance: d.Entrance,
+	}
+
+	return &resp
+}
+
+func CheckinByTokenResponseToPB(d *CheckinByTokenResponse) *pb.CheckinByTokenResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CheckinByTokenResponse{
+		Status:           StatusToPB(d.Status),
+		Data:             UserToPB(d.Data),
+		AlreadyCheckedIn: d.AlreadyCheckedIn,
+		CheckedInAt:      d.CheckedInAt,
+	}
+
+	return &resp
+}
+
+func PBToCheckinByTokenResponse(d *pb.CheckinByTokenResponse) *CheckinByTokenResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := CheckinByTokenResponse{
+		Status:           PBToStatus(d.Status),
+		Data:             PBToUser(d.Data),
+		AlreadyCheckedIn: d.AlreadyCheckedIn,
+		CheckedInAt:      d.CheckedInAt,
+	}
+
+	return &resp
+}
+
+func VerifyPassRequestToPB(d *VerifyPassRequest) *pb.VerifyPassRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.VerifyPassRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Payload: d.Payload,
+	}
+
+	return &resp
+}
+
+func PBToVerifyPassRequest(d *pb.VerifyPassRequest) *VerifyPassRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := VerifyPassRequest{
+		Id:      d.Id,
+		EventId: d.EventId,
+		Payload: d.Payload,
+	}
+
+	return &resp
+}
+
+func VerifyPassResponseToPB(d *VerifyPassResponse) *pb.VerifyPassResponse {
+	if d == nil {
+		return nil
+	}
 
-// NewGRPCServer makes a set of endpoints available as a gRPC userServer.
-func NewGRPCServer(ctx context.Context, s Service) pb.UserServiceServer {
-	logger := logging.FromContext(ctx)
-	logger = log.With(logger, "grpc handler", "user")
-	tracer := tracing.FromContext(ctx)
+	resp := pb.VerifyPassResponse{
+		Status: StatusToPB(d.Status),
+		Check:  PassCheckToPB(d.Check),
+		Data:   UserToPB(d.Data),
+	}
 
-	options := []grpctransport.ServerOption{
-		// grpctransport.ServerErrorLogger(logger),
-		grpctransport.ServerBefore(grpcToContext()),
-		grpctransport.ServerBefore(opentracing.GRPCToContext(tracer, "grpc server", logger)),
-		grpctransport.ServerFinalizer(closeGRPCTracer()),
+	return &resp
+}
+
+func PBToVerifyPassResponse(d *pb.VerifyPassResponse) *VerifyPassResponse {
+	if d == nil {
+		return nil
 	}
 
-	return &grpcServer{
-		updateUser: grpctransport.NewServer(
-			makeUpdateUserEndpoint(s),
-			decodeGRPCUpdateUserRequest,
-			encodeGRPCUpdateUserResponse,
-			options...,
-		),
-		searchUser: grpctransport.NewServer(
-			makeSearchUserEndpoint(s),
-			decodeGRPCSearchUserRequest,
-			encodeGRPCSearchUserResponse,
-			options...,
-		),
+	resp := VerifyPassResponse{
+		Status: PBToStatus(d.Status),
+		Check:  PBToPassCheck(d.Check),
+		Data:   PBToUser(d.Data),
 	}
+
+	return &resp
 }
 
-func JoinGRPC(ctx context.Context, s Service) func(*googlegrpc.Server) {
-	return func(g *googlegrpc.Server) {
-		pb.RegisterUserServiceServer(g, NewGRPCServer(ctx, s))
+func PassCheckToPB(d *PassCheck) *pb.PassCheck {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.PassCheck{
+		Status:        d.Status,
+		Reason:        d.Reason,
+		Source:        d.Source,
+		CertificateId: d.CertificateId,
+		Holder:        d.Holder,
+		BirthDate:     d.BirthDate,
+		ExpiresAt:     d.ExpiresAt,
+		CheckedAt:     d.CheckedAt,
+		CheckedBy:     d.CheckedBy,
 	}
+
+	return &resp
 }
 
-func grpcToContext() grpc.ServerRequestFunc {
-	return func(ctx context.Context, md metadata.MD) context.Context {
-		return context.WithValue(ctx, ContextGRPCKey{}, GRPCInfo{})
+func PBToPassCheck(d *pb.PassCheck) *PassCheck {
+	if d == nil {
+		return nil
+	}
+
+	resp := PassCheck{
+		Status:        d.Status,
+		Reason:        d.Reason,
+		Source:        d.Source,
+		CertificateId: d.CertificateId,
+		Holder:        d.Holder,
+		BirthDate:     d.BirthDate,
+		ExpiresAt:     d.ExpiresAt,
+		CheckedAt:     d.CheckedAt,
+		CheckedBy:     d.CheckedBy,
 	}
+
+	return &resp
 }
 
-func closeGRPCTracer() grpc.ServerFinalizerFunc {
-	return func(ctx context.Context, err error) {
-		span := stdopentracing.SpanFromContext(ctx)
-		span.Finish()
+func WatchUsersRequestToPB(d *WatchUsersRequest) *pb.WatchUsersRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.WatchUsersRequest{
+		EventId:    d.EventId,
+		AfterSeq:   d.AfterSeq,
+		WithTokens: d.WithTokens,
 	}
+
+	return &resp
 }
 
-func (s *grpcServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
-	_, rep, err := s.updateUser.ServeGRPC(ctx, req)
-	if err != nil {
-		return nil, err
+func PBToWatchUsersRequest(d *pb.WatchUsersRequest) *WatchUsersRequest {
+	if d == nil {
+		return nil
 	}
-	return rep.(*pb.UpdateUserResponse), nil
+
+	resp := WatchUsersRequest{
+		EventId:    d.EventId,
+		AfterSeq:   d.AfterSeq,
+		WithTokens: d.WithTokens,
+	}
+
+	return &resp
 }
 
-func (s *grpcServer) SearchUser(ctx context.Context, req *pb.SearchUserRequest) (*pb.SearchUserResponse, error) {
-	_, rep, err := s.searchUser.ServeGRPC(ctx, req)
-	if err != nil {
-		return nil, err
+func UserChangeToPB(d *UserChange) *pb.UserChange {
+	if d == nil {
+		return nil
 	}
-	return rep.(*pb.SearchUserResponse), nil
+
+	resp := pb.UserChange{
+		Seq:       d.Seq,
+		Action:    d.Action,
+		UserId:    d.UserId,
+		EventId:   d.EventId,
+		Data:      UserToPB(d.Data),
+		Actor:     d.Actor,
+		CreatedAt: d.CreatedAt,
+	}
+
+	return &resp
 }
 
-func decodeGRPCUpdateUserRequest(_ context.Context, request interface{}) (interface{}, error) {
-	inReq, ok := request.(*pb.UpdateUserRequest)
-	if !ok {
-		return nil, errors.New("decodeGRPCUpdateUserRequest wrong request")
+func PBToUserChange(d *pb.UserChange) *UserChange {
+	if d == nil {
+		return nil
 	}
 
-	req := PBToUpdateUserRequest(inReq)
-	if err := validate(req); err != nil {
-		return nil, err
+	resp := UserChange{
+		Seq:       d.Seq,
+		Action:    d.Action,
+		UserId:    d.UserId,
+		EventId:   d.EventId,
+		Data:      PBToUser(d.Data),
+		Actor:     d.Actor,
+		CreatedAt: d.CreatedAt,
 	}
-	return *req, nil
+
+	return &resp
 }
 
-func decodeGRPCSearchUserRequest(_ context.Context, request interface{}) (interface{}, error) {
-	inReq, ok := request.(*pb.SearchUserRequest)
-	if !ok {
-		return nil, errors.New("decodeGRPCSearchUserRequest wrong request")
+func BulkFilterToPB(d *BulkFilter) *pb.BulkFilter {
+	if d == nil {
+		return nil
 	}
 
-	req := PBToSearchUserRequest(inReq)
-	if err := validate(req); err != nil {
-		return nil, err
+	resp := pb.BulkFilter{
+		Company: d.Company,
+		Guest:   d.Guest,
 	}
-	return *req, nil
+
+	return &resp
 }
 
-func encodeGRPCUpdateUserResponse(_ context.Context, response interface{}) (interface{}, error) {
-	inResp, ok := response.(*UpdateUserResponse)
-	if !ok {
-		return nil, errors.New("encodeGRPCUpdateUserResponse wrong response")
+func PBToBulkFilter(d *pb.BulkFilter) *BulkFilter {
+	if d == nil {
+		return nil
 	}
 
-	return UpdateUserResponseToPB(inResp), nil
+	resp := BulkFilter{
+		Company: d.Company,
+		Guest:   d.Guest,
+	}
+
+	return &resp
 }
 
-func encodeGRPCSearchUserResponse(_ context.Context, response interface{}) (interface{}, error) {
-	inResp, ok := response.(*SearchUserResponse)
-	if !ok {
-		return nil, errors.New("encodeGRPCSearchUserResponse wrong response")
+func BulkUpdateDataToPB(d *BulkUpdateData) *pb.BulkUpdateData {
+	if d == nil {
+		return nil
 	}
 
-	return SearchUserResponseToPB(inResp), nil
+	resp := pb.BulkUpdateData{
+		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
+		CovidPass: CovidPassToPB(d.CovidPass),
+	}
+
+	return &resp
 }
 
-func SearchUserRequestToPB(d *SearchUserRequest) *pb.SearchUserRequest {
+func PBToBulkUpdateData(d *pb.BulkUpdateData) *BulkUpdateData {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.SearchUserRequest{
-		Surname: d.Surname,
+	resp := BulkUpdateData{
+		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
+		CovidPass: PBToCovidPass(d.CovidPass),
 	}
 
 	return &resp
 }
 
-func PBToSearchUserRequest(d *pb.SearchUserRequest) *SearchUserRequest {
+func BulkUpdateUsersRequestToPB(d *BulkUpdateUsersRequest) *pb.BulkUpdateUsersRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := SearchUserRequest{
-		Surname: d.Surname,
+	resp := pb.BulkUpdateUsersRequest{
+		EventId:      d.EventId,
+		Ids:          d.Ids,
+		Filter:       BulkFilterToPB(d.Filter),
+		Data:         BulkUpdateDataToPB(d.Data),
+		AllOrNothing: d.AllOrNothing,
 	}
 
 	return &resp
 }
 
-func SearchUserResponseToPB(d *SearchUserResponse) *pb.SearchUserResponse {
+func PBToBulkUpdateUsersRequest(d *pb.BulkUpdateUsersRequest) *BulkUpdateUsersRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.SearchUserResponse{
-		Status: StatusToPB(d.Status),
+	resp := BulkUpdateUsersRequest{
+		EventId:      d.EventId,
+		Ids:          d.Ids,
+		Filter:       PBToBulkFilter(d.Filter),
+		Data:         PBToBulkUpdateData(d.Data),
+		AllOrNothing: d.AllOrNothing,
 	}
 
-	for _, v := range d.Data {
-		resp.Data = append(resp.Data, UserToPB(&v))
+	return &resp
+}
+
+func BulkResultToPB(d *BulkResult) *pb.BulkResult {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.BulkResult{
+		Id:    d.Id,
+		Ok:    d.Ok,
+		Error: d.Error,
+		Data:  UserToPB(d.Data),
 	}
 
 	return &resp
 }
 
-func PBToSearchUserResponse(d *pb.SearchUserResponse) *SearchUserResponse {
+func PBToBulkResult(d *pb.BulkResult) *BulkResult {
 	if d == nil {
 		return nil
 	}
 
-	resp := SearchUserResponse{
-		Status: PBToStatus(d.Status),
+	resp := BulkResult{
+		Id:    d.Id,
+		Ok:    d.Ok,
+		Error: d.Error,
+		Data:  PBToUser(d.Data),
 	}
 
-	for _, v := range d.Data {
-		resp.Data = append(resp.Data, *PBToUser(v))
+	return &resp
+}
+
+func BulkUpdateUsersResponseToPB(d *BulkUpdateUsersResponse) *pb.BulkUpdateUsersResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.BulkUpdateUsersResponse{
+		Status:  StatusToPB(d.Status),
+		Updated: d.Updated,
+	}
+
+	for _, v := range d.Results {
+		resp.Results = append(resp.Results, BulkResultToPB(&v))
 	}
 
 	return &resp
 }
 
-func StatusToPB(d *Status) *pb.Status {
+func PBToBulkUpdateUsersResponse(d *pb.BulkUpdateUsersResponse) *BulkUpdateUsersResponse {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.Status{
-		Status:  d.Status,
-		Message: d.Message,
+	resp := BulkUpdateUsersResponse{
+		Status:  PBToStatus(d.Status),
+		Updated: d.Updated,
+	}
+
+	for _, v := range d.Results {
+		resp.Results = append(resp.Results, *PBToBulkResult(v))
 	}
 
 	return &resp
 }
 
-func PBToStatus(d *pb.Status) *Status {
+func CompanionToPB(d *Companion) *pb.Companion {
 	if d == nil {
 		return nil
 	}
 
-	resp := Status{
-		Status:  d.Status,
-		Message: d.Message,
+	resp := pb.Companion{
+		Id:            d.Id,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		CovidPass:     CovidPassToPB(d.CovidPass),
+		Checkin:       d.Checkin,
+		CheckinRecord: CheckinRecordToPB(d.CheckinRecord),
 	}
 
 	return &resp
 }
 
-func UpdateDataToPB(d *UpdateData) *pb.UpdateData {
+func PBToCompanion(d *pb.Companion) *Companion {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.UpdateData{
-		CovidPass: d.CovidPass,
+	resp := Companion{
+		Id:            d.Id,
+		Surname:       d.Surname,
+		Name:          d.Name,
+		CovidPass:     PBToCovidPass(d.CovidPass),
+		Checkin:       d.Checkin,
+		CheckinRecord: PBToCheckinRecord(d.CheckinRecord),
+	}
+
+	return &resp
+}
+
+func CompanionDataToPB(d *CompanionData) *pb.CompanionData {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.CompanionData{
+		Surname:   d.Surname,
+		Name:      d.Name,
+		CovidPass: CovidPassToPB(d.CovidPass),
 		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
 	}
 
 	return &resp
 }
 
-func PBToUpdateData(d *pb.UpdateData) *UpdateData {
+func PBToCompanionData(d *pb.CompanionData) *CompanionData {
 	if d == nil {
 		return nil
 	}
 
-	resp := UpdateData{
-		CovidPass: d.CovidPass,
+	resp := CompanionData{
+		Surname:   d.Surname,
+		Name:      d.Name,
+		CovidPass: PBToCovidPass(d.CovidPass),
 		Checkin:   d.Checkin,
+		Entrance:  d.Entrance,
 	}
 
 	return &resp
 }
 
-func UpdateUserRequestToPB(d *UpdateUserRequest) *pb.UpdateUserRequest {
+func AddCompanionRequestToPB(d *AddCompanionRequest) *pb.AddCompanionRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.UpdateUserRequest{
-		Id:   d.Id,
-		Data: UpdateDataToPB(d.Data),
+	resp := pb.AddCompanionRequest{
+		UserId:  d.UserId,
+		EventId: d.EventId,
+		Data:    CompanionDataToPB(d.Data),
 	}
 
 	return &resp
 }
 
-func PBToUpdateUserRequest(d *pb.UpdateUserRequest) *UpdateUserRequest {
+func PBToAddCompanionRequest(d *pb.AddCompanionRequest) *AddCompanionRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := UpdateUserRequest{
-		Id:   d.Id,
-		Data: PBToUpdateData(d.Data),
+	resp := AddCompanionRequest{
+		UserId:  d.UserId,
+		EventId: d.EventId,
+		Data:    PBToCompanionData(d.Data),
 	}
 
 	return &resp
 }
 
-func UpdateUserResponseToPB(d *UpdateUserResponse) *pb.UpdateUserResponse {
+func AddCompanionResponseToPB(d *AddCompanionResponse) *pb.AddCompanionResponse {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.UpdateUserResponse{
+	resp := pb.AddCompanionResponse{
 		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
 	}
 
 	return &resp
 }
 
-func PBToUpdateUserResponse(d *pb.UpdateUserResponse) *UpdateUserResponse {
+func PBToAddCompanionResponse(d *pb.AddCompanionResponse) *AddCompanionResponse {
 	if d == nil {
 		return nil
 	}
 
-	resp := UpdateUserResponse{
+	resp := AddCompanionResponse{
 		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
 	}
 
 	return &resp
 }
 
-func UserToPB(d *User) *pb.User {
+func PatchCompanionRequestToPB(d *PatchCompanionRequest) *pb.PatchCompanionRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := pb.User{
-		Id:           d.Id,
-		Status:       d.Status,
-		Company:      d.Company,
-		Surname:      d.Surname,
-		Name:         d.Name,
-		Guest:        d.Guest,
-		CovidPass:    d.CovidPass,
-		Rank:         d.Rank,
-		ContactPhone: d.ContactPhone,
-		ContactMail:  d.ContactMail,
-		Checkin:      d.Checkin,
+	resp := pb.PatchCompanionRequest{
+		Id:      d.Id,
+		UserId:  d.UserId,
+		EventId: d.EventId,
+		Data:    CompanionDataToPB(d.Data),
 	}
 
 	return &resp
 }
 
-func PBToUser(d *pb.User) *User {
+func PBToPatchCompanionRequest(d *pb.PatchCompanionRequest) *PatchCompanionRequest {
 	if d == nil {
 		return nil
 	}
 
-	resp := User{
-		Id:           d.Id,
-		Status:       d.Status,
-		Company:      d.Company,
-		Surname:      d.Surname,
-		Name:         d.Name,
-		Guest:        d.Guest,
-		CovidPass:    d.CovidPass,
-		Rank:         d.Rank,
-		ContactPhone: d.ContactPhone,
-		ContactMail:  d.ContactMail,
-		Checkin:      d.Checkin,
+	resp := PatchCompanionRequest{
+		Id:      d.Id,
+		UserId:  d.UserId,
+		EventId: d.EventId,
+		Data:    PBToCompanionData(d.Data),
+	}
+
+	return &resp
+}
+
+func PatchCompanionResponseToPB(d *PatchCompanionResponse) *pb.PatchCompanionResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.PatchCompanionResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToPatchCompanionResponse(d *pb.PatchCompanionResponse) *PatchCompanionResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := PatchCompanionResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
+	}
+
+	return &resp
+}
+
+func DeleteCompanionRequestToPB(d *DeleteCompanionRequest) *pb.DeleteCompanionRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.DeleteCompanionRequest{
+		Id:      d.Id,
+		UserId:  d.UserId,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func PBToDeleteCompanionRequest(d *pb.DeleteCompanionRequest) *DeleteCompanionRequest {
+	if d == nil {
+		return nil
+	}
+
+	resp := DeleteCompanionRequest{
+		Id:      d.Id,
+		UserId:  d.UserId,
+		EventId: d.EventId,
+	}
+
+	return &resp
+}
+
+func DeleteCompanionResponseToPB(d *DeleteCompanionResponse) *pb.DeleteCompanionResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := pb.DeleteCompanionResponse{
+		Status: StatusToPB(d.Status),
+		Data:   UserToPB(d.Data),
+	}
+
+	return &resp
+}
+
+func PBToDeleteCompanionResponse(d *pb.DeleteCompanionResponse) *DeleteCompanionResponse {
+	if d == nil {
+		return nil
+	}
+
+	resp := DeleteCompanionResponse{
+		Status: PBToStatus(d.Status),
+		Data:   PBToUser(d.Data),
 	}
 
 	return &resp
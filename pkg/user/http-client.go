@@ -1,18 +1,24 @@
 package user
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/tracing/opentracing"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/schema"
+	"github.com/nakiner/guestcovider/internal/auth"
 	stdopentracing "github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 )
@@ -32,7 +38,9 @@ func NewHTTPClient(instance string, tracer stdopentracing.Tracer, logger log.Log
 	}
 
 	// global client middlewares
-	var options []httptransport.ClientOption
+	options := []httptransport.ClientOption{
+		httptransport.ClientBefore(auth.ContextToHTTP()),
+	}
 	if tracer != nil {
 		options = append(
 			options,
@@ -55,9 +63,208 @@ func NewHTTPClient(instance string, tracer stdopentracing.Tracer, logger log.Log
 			decodeHTTPSearchUserSearchUserResponse,
 			options...,
 		).Endpoint(),
+		ImportUsersEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user/import"),
+			encodeHTTPImportUsersImportUsersRequest,
+			decodeHTTPImportUsersImportUsersResponse,
+			options...,
+		).Endpoint(),
+		CreateUserEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user"),
+			encodeHTTPCreateUserCreateUserRequest,
+			decodeHTTPCreateUserCreateUserResponse,
+			options...,
+		).Endpoint(),
+		GetUserEndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/user"),
+			encodeHTTPGetUserGetUserRequest,
+			decodeHTTPGetUserGetUserResponse,
+			options...,
+		).Endpoint(),
+		PatchUserEndpoint: httptransport.NewClient(
+			"PATCH",
+			copyURL(u, "/user"),
+			encodeHTTPPatchUserPatchUserRequest,
+			decodeHTTPPatchUserPatchUserResponse,
+			options...,
+		).Endpoint(),
+		DeleteUserEndpoint: httptransport.NewClient(
+			"DELETE",
+			copyURL(u, "/user"),
+			encodeHTTPDeleteUserDeleteUserRequest,
+			decodeHTTPDeleteUserDeleteUserResponse,
+			options...,
+		).Endpoint(),
+		GetUserHistoryEndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/user"),
+			encodeHTTPGetUserHistoryGetUserHistoryRequest,
+			decodeHTTPGetUserHistoryGetUserHistoryResponse,
+			options...,
+		).Endpoint(),
+		GetUserQREndpoint: httptransport.NewClient(
+			"GET",
+			copyURL(u, "/user"),
+			encodeHTTPGetUserQRGetUserQRRequest,
+			decodeHTTPGetUserQRGetUserQRResponse,
+			options...,
+		).Endpoint(),
+		CheckinByTokenEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user/checkin"),
+			encodeHTTPCheckinByTokenCheckinByTokenRequest,
+			decodeHTTPCheckinByTokenCheckinByTokenResponse,
+			options...,
+		).Endpoint(),
+		VerifyPassEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user"),
+			encodeHTTPVerifyPassVerifyPassRequest,
+			decodeHTTPVerifyPassVerifyPassResponse,
+			options...,
+		).Endpoint(),
+		BulkUpdateUsersEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user/bulk"),
+			encodeHTTPBulkUpdateUsersBulkUpdateUsersRequest,
+			decodeHTTPBulkUpdateUsersBulkUpdateUsersResponse,
+			options...,
+		).Endpoint(),
+		AddCompanionEndpoint: httptransport.NewClient(
+			"POST",
+			copyURL(u, "/user"),
+			encodeHTTPAddCompanionAddCompanionRequest,
+			decodeHTTPAddCompanionAddCompanionResponse,
+			options...,
+		).Endpoint(),
+		PatchCompanionEndpoint: httptransport.NewClient(
+			"PATCH",
+			copyURL(u, "/user"),
+			encodeHTTPPatchCompanionPatchCompanionRequest,
+			decodeHTTPPatchCompanionPatchCompanionResponse,
+			options...,
+		).Endpoint(),
+		DeleteCompanionEndpoint: httptransport.NewClient(
+			"DELETE",
+			copyURL(u, "/user"),
+			encodeHTTPDeleteCompanionDeleteCompanionRequest,
+			decodeHTTPDeleteCompanionDeleteCompanionResponse,
+			options...,
+		).Endpoint(),
+		ExportUsersStream: makeHTTPExportUsersStream(copyURL(u, "/user/export")),
+		WatchUsersStream:  makeHTTPWatchUsersStream(copyURL(u, "/user/events")),
 	}, nil
 }
 
+// makeHTTPExportUsersStream requests the JSON export and decodes the array
+// element by element.
+func makeHTTPExportUsersStream(u *url.URL) exportFunc {
+	return func(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+		in := *req
+		in.Format = FormatJSON
+
+		r, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(&in, queryMap); err != nil {
+			return errors.Wrap(err, "encode request")
+		}
+		r.URL.RawQuery = url.Values(queryMap).Encode()
+		auth.ContextToHTTP()(ctx, r)
+
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.New(resp.Status)
+		}
+
+		dec := json.NewDecoder(resp.Body)
+		if _, err := dec.Token(); err != nil {
+			return errors.Wrap(err, "decode response body")
+		}
+		for dec.More() {
+			var u User
+			if err := dec.Decode(&u); err != nil {
+				return errors.Wrap(err, "decode response body")
+			}
+			if err := send(u); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// makeHTTPWatchUsersStream reads the server-sent events of the change feed.
+func makeHTTPWatchUsersStream(u *url.URL) watchFunc {
+	return func(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+		r, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return err
+		}
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(req, queryMap); err != nil {
+			return errors.Wrap(err, "encode request")
+		}
+		r.URL.RawQuery = url.Values(queryMap).Encode()
+		r.Header.Set("Accept", "text/event-stream")
+		auth.ContextToHTTP()(ctx, r)
+
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.New(resp.Status)
+		}
+
+		return readEvents(resp.Body, func(data []byte) error {
+			var c UserChange
+			if err := json.Unmarshal(data, &c); err != nil {
+				return errors.Wrap(err, "decode event")
+			}
+			return send(c)
+		})
+	}
+}
+
+// readEvents calls fn with the data of every server-sent event, other
+// fields and comments are skipped.
+func readEvents(r io.Reader, fn func(data []byte) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+
+	var data []byte
+	for sc.Scan() {
+		line := sc.Bytes()
+		switch {
+		case len(line) == 0 && len(data) > 0:
+			if err := fn(data); err != nil {
+				return err
+			}
+			data = data[:0]
+		case bytes.HasPrefix(line, []byte("data:")):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			value := bytes.TrimPrefix(line[len("data:"):], []byte(" "))
+			data = append(data, value...)
+		}
+	}
+	return errors.Wrap(sc.Err(), "read events")
+}
+
 func copyURL(base *url.URL, path string) *url.URL {
 	next := *base
 	next.Path = path
@@ -86,7 +293,235 @@ func encodeHTTPSearchUserSearchUserRequest(_ context.Context, r *http.Request, r
 	return nil
 }
 
+func encodeHTTPImportUsersImportUsersRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*ImportUsersRequest)
+	if !ok {
+		return errors.New("encodeHTTPImportUsersImportUsersRequest wrong request")
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("format", req.Format); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	if err := mw.WriteField("eventId", strconv.FormatUint(req.EventId, 10)); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	if err := mw.WriteField("dryRun", strconv.FormatBool(req.DryRun)); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	fw, err := mw.CreateFormFile("file", "guests."+req.Format)
+	if err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	if _, err := fw.Write(req.Content); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	if err := mw.Close(); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	r.ContentLength = int64(buf.Len())
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPCreateUserCreateUserRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPGetUserGetUserRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*GetUserRequest)
+	if !ok {
+		return errors.New("encodeHTTPGetUserGetUserRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d", req.Id)
+
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPPatchUserPatchUserRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*PatchUserRequest)
+	if !ok {
+		return errors.New("encodeHTTPPatchUserPatchUserRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d", req.Id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPDeleteUserDeleteUserRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*DeleteUserRequest)
+	if !ok {
+		return errors.New("encodeHTTPDeleteUserDeleteUserRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d", req.Id)
+
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPGetUserHistoryGetUserHistoryRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*GetUserHistoryRequest)
+	if !ok {
+		return errors.New("encodeHTTPGetUserHistoryGetUserHistoryRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/history", req.Id)
+
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPGetUserQRGetUserQRRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*GetUserQRRequest)
+	if !ok {
+		return errors.New("encodeHTTPGetUserQRGetUserQRRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/qr", req.Id)
+
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
+func encodeHTTPCheckinByTokenCheckinByTokenRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPVerifyPassVerifyPassRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*VerifyPassRequest)
+	if !ok {
+		return errors.New("encodeHTTPVerifyPassVerifyPassRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/pass/verify", req.Id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPBulkUpdateUsersBulkUpdateUsersRequest(_ context.Context, r *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPAddCompanionAddCompanionRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*AddCompanionRequest)
+	if !ok {
+		return errors.New("encodeHTTPAddCompanionAddCompanionRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/companions", req.UserId)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPPatchCompanionPatchCompanionRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*PatchCompanionRequest)
+	if !ok {
+		return errors.New("encodeHTTPPatchCompanionPatchCompanionRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/companions/%d", req.UserId, req.Id)
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return errors.Wrap(err, "encode request body")
+	}
+	r.Body = ioutil.NopCloser(&buf)
+
+	return nil
+}
+
+func encodeHTTPDeleteCompanionDeleteCompanionRequest(_ context.Context, r *http.Request, request interface{}) error {
+	req, ok := request.(*DeleteCompanionRequest)
+	if !ok {
+		return errors.New("encodeHTTPDeleteCompanionDeleteCompanionRequest wrong request")
+	}
+	r.URL.Path = fmt.Sprintf("/user/%d/companions/%d", req.UserId, req.Id)
+
+	{
+		queryMap := make(map[string][]string)
+		if err := schema.NewEncoder().Encode(request, queryMap); err == nil {
+			query := url.Values(queryMap)
+			r.URL.RawQuery = query.Encode()
+		}
+	}
+
+	return nil
+}
+
 func decodeHTTPUpdateUserUpdateUserResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode == http.StatusConflict {
+		var body struct {
+			Current *User `json:"current"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, errors.Wrap(err, "decode conflict body")
+		}
+		return nil, &ConflictError{Current: body.Current}
+	}
 	if r.StatusCode != http.StatusOK {
 		return nil, errors.New(r.Status)
 	}
@@ -107,3 +542,151 @@ func decodeHTTPSearchUserSearchUserResponse(_ context.Context, r *http.Response)
 	}
 	return request, nil
 }
+
+func decodeHTTPImportUsersImportUsersResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request ImportUsersResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPCreateUserCreateUserResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request CreateUserResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPGetUserGetUserResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request GetUserResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPPatchUserPatchUserResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request PatchUserResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPDeleteUserDeleteUserResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request DeleteUserResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPGetUserHistoryGetUserHistoryResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request GetUserHistoryResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPGetUserQRGetUserQRResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	image, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read image")
+	}
+	return GetUserQRResponse{
+		Status:      &Status{Status: true, Message: "OK"},
+		Token:       r.Header.Get(inviteTokenHeader),
+		ContentType: r.Header.Get("Content-Type"),
+		Image:       image,
+	}, nil
+}
+
+func decodeHTTPCheckinByTokenCheckinByTokenResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request CheckinByTokenResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPVerifyPassVerifyPassResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request VerifyPassResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPBulkUpdateUsersBulkUpdateUsersResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request BulkUpdateUsersResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPAddCompanionAddCompanionResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request AddCompanionResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPPatchCompanionPatchCompanionResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request PatchCompanionResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
+
+func decodeHTTPDeleteCompanionDeleteCompanionResponse(_ context.Context, r *http.Response) (interface{}, error) {
+	if r.StatusCode != http.StatusOK {
+		return nil, errors.New(r.Status)
+	}
+	var request DeleteCompanionResponse
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+	return request, nil
+}
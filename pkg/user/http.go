@@ -3,7 +3,15 @@ package user
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/tracing/opentracing"
@@ -16,6 +24,12 @@ import (
 	"github.com/nakiner/guestcovider/tools/tracing"
 )
 
+// maxImportSize limits uploaded guest lists.
+const maxImportSize = 32 << 20
+
+// inviteTokenHeader carries the token encoded in a QR image response.
+const inviteTokenHeader = "X-Invite-Token"
+
 func MakeHTTPHandler(ctx context.Context, s Service) http.Handler {
 	logger := logging.FromContext(ctx)
 	logger = log.With(logger, "http handler", "user")
@@ -45,7 +59,284 @@ func MakeHTTPHandler(ctx context.Context, s Service) http.Handler {
 		options...,
 	))
 
-	return accessControl(r)
+	r.Methods("POST").Path("/user/import").Handler(httptransport.NewServer(
+		makeImportUsersEndpoint(s),
+		decodePOSTImportUsersRequest,
+		encodeImportUsersResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/user/export").Handler(&exportUsersHandler{
+		s: s,
+		before: []httptransport.RequestFunc{
+			httpToContext(),
+			opentracing.HTTPToContext(tracer, "http server", logger),
+		},
+		finalizer: closeHTTPTracer(),
+	})
+
+	r.Methods("GET").Path("/user/events").Handler(&watchUsersHandler{
+		s: s,
+		before: []httptransport.RequestFunc{
+			httpToContext(),
+			opentracing.HTTPToContext(tracer, "http server", logger),
+		},
+		finalizer: closeHTTPTracer(),
+	})
+
+	r.Methods("POST").Path("/user").Handler(httptransport.NewServer(
+		makeCreateUserEndpoint(s),
+		decodePOSTCreateUserRequest,
+		encodeCreateUserResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/user/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makeGetUserEndpoint(s),
+		decodeGETGetUserRequest,
+		encodeGetUserResponse,
+		options...,
+	))
+
+	r.Methods("PATCH").Path("/user/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makePatchUserEndpoint(s),
+		decodePATCHPatchUserRequest,
+		encodePatchUserResponse,
+		options...,
+	))
+
+	r.Methods("DELETE").Path("/user/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makeDeleteUserEndpoint(s),
+		decodeDELETEDeleteUserRequest,
+		encodeDeleteUserResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/user/{id:[0-9]+}/history").Handler(httptransport.NewServer(
+		makeGetUserHistoryEndpoint(s),
+		decodeGETGetUserHistoryRequest,
+		encodeGetUserHistoryResponse,
+		options...,
+	))
+
+	r.Methods("GET").Path("/user/{id:[0-9]+}/qr").Handler(httptransport.NewServer(
+		makeGetUserQREndpoint(s),
+		decodeGETGetUserQRRequest,
+		encodeGetUserQRResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/user/checkin").Handler(httptransport.NewServer(
+		makeCheckinByTokenEndpoint(s),
+		decodePOSTCheckinByTokenRequest,
+		encodeCheckinByTokenResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/user/{id:[0-9]+}/pass/verify").Handler(httptransport.NewServer(
+		makeVerifyPassEndpoint(s),
+		decodePOSTVerifyPassRequest,
+		encodeVerifyPassResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/user/bulk").Handler(httptransport.NewServer(
+		makeBulkUpdateUsersEndpoint(s),
+		decodePOSTBulkUpdateUsersRequest,
+		encodeBulkUpdateUsersResponse,
+		options...,
+	))
+
+	r.Methods("POST").Path("/user/{user_id:[0-9]+}/companions").Handler(httptransport.NewServer(
+		makeAddCompanionEndpoint(s),
+		decodePOSTAddCompanionRequest,
+		encodeAddCompanionResponse,
+		options...,
+	))
+
+	r.Methods("PATCH").Path("/user/{user_id:[0-9]+}/companions/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makePatchCompanionEndpoint(s),
+		decodePATCHPatchCompanionRequest,
+		encodePatchCompanionResponse,
+		options...,
+	))
+
+	r.Methods("DELETE").Path("/user/{user_id:[0-9]+}/companions/{id:[0-9]+}").Handler(httptransport.NewServer(
+		makeDeleteCompanionEndpoint(s),
+		decodeDELETEDeleteCompanionRequest,
+		encodeDeleteCompanionResponse,
+		options...,
+	))
+
+	return r
+}
+
+// exportUsersHandler writes guests to the response as they are read from
+// the database, go-kit transport would keep the whole list in memory.
+type exportUsersHandler struct {
+	s         Service
+	before    []httptransport.RequestFunc
+	finalizer httptransport.ServerFinalizerFunc
+}
+
+func (h *exportUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	for _, f := range h.before {
+		ctx = f(ctx, r)
+	}
+	defer h.finalizer(ctx, 0, r)
+
+	request, err := decodeGETExportUsersRequest(ctx, r)
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+	req := request.(ExportUsersRequest)
+
+	out := &headerWriter{ResponseWriter: w}
+	enc, err := newExportWriter(req.Format, out)
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = FormatCSV
+	}
+	out.header = func() {
+		w.Header().Set("Content-Type", enc.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="guests.`+format+`"`)
+	}
+
+	err = h.s.ExportUsers(ctx, &req, enc.Write)
+	if err == nil {
+		err = enc.Close()
+	}
+	if err != nil && !out.written {
+		encodeError(ctx, err, w)
+	}
+}
+
+// headerWriter sets response headers right before the first byte of the body,
+// so errors raised before any row is written still get a proper status.
+type headerWriter struct {
+	http.ResponseWriter
+	header  func()
+	written bool
+}
+
+func (w *headerWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.written = true
+		w.header()
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// eventsHeartbeat is how often an idle event stream gets a comment, so
+// proxies keep it open.
+const eventsHeartbeat = 20 * time.Second
+
+// watchUsersHandler streams guest changes as server-sent events. The id
+// of every event is its sequence number, so a reconnecting EventSource
+// resumes after the last change it saw.
+type watchUsersHandler struct {
+	s         Service
+	before    []httptransport.RequestFunc
+	finalizer httptransport.ServerFinalizerFunc
+}
+
+func (h *watchUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	for _, f := range h.before {
+		ctx = f(ctx, r)
+	}
+	defer h.finalizer(ctx, 0, r)
+
+	request, err := decodeGETWatchUsersRequest(ctx, r)
+	if err != nil {
+		encodeError(ctx, err, w)
+		return
+	}
+	req := request.(WatchUsersRequest)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		encodeError(ctx, errors.New("streaming is not supported"), w)
+		return
+	}
+	stream := &eventStream{w: w, flusher: flusher}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go stream.heartbeat(ctx, eventsHeartbeat)
+
+	err = h.s.WatchUsers(ctx, &req, stream.Send)
+	if err != nil && !stream.Started() {
+		encodeError(ctx, err, w)
+	}
+}
+
+// eventStream writes server-sent events, headers are sent with the first
+// event so errors raised before it still get a proper status.
+type eventStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	started bool
+}
+
+// Send writes the change as a "change" event, or a "ready" one.
+func (s *eventStream) Send(c UserChange) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "encode event")
+	}
+	event := "change"
+	if c.Action == ChangeReady {
+		event = ChangeReady
+	}
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", c.Seq, event, data))
+}
+
+func (s *eventStream) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+func (s *eventStream) heartbeat(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if s.Started() {
+				s.write(": ping\n\n")
+			}
+		}
+	}
+}
+
+func (s *eventStream) write(msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.Header().Set("X-Accel-Buffering", "no")
+		s.w.WriteHeader(http.StatusOK)
+		msg = "retry: 3000\n\n" + msg
+	}
+	if _, err := io.WriteString(s.w, msg); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
 }
 
 func httpToContext() httptransport.RequestFunc {
@@ -98,6 +389,365 @@ func decodePUTUpdateUserRequest(_ context.Context, r *http.Request) (interface{}
 	return request, nil
 }
 
+func decodeGETExportUsersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request ExportUsersRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+// decodeGETWatchUsersRequest takes the Last-Event-ID header of a
+// reconnecting EventSource over the afterSeq parameter.
+func decodeGETWatchUsersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request WatchUsersRequest
+
+	{
+		decoder := schema.NewDecoder()
+		decoder.IgnoreUnknownKeys(true)
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		seq, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidArgument, "bad Last-Event-ID %q", id)
+		}
+		request.AfterSeq = seq
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+// decodePOSTImportUsersRequest reads the guest list from the "file" part of
+// a multipart form. Format is taken from the "format" value or the file name.
+func decodePOSTImportUsersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request ImportUsersRequest
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+
+	{
+		decoder := schema.NewDecoder()
+		decoder.IgnoreUnknownKeys(true)
+		err := decoder.Decode(&request, r.Form)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+	defer file.Close()
+
+	if request.Format == "" {
+		request.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+
+	request.Content, err = ioutil.ReadAll(io.LimitReader(file, maxImportSize))
+	if err != nil {
+		return nil, errors.Wrap(ErrBadRequest, err.Error())
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTCreateUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request CreateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETGetUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request GetUserRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePATCHPatchUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request PatchUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeDELETEDeleteUserRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request DeleteUserRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETGetUserHistoryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request GetUserHistoryRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeGETGetUserQRRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request GetUserQRRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTCheckinByTokenRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request CheckinByTokenRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTVerifyPassRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request VerifyPassRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTBulkUpdateUsersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request BulkUpdateUsersRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePOSTAddCompanionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request AddCompanionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		userID, err := strconv.ParseUint(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.UserId = userID
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodePATCHPatchCompanionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request PatchCompanionRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		return nil, errors.Wrap(err, "decode request body")
+	}
+
+	{
+		userID, err := strconv.ParseUint(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.UserId = userID
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
+func decodeDELETEDeleteCompanionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request DeleteCompanionRequest
+
+	{
+		decoder := schema.NewDecoder()
+		err := decoder.Decode(&request, r.URL.Query())
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+	}
+	{
+		userID, err := strconv.ParseUint(mux.Vars(r)["user_id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.UserId = userID
+	}
+	{
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidArgument, err.Error())
+		}
+		request.Id = id
+	}
+	{
+		if err := validate(request); err != nil {
+			return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+		}
+	}
+	return request, nil
+}
+
 func encodeSearchUserResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	if e, ok := response.(errorer); ok && e.error() != nil {
 		encodeError(ctx, e.error(), w)
@@ -116,6 +766,129 @@ func encodeUpdateUserResponse(ctx context.Context, w http.ResponseWriter, respon
 	return json.NewEncoder(w).Encode(response)
 }
 
+func encodeImportUsersResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeCreateUserResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeGetUserResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodePatchUserResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeDeleteUserResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeGetUserHistoryResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+// encodeGetUserQRResponse writes the image itself so that it can be used
+// as an img source, the token is passed in a header.
+func encodeGetUserQRResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	resp := response.(*GetUserQRResponse)
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set(inviteTokenHeader, resp.Token)
+	_, err := w.Write(resp.Image)
+	return err
+}
+
+func encodeCheckinByTokenResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeVerifyPassResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeBulkUpdateUsersResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeAddCompanionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodePatchCompanionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeDeleteCompanionResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		encodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
+
 type errorer interface {
 	error() error
 }
@@ -125,23 +898,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	w.Header().Set("X-Esp-Error", err.Error())
 	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
 
-	w.WriteHeader(getHTTPStatusCode(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
+	body := map[string]interface{}{
 		"error": err.Error(),
-	})
-}
-
-// accessControl is CORS middleware.
-func accessControl(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE, UPDATE, PATCH")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
-
-		if r.Method == "OPTIONS" {
-			return
-		}
+	}
+	var conflict *ConflictError
+	if errors.As(err, &conflict) && conflict.Current != nil {
+		body["current"] = conflict.Current
+	}
 
-		h.ServeHTTP(w, r)
-	})
+	w.WriteHeader(getHTTPStatusCode(err))
+	json.NewEncoder(w).Encode(body)
 }
@@ -0,0 +1,307 @@
+package user
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"unicode"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// importColumns maps normalized header titles to model fields.
+// Headers are compared lower cased with spaces, dashes and underscores removed.
+var importColumns = map[string]string{
+	"status":       "Status",
+	"статус":       "Status",
+	"company":      "Company",
+	"компания":     "Company",
+	"организация":  "Company",
+	"surname":      "Surname",
+	"lastname":     "Surname",
+	"фамилия":      "Surname",
+	"name":         "Name",
+	"firstname":    "Name",
+	"имя":          "Name",
+	"guest":        "Guest",
+	"гость":        "Guest",
+	"rank":         "Rank",
+	"position":     "Rank",
+	"должность":    "Rank",
+	"phone":        "ContactPhone",
+	"contactphone": "ContactPhone",
+	"телефон":      "ContactPhone",
+	"mail":         "ContactMail",
+	"email":        "ContactMail",
+	"contactmail":  "ContactMail",
+	"почта":        "ContactMail",
+}
+
+type importRow struct {
+	line   uint32
+	user   userRepository.User
+	errors []string
+}
+
+// parseGuestList reads rows of the uploaded file and maps them onto users.
+func parseGuestList(format string, content []byte) ([]*importRow, error) {
+	var (
+		records [][]string
+		err     error
+	)
+
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		records, err = readCSV(content)
+	case FormatXLSX:
+		records, err = readXLSX(content)
+	default:
+		return nil, errors.Wrapf(ErrInvalidArgument, "unknown file format %q", format)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrBadRequest, err.Error())
+	}
+
+	header := -1
+	var columns map[int]string
+	for i, rec := range records {
+		columns = mapColumns(rec)
+		for _, field := range columns {
+			if field == "Surname" {
+				header = i
+			}
+		}
+		if header >= 0 {
+			break
+		}
+	}
+	if header < 0 {
+		return nil, errors.Wrap(ErrBadRequest, "no header row with a surname column")
+	}
+
+	var rows []*importRow
+	for i := header + 1; i < len(records); i++ {
+		rec := records[i]
+		if isEmptyRecord(rec) {
+			continue
+		}
+
+		row := &importRow{line: uint32(i + 1)}
+		for idx, field := range columns {
+			if idx >= len(rec) {
+				continue
+			}
+			value := strings.TrimSpace(rec[idx])
+			switch field {
+			case "Status":
+				row.user.Status = value
+			case "Company":
+				row.user.Company = value
+			case "Surname":
+				row.user.Surname = value
+			case "Name":
+				row.user.Name = value
+			case "Guest":
+				row.user.Guest = value
+			case "Rank":
+				row.user.Rank = value
+			case "ContactPhone":
+				row.user.ContactPhone = value
+			case "ContactMail":
+				row.user.ContactMail = value
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// validateGuestList fills per row errors: missing surname, malformed contacts
+// and duplicates within the file.
+func validateGuestList(rows []*importRow) {
+	seenNames := make(map[string]uint32)
+	seenMails := make(map[string]uint32)
+
+	for _, row := range rows {
+		u := &row.user
+
+		row.errors = append(row.errors, validateGuest(u)...)
+
+		if u.Surname != "" {
+			key := strings.ToLower(u.Surname + "\x00" + u.Name + "\x00" + u.Company)
+			if line, ok := seenNames[key]; ok {
+				row.errors = append(row.errors, fmt.Sprintf("duplicate of row %d", line))
+			} else {
+				seenNames[key] = row.line
+			}
+		}
+
+		if u.ContactMail != "" {
+			key := strings.ToLower(u.ContactMail)
+			if line, ok := seenMails[key]; ok {
+				row.errors = append(row.errors, fmt.Sprintf("email duplicates row %d", line))
+			} else {
+				seenMails[key] = row.line
+			}
+		}
+	}
+}
+
+// guestColumns are the columns validateColumns checks.
+var guestColumns = []string{"surname", "contact_mail", "contact_phone"}
+
+// validateGuest checks a single guest and normalizes its phone number.
+func validateGuest(u *userRepository.User) []string {
+	return validateColumns(u, guestColumns)
+}
+
+// validateColumns checks only the given columns of a guest, so a patch is
+// not refused for fields it leaves as they are.
+func validateColumns(u *userRepository.User, columns []string) []string {
+	var errs []string
+
+	for _, column := range columns {
+		switch column {
+		case "surname":
+			if u.Surname == "" {
+				errs = append(errs, "missing surname")
+			}
+		case "contact_mail":
+			if u.ContactMail != "" && !validEmail(u.ContactMail) {
+				errs = append(errs, fmt.Sprintf("malformed email %q", u.ContactMail))
+			}
+		case "contact_phone":
+			if u.ContactPhone == "" {
+				continue
+			}
+			phone, ok := normalizePhone(u.ContactPhone)
+			if !ok {
+				errs = append(errs, fmt.Sprintf("malformed phone %q", u.ContactPhone))
+			} else {
+				u.ContactPhone = phone
+			}
+		}
+	}
+
+	return errs
+}
+
+// validEmail accepts bare addresses with a dotted domain.
+func validEmail(in string) bool {
+	addr, err := mail.ParseAddress(in)
+	if err != nil || addr.Address != in {
+		return false
+	}
+	domain := in[strings.LastIndex(in, "@")+1:]
+	return strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+// normalizePhone strips formatting and checks that 10 to 15 digits remain.
+func normalizePhone(in string) (string, bool) {
+	var b strings.Builder
+	for i, r := range in {
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", false
+		}
+	}
+
+	phone := b.String()
+	digits := len(strings.TrimPrefix(phone, "+"))
+	if digits < 10 || digits > 15 {
+		return "", false
+	}
+	return phone, true
+}
+
+func readCSV(content []byte) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(content))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.Comma = detectDelimiter(content)
+
+	var records [][]string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// readXLSX returns the cells of the first sheet as text. Unpacked parts are
+// capped, so a small upload cannot inflate into gigabytes.
+func readXLSX(content []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content), excelize.Options{UnzipSizeLimit: 8 * maxImportSize})
+	if err != nil {
+		return nil, errors.Wrap(err, "open xlsx")
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+	return f.GetRows(sheets[0])
+}
+
+// detectDelimiter picks ';' for spreadsheets exported with a russian locale.
+func detectDelimiter(content []byte) rune {
+	line := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		line = content[:i]
+	}
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func mapColumns(rec []string) map[int]string {
+	out := make(map[int]string)
+	for i, title := range rec {
+		key := strings.Map(func(r rune) rune {
+			if r == ' ' || r == '_' || r == '-' || r == '.' {
+				return -1
+			}
+			return unicode.ToLower(r)
+		}, strings.TrimSpace(title))
+
+		if field, ok := importColumns[key]; ok {
+			out[i] = field
+		}
+	}
+	return out
+}
+
+func isEmptyRecord(rec []string) bool {
+	for _, c := range rec {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package user
+
+import (
+	"io"
+	"testing"
+
+	pb "github.com/nakiner/guestcovider/internal/guestcoviderpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseGuestListCSV(t *testing.T) {
+	content := "\xef\xbb\xbfСписок гостей;;;\n" +
+		"Фамилия;Имя;Компания;Телефон;E-mail\n" +
+		"Иванов;Иван;Рога и копыта;+7 (916) 123-45-67;ivanov@example.com\n" +
+		";Пётр;;;\n" +
+		"иванов;иван;рога и копыта;12;ivanov@example\n" +
+		";;;;\n"
+
+	rows, err := parseGuestList("csv", []byte(content))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	validateGuestList(rows)
+
+	assert.Equal(t, uint32(3), rows[0].line)
+	assert.Equal(t, "Иванов", rows[0].user.Surname)
+	assert.Equal(t, "Рога и копыта", rows[0].user.Company)
+	assert.Equal(t, "+79161234567", rows[0].user.ContactPhone)
+	assert.Empty(t, rows[0].errors)
+
+	assert.Equal(t, []string{"missing surname"}, rows[1].errors)
+
+	assert.Equal(t, []string{
+		`malformed email "ivanov@example"`,
+		`malformed phone "12"`,
+		"duplicate of row 3",
+	}, rows[2].errors)
+}
+
+func TestParseGuestListErrors(t *testing.T) {
+	_, err := parseGuestList("ods", []byte("surname\n"))
+	assert.Equal(t, 400, getHTTPStatusCode(err))
+
+	_, err = parseGuestList("csv", []byte("name,company\nИван,ООО\n"))
+	assert.Equal(t, 400, getHTTPStatusCode(err))
+}
+
+func TestNormalizePhone(t *testing.T) {
+	testCases := []struct {
+		in  string
+		out string
+		ok  bool
+	}{
+		{"8 916 123 45 67", "89161234567", true},
+		{"+7(916)123-45-67", "+79161234567", true},
+		{"916-12", "", false},
+		{"7916123456x", "", false},
+	}
+	for _, c := range testCases {
+		out, ok := normalizePhone(c.in)
+		assert.Equal(t, c.ok, ok, c.in)
+		assert.Equal(t, c.out, out, c.in)
+	}
+}
+
+// importStream feeds chunks of fixed size to ImportUsers.
+type importStream struct {
+	grpc.ServerStream
+	chunk  []byte
+	chunks int
+}
+
+func (s *importStream) Recv() (*pb.ImportUsersRequest, error) {
+	if s.chunks == 0 {
+		return nil, io.EOF
+	}
+	s.chunks--
+	return &pb.ImportUsersRequest{EventId: 1, Format: "csv", Content: s.chunk}, nil
+}
+
+func (s *importStream) SendAndClose(*pb.ImportUsersResponse) error {
+	return nil
+}
+
+func TestImportUsersStreamLimit(t *testing.T) {
+	stream := &importStream{chunk: make([]byte, 1<<20), chunks: maxImportSize>>20 + 1}
+	err := (&grpcServer{}).ImportUsers(stream)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 0, stream.chunks)
+}
@@ -10,4 +10,47 @@ import (
 type Service interface {
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	SearchUser(context.Context, *SearchUserRequest) (*SearchUserResponse, error)
+
+	// ImportUsers parses a CSV or XLSX guest list and upserts valid rows.
+	// With DryRun set only the validation report is returned.
+	ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersResponse, error)
+
+	// ExportUsers streams guests matching the filters to send, stopping on its first error.
+	ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error
+
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+
+	// PatchUser changes only the fields set in the request.
+	PatchUser(context.Context, *PatchUserRequest) (*PatchUserResponse, error)
+
+	// DeleteUser hides the guest from every listing, the row itself is kept.
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+
+	// GetUserHistory returns the audit trail of a guest, oldest change first.
+	GetUserHistory(context.Context, *GetUserHistoryRequest) (*GetUserHistoryResponse, error)
+
+	GetUserQR(context.Context, *GetUserQRRequest) (*GetUserQRResponse, error)
+
+	CheckinByToken(context.Context, *CheckinByTokenRequest) (*CheckinByTokenResponse, error)
+
+	VerifyPass(context.Context, *VerifyPassRequest) (*VerifyPassResponse, error)
+
+	// WatchUsers sends changes of the event after AfterSeq, then a ready
+	// change, then every change as it is committed until ctx is done or
+	// send fails.
+	WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error
+
+	// BulkUpdateUsers checks in or changes the pass of guests picked by ids
+	// or a filter in one transaction and reports the outcome per guest.
+	BulkUpdateUsers(context.Context, *BulkUpdateUsersRequest) (*BulkUpdateUsersResponse, error)
+
+	// AddCompanion adds a person coming with the guest, no more than the
+	// invitation allows. Companion methods return the guest with the party.
+	AddCompanion(context.Context, *AddCompanionRequest) (*AddCompanionResponse, error)
+
+	// PatchCompanion changes only the fields set in the request.
+	PatchCompanion(context.Context, *PatchCompanionRequest) (*PatchCompanionResponse, error)
+
+	DeleteCompanion(context.Context, *DeleteCompanionRequest) (*DeleteCompanionResponse, error)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
+	"github.com/nakiner/guestcovider/internal/auth"
 	"github.com/nakiner/guestcovider/tools/logging"
 )
 
@@ -87,6 +88,9 @@ func (s *loggingService) SearchUser(ctx context.Context, req *SearchUserRequest)
 
 func getInfoFromContext(ctx context.Context) []interface{} {
 	m := make([]interface{}, 0)
+	if op, ok := auth.OperatorFromContext(ctx); ok {
+		m = append(m, "operator", op.Login)
+	}
 	{
 		val := ctx.Value(ContextGRPCKey{})
 		if _, ok := val.(GRPCInfo); ok {
@@ -108,3 +112,373 @@ func getInfoFromContext(ctx context.Context) []interface{} {
 
 	return m
 }
+
+func (s *loggingService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "ImportUsers",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.ImportUsers(ctx, req)
+}
+
+func (s *loggingService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) (err error) {
+	var rows int
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "ExportUsers",
+			"rows", rows,
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, nil)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.ExportUsers(ctx, req, func(u User) error {
+		rows++
+		return send(u)
+	})
+}
+
+func (s *loggingService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) (err error) {
+	var changes int
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "WatchUsers",
+			"changes", changes,
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, nil)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.WatchUsers(ctx, req, func(c UserChange) error {
+		changes++
+		return send(c)
+	})
+}
+
+func (s *loggingService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "CreateUser",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.CreateUser(ctx, req)
+}
+
+func (s *loggingService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "GetUser",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.GetUser(ctx, req)
+}
+
+func (s *loggingService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "PatchUser",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.PatchUser(ctx, req)
+}
+
+func (s *loggingService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "DeleteUser",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.DeleteUser(ctx, req)
+}
+
+func (s *loggingService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "GetUserHistory",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.GetUserHistory(ctx, req)
+}
+
+func (s *loggingService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "GetUserQR",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.GetUserQR(ctx, req)
+}
+
+func (s *loggingService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "CheckinByToken",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.CheckinByToken(ctx, req)
+}
+
+func (s *loggingService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "VerifyPass",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.VerifyPass(ctx, req)
+}
+
+func (s *loggingService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "BulkUpdateUsers",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.BulkUpdateUsers(ctx, req)
+}
+
+func (s *loggingService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "AddCompanion",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.AddCompanion(ctx, req)
+}
+
+func (s *loggingService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "PatchCompanion",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.PatchCompanion(ctx, req)
+}
+
+func (s *loggingService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		m := getInfoFromContext(ctx)
+		m = append(m,
+			"code", getHTTPStatusCode(err),
+			"method", "DeleteCompanion",
+			"took", time.Since(begin),
+		)
+
+		m = append(m, s.getLog(req, resp)...)
+
+		if getHTTPStatusCode(err) == 404 {
+			m = append(m, "msg", err)
+			level.Warn(s.logger).Log(m...)
+		} else if err != nil {
+			m = append(m, "err", err)
+			level.Error(s.logger).Log(m...)
+		} else {
+			level.Info(s.logger).Log(m...)
+		}
+	}(time.Now())
+	return s.Service.DeleteCompanion(ctx, req)
+}
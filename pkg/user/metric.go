@@ -40,3 +40,153 @@ func (s *metricService) SearchUser(ctx context.Context, req *SearchUserRequest)
 	}(time.Now())
 	return s.Service.SearchUser(ctx, req)
 }
+
+func (s *metricService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "ImportUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "ImportUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.ImportUsers(ctx, req)
+}
+
+func (s *metricService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) (err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "ExportUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "ExportUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.ExportUsers(ctx, req, send)
+}
+
+func (s *metricService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) (err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "WatchUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "WatchUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.WatchUsers(ctx, req, send)
+}
+
+func (s *metricService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "CreateUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "CreateUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.CreateUser(ctx, req)
+}
+
+func (s *metricService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "GetUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "GetUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.GetUser(ctx, req)
+}
+
+func (s *metricService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "PatchUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "PatchUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.PatchUser(ctx, req)
+}
+
+func (s *metricService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "DeleteUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "DeleteUser", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.DeleteUser(ctx, req)
+}
+
+func (s *metricService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "GetUserHistory", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "GetUserHistory", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.GetUserHistory(ctx, req)
+}
+
+func (s *metricService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "GetUserQR", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "GetUserQR", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.GetUserQR(ctx, req)
+}
+
+func (s *metricService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "CheckinByToken", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "CheckinByToken", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.CheckinByToken(ctx, req)
+}
+
+func (s *metricService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "VerifyPass", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "VerifyPass", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.VerifyPass(ctx, req)
+}
+
+func (s *metricService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "BulkUpdateUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "BulkUpdateUsers", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.BulkUpdateUsers(ctx, req)
+}
+
+func (s *metricService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "AddCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "AddCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.AddCompanion(ctx, req)
+}
+
+func (s *metricService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "PatchCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "PatchCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.PatchCompanion(ctx, req)
+}
+
+func (s *metricService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	defer func(begin time.Time) {
+		go func() {
+			s.requestCount.With("service", "user", "handler", "DeleteCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Add(1)
+			s.requestLatency.With("service", "user", "handler", "DeleteCompanion", "code", strconv.Itoa(getHTTPStatusCode(err))).Observe(time.Since(begin).Seconds())
+		}()
+	}(time.Now())
+	return s.Service.DeleteCompanion(ctx, req)
+}
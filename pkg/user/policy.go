@@ -0,0 +1,222 @@
+package user
+
+import (
+	"context"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/operatorRepository"
+	"github.com/pkg/errors"
+)
+
+// methodRoles is the lowest role allowed to call a method: door staff only
+// search and check in, coordinators edit guest data and lists, admins may do everything.
+var methodRoles = map[string]string{
+	"SearchUser":     operatorRepository.RoleDoor,
+	"UpdateUser":     operatorRepository.RoleDoor,
+	"GetUser":        operatorRepository.RoleCoordinator,
+	"CreateUser":     operatorRepository.RoleCoordinator,
+	"PatchUser":      operatorRepository.RoleCoordinator,
+	"DeleteUser":     operatorRepository.RoleCoordinator,
+	"GetUserHistory": operatorRepository.RoleCoordinator,
+	"GetUserQR":      operatorRepository.RoleCoordinator,
+	"CheckinByToken": operatorRepository.RoleDoor,
+	"VerifyPass":     operatorRepository.RoleDoor,
+	"ImportUsers":    operatorRepository.RoleCoordinator,
+	"ExportUsers":    operatorRepository.RoleCoordinator,
+	"WatchUsers":     operatorRepository.RoleDoor,
+	// door staff check groups in, the same changes UpdateUser allows them
+	"BulkUpdateUsers": operatorRepository.RoleDoor,
+	// companions named at the door are added within the party size
+	"AddCompanion": operatorRepository.RoleDoor,
+	// door staff only check companions in, see allowPatchCompanion
+	"PatchCompanion":     operatorRepository.RoleDoor,
+	"PatchCompanionData": operatorRepository.RoleCoordinator,
+	"DeleteCompanion":    operatorRepository.RoleCoordinator,
+}
+
+// syncMethods are the calls an edge sync account sends, allowed to it
+// whatever its role.
+var syncMethods = map[string]bool{
+	"ExportUsers":    true,
+	"WatchUsers":     true,
+	"GetUser":        true,
+	"UpdateUser":     true,
+	"CheckinByToken": true,
+}
+
+// NewPolicyService returns a Service that checks the role of the calling
+// operator before every method. Methods missing from methodRoles are admin only.
+func NewPolicyService(s Service) Service {
+	return &policyService{s}
+}
+
+type policyService struct {
+	Service
+}
+
+func allow(ctx context.Context, method string) error {
+	op, ok := auth.OperatorFromContext(ctx)
+	if !ok {
+		return errors.Wrap(ErrPermissionDenied, "no operator")
+	}
+	if op.Sync && syncMethods[method] {
+		return nil
+	}
+
+	need, ok := methodRoles[method]
+	if !ok {
+		need = operatorRepository.RoleAdmin
+	}
+	if !op.HasRole(need) {
+		return errors.Wrapf(ErrPermissionDenied, "%s requires the %s role", method, need)
+	}
+	return nil
+}
+
+// allowTokens lets only edge sync accounts read invitation tokens in bulk.
+func allowTokens(ctx context.Context, withTokens bool) error {
+	if !withTokens {
+		return nil
+	}
+	if op, ok := auth.OperatorFromContext(ctx); !ok || !op.Sync {
+		return errors.Wrap(ErrPermissionDenied, "invitation tokens are only sent to edge sync accounts")
+	}
+	return nil
+}
+
+// allowPatchCompanion lets door staff change only the check-in and entrance
+// of a companion, other fields are guest data edited by coordinators.
+func allowPatchCompanion(ctx context.Context, data *CompanionData) error {
+	if err := allow(ctx, "PatchCompanion"); err != nil {
+		return err
+	}
+	if data != nil && (data.Surname != nil || data.Name != nil || data.CovidPass != nil) {
+		return allow(ctx, "PatchCompanionData")
+	}
+	return nil
+}
+
+func (s *policyService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (resp *UpdateUserResponse, err error) {
+	if err := allow(ctx, "UpdateUser"); err != nil {
+		return &UpdateUserResponse{}, err
+	}
+	return s.Service.UpdateUser(ctx, req)
+}
+
+func (s *policyService) SearchUser(ctx context.Context, req *SearchUserRequest) (resp *SearchUserResponse, err error) {
+	if err := allow(ctx, "SearchUser"); err != nil {
+		return &SearchUserResponse{}, err
+	}
+	return s.Service.SearchUser(ctx, req)
+}
+
+func (s *policyService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	if err := allow(ctx, "ImportUsers"); err != nil {
+		return &ImportUsersResponse{}, err
+	}
+	return s.Service.ImportUsers(ctx, req)
+}
+
+func (s *policyService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	if err := allow(ctx, "ExportUsers"); err != nil {
+		return err
+	}
+	if err := allowTokens(ctx, req.WithTokens); err != nil {
+		return err
+	}
+	return s.Service.ExportUsers(ctx, req, send)
+}
+
+func (s *policyService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	if err := allow(ctx, "WatchUsers"); err != nil {
+		return err
+	}
+	if err := allowTokens(ctx, req.WithTokens); err != nil {
+		return err
+	}
+	return s.Service.WatchUsers(ctx, req, send)
+}
+
+func (s *policyService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	if err := allow(ctx, "CreateUser"); err != nil {
+		return &CreateUserResponse{}, err
+	}
+	return s.Service.CreateUser(ctx, req)
+}
+
+func (s *policyService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	if err := allow(ctx, "GetUser"); err != nil {
+		return &GetUserResponse{}, err
+	}
+	return s.Service.GetUser(ctx, req)
+}
+
+func (s *policyService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	if err := allow(ctx, "PatchUser"); err != nil {
+		return &PatchUserResponse{}, err
+	}
+	return s.Service.PatchUser(ctx, req)
+}
+
+func (s *policyService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	if err := allow(ctx, "DeleteUser"); err != nil {
+		return &DeleteUserResponse{}, err
+	}
+	return s.Service.DeleteUser(ctx, req)
+}
+
+func (s *policyService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	if err := allow(ctx, "GetUserHistory"); err != nil {
+		return &GetUserHistoryResponse{}, err
+	}
+	return s.Service.GetUserHistory(ctx, req)
+}
+
+func (s *policyService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	if err := allow(ctx, "GetUserQR"); err != nil {
+		return &GetUserQRResponse{}, err
+	}
+	return s.Service.GetUserQR(ctx, req)
+}
+
+func (s *policyService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	if err := allow(ctx, "CheckinByToken"); err != nil {
+		return &CheckinByTokenResponse{}, err
+	}
+	return s.Service.CheckinByToken(ctx, req)
+}
+
+func (s *policyService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	if err := allow(ctx, "VerifyPass"); err != nil {
+		return &VerifyPassResponse{}, err
+	}
+	return s.Service.VerifyPass(ctx, req)
+}
+
+func (s *policyService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	if err := allow(ctx, "BulkUpdateUsers"); err != nil {
+		return &BulkUpdateUsersResponse{}, err
+	}
+	return s.Service.BulkUpdateUsers(ctx, req)
+}
+
+func (s *policyService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	if err := allow(ctx, "AddCompanion"); err != nil {
+		return &AddCompanionResponse{}, err
+	}
+	return s.Service.AddCompanion(ctx, req)
+}
+
+func (s *policyService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	if err := allowPatchCompanion(ctx, req.Data); err != nil {
+		return &PatchCompanionResponse{}, err
+	}
+	return s.Service.PatchCompanion(ctx, req)
+}
+
+func (s *policyService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	if err := allow(ctx, "DeleteCompanion"); err != nil {
+		return &DeleteCompanionResponse{}, err
+	}
+	return s.Service.DeleteCompanion(ctx, req)
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy(t *testing.T) {
+	as := func(role string) context.Context {
+		return auth.WithOperator(context.Background(), auth.Operator{Login: role, Role: role})
+	}
+
+	assert.NoError(t, allow(as("door"), "SearchUser"))
+	assert.NoError(t, allow(as("door"), "UpdateUser"))
+	assert.Equal(t, 403, getHTTPStatusCode(allow(as("door"), "PatchUser")))
+	assert.Equal(t, 403, getHTTPStatusCode(allow(as("door"), "ImportUsers")))
+
+	assert.NoError(t, allow(as("coordinator"), "ImportUsers"))
+	assert.NoError(t, allow(as("coordinator"), "PatchUser"))
+	assert.Error(t, allow(as("coordinator"), "UnknownMethod"))
+
+	name, checkin := "Пётр", true
+	assert.NoError(t, allowPatchCompanion(as("door"), &CompanionData{Checkin: &checkin}))
+	assert.Equal(t, ErrPermissionDenied, errors.Cause(allowPatchCompanion(as("door"), &CompanionData{Name: &name, Checkin: &checkin})))
+	assert.NoError(t, allowPatchCompanion(as("coordinator"), &CompanionData{Name: &name}))
+
+	assert.NoError(t, allow(as("admin"), "UnknownMethod"))
+	assert.Error(t, allow(as("guest"), "SearchUser"))
+	assert.Error(t, allow(context.Background(), "SearchUser"))
+
+	// the edge sync account reads the guests and sends door changes, nothing else
+	edge := auth.WithOperator(context.Background(), auth.Operator{Login: "edge", Role: "door", Sync: true})
+	assert.NoError(t, allow(edge, "ExportUsers"))
+	assert.NoError(t, allow(edge, "GetUser"))
+	assert.Error(t, allow(edge, "PatchUser"))
+	assert.NoError(t, allowTokens(edge, true))
+	assert.NoError(t, allowTokens(as("admin"), false))
+	assert.Equal(t, 403, getHTTPStatusCode(allowTokens(as("admin"), true)))
+}
+
+func TestGRPCError(t *testing.T) {
+	assert.Equal(t, codes.PermissionDenied, status.Code(grpcError(allow(context.Background(), "SearchUser"))))
+	assert.Equal(t, codes.NotFound, status.Code(grpcError(ErrNotFound)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(grpcError(ErrInvalidArgument)))
+
+	st := status.Error(codes.Canceled, "canceled")
+	assert.Equal(t, st, grpcError(st))
+	assert.NoError(t, grpcError(nil))
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"strings"
+
+	"github.com/nakiner/guestcovider/internal/qr"
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	defaultQRScale = 8
+	maxQRScale     = 64
+)
+
+// renderQR encodes an invitation token as an image, scale is the size of
+// a module in pixels.
+func renderQR(token, format string, scale uint32) (image []byte, contentType string, err error) {
+	if scale == 0 {
+		scale = defaultQRScale
+	}
+	if scale > maxQRScale {
+		return nil, "", errors.Wrapf(ErrInvalidArgument, "scale must not exceed %d", maxQRScale)
+	}
+
+	code, err := qr.Encode([]byte(token), qr.Medium)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch strings.ToLower(format) {
+	case FormatPNG, "":
+		image, err = code.PNG(int(scale))
+		return image, "image/png", err
+	case FormatSVG:
+		return code.SVG(int(scale)), "image/svg+xml", nil
+	default:
+		return nil, "", errors.Wrapf(ErrInvalidArgument, "unknown image format %q", format)
+	}
+}
@@ -0,0 +1,31 @@
+package user
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderQR(t *testing.T) {
+	image, contentType, err := renderQR("kqF3n8XyVbT0pL2wRzA9cA", "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	img, err := png.Decode(bytes.NewReader(image))
+	require.NoError(t, err)
+	// version 2 is 25 modules wide, plus the quiet zone
+	assert.Equal(t, (25+8)*defaultQRScale, img.Bounds().Dx())
+
+	image, contentType, err = renderQR("kqF3n8XyVbT0pL2wRzA9cA", "SVG", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", contentType)
+	assert.Contains(t, string(image), `width="66"`)
+
+	_, _, err = renderQR("token", "gif", 0)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, _, err = renderQR("token", FormatPNG, maxQRScale+1)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
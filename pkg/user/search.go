@@ -0,0 +1,98 @@
+package user
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nakiner/guestcovider/internal/translit"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// searchTerms returns the query as typed along with its transliteration,
+// so that "Ivanov" also finds "Иванов" and the other way round.
+func searchTerms(query string) []string {
+	query = userRepository.NormalizeSearch(query)
+	if query == "" {
+		return nil
+	}
+
+	terms := []string{query}
+	add := func(t string) {
+		for _, v := range terms {
+			if v == t {
+				return
+			}
+		}
+		terms = append(terms, t)
+	}
+	if translit.IsCyrillic(query) {
+		add(userRepository.NormalizeSearch(translit.ToLatin(query)))
+	}
+	if hasLatin(query) {
+		add(userRepository.NormalizeSearch(translit.ToCyrillic(query)))
+	}
+
+	return terms
+}
+
+func hasLatin(s string) bool {
+	for _, r := range s {
+		if unicode.In(r, unicode.Latin) {
+			return true
+		}
+	}
+	return false
+}
+
+func searchLimit(limit uint32) int {
+	switch {
+	case limit == 0:
+		return defaultSearchLimit
+	case limit > maxSearchLimit:
+		return maxSearchLimit
+	}
+	return int(limit)
+}
+
+// encodeCursor makes an opaque page token of the rank and id of the last
+// guest on a page.
+func encodeCursor(c *userRepository.Cursor) string {
+	if c == nil {
+		return ""
+	}
+	raw := strconv.FormatFloat(float64(c.Rank), 'g', -1, 32) + ":" + strconv.FormatUint(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (*userRepository.Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArgument, "malformed cursor")
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.Wrap(ErrInvalidArgument, "malformed cursor")
+	}
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArgument, "malformed cursor")
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidArgument, "malformed cursor")
+	}
+
+	return &userRepository.Cursor{Rank: float32(rank), ID: id}, nil
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Nil(t, searchTerms("  "))
+	assert.Equal(t, []string{"иванов", "ivanov"}, searchTerms("Иванов "))
+	assert.Equal(t, []string{"ivanov", "иванов"}, searchTerms("Ivanov"))
+	assert.Equal(t, []string{"семенов", "semenov"}, searchTerms("Семёнов"))
+	assert.Equal(t, []string{"8916"}, searchTerms("8916"))
+}
+
+func TestSearchLimit(t *testing.T) {
+	assert.Equal(t, defaultSearchLimit, searchLimit(0))
+	assert.Equal(t, 5, searchLimit(5))
+	assert.Equal(t, maxSearchLimit, searchLimit(1000))
+}
+
+func TestCursor(t *testing.T) {
+	assert.Equal(t, "", encodeCursor(nil))
+
+	c := &userRepository.Cursor{Rank: 0.42857143, ID: 17}
+	got, err := decodeCursor(encodeCursor(c))
+	require.NoError(t, err)
+	assert.Equal(t, c, got)
+
+	got, err = decodeCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	for _, bad := range []string{"!!", "MTc", "eDox"} {
+		_, err := decodeCursor(bad)
+		assert.Equal(t, ErrInvalidArgument, errors.Cause(err), bad)
+	}
+}
@@ -62,3 +62,243 @@ func (s *sentryService) SearchUser(ctx context.Context, req *SearchUserRequest)
 	}()
 	return s.Service.SearchUser(ctx, req)
 }
+
+func (s *sentryService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "ImportUsers")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.ImportUsers(ctx, req)
+}
+
+func (s *sentryService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) (err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, nil)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "ExportUsers")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.ExportUsers(ctx, req, send)
+}
+
+func (s *sentryService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) (err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, nil)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "WatchUsers")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.WatchUsers(ctx, req, send)
+}
+
+func (s *sentryService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "CreateUser")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.CreateUser(ctx, req)
+}
+
+func (s *sentryService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "GetUser")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.GetUser(ctx, req)
+}
+
+func (s *sentryService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "PatchUser")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.PatchUser(ctx, req)
+}
+
+func (s *sentryService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "DeleteUser")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.DeleteUser(ctx, req)
+}
+
+func (s *sentryService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "GetUserHistory")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.GetUserHistory(ctx, req)
+}
+
+func (s *sentryService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "GetUserQR")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.GetUserQR(ctx, req)
+}
+
+func (s *sentryService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "CheckinByToken")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.CheckinByToken(ctx, req)
+}
+
+func (s *sentryService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "VerifyPass")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.VerifyPass(ctx, req)
+}
+
+func (s *sentryService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "BulkUpdateUsers")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.BulkUpdateUsers(ctx, req)
+}
+
+func (s *sentryService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "AddCompanion")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.AddCompanion(ctx, req)
+}
+
+func (s *sentryService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "PatchCompanion")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.PatchCompanion(ctx, req)
+}
+
+func (s *sentryService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	defer func() {
+		if err != nil {
+			log := s.getSentryLog(req, resp)
+			sentry.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("code", strconv.Itoa(getHTTPStatusCode(err)))
+				scope.SetTag("method", "DeleteCompanion")
+				scope.SetExtra("request", log["request"])
+				scope.SetExtra("response", log["response"])
+			})
+			sentry.CaptureException(err)
+		}
+	}()
+	return s.Service.DeleteCompanion(ctx, req)
+}
@@ -2,34 +2,78 @@ package user
 
 import (
 	"context"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/covidcert"
+	"github.com/nakiner/guestcovider/internal/eventRepository"
 	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
 )
 
 type userService struct {
-	repo userRepository.Repository
+	repo     userRepository.Repository
+	events   eventRepository.Repository
+	verifier *covidcert.Verifier
+	feed     *userRepository.Feed
 }
 
-func NewUserService(repo userRepository.Repository) Service {
-	return &userService{repo: repo}
+func NewUserService(repo userRepository.Repository, events eventRepository.Repository, verifier *covidcert.Verifier, feed *userRepository.Feed) Service {
+	return &userService{repo: repo, events: events, verifier: verifier, feed: feed}
+}
+
+// getEvent loads the event a request is scoped to, zero means the default event.
+func (s *userService) getEvent(ctx context.Context, id uint64) (*eventRepository.Event, error) {
+	if id == 0 {
+		id = eventRepository.DefaultEventID
+	}
+
+	event, err := s.events.GetEvent(ctx, id)
+	if errors.Is(err, eventRepository.ErrNotFound) {
+		return nil, errors.Wrapf(ErrNotFound, "event %d", id)
+	}
+	return event, err
 }
 
 func (s *userService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (resp *UpdateUserResponse, err error) {
 	resp = &UpdateUserResponse{}
+	ctx = auditContext(ctx)
 
 	if req.Id < 0 {
 		return resp, ErrInvalidRequest
 	}
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "no update data")
+	}
+	if req.Version == 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, "no version of the user")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
 
 	user := userRepository.User{
-		ID: req.Id,
-		Checkin: req.Data.Checkin,
-		CovidPass: req.Data.CovidPass,
+		ID:       req.Id,
+		EventID:  event.ID,
+		Version:  req.Version,
+		Checkin:  req.Data.Checkin,
+		Entrance: strings.TrimSpace(req.Data.Entrance),
+	}
+	if err := setCovidPass(&user, req.Data.CovidPass); err != nil {
+		return resp, err
+	}
+	if err := validateCovidPass(event, &user); err != nil {
+		return resp, err
 	}
 
 	if err := s.repo.UpdateUser(ctx, &user); err != nil {
-		return resp, err
+		return resp, repoError(err)
 	}
 
+	data := UserFromRepo(&user)
+	resp.Data = &data
 	resp.Status = &Status{
 		Status: true,
 		Message: "OK",
@@ -40,21 +84,726 @@ func (s *userService) UpdateUser(ctx context.Context, req *UpdateUserRequest) (r
 
 func (s *userService) SearchUser(ctx context.Context, req *SearchUserRequest) (resp *SearchUserResponse, err error) {
 	resp = &SearchUserResponse{}
-	if len(req.Surname) < 1 {
-		return resp, nil
+
+	query := req.Query
+	if query == "" {
+		query = req.Surname
+	}
+	after, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return resp, err
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
 	}
 
-	users, err := s.repo.FindBySurname(ctx, req.Surname)
+	result, err := s.repo.SearchUsers(ctx, userRepository.SearchQuery{
+		EventID: event.ID,
+		Terms:   searchTerms(query),
+		Limit:   searchLimit(req.Limit),
+		After:   after,
+	})
 	if err != nil {
 		return resp, err
 	}
 
+	resp.Total = uint64(result.Total)
+	resp.NextCursor = encodeCursor(result.Next)
 	resp.Status = &Status{
 		Status: true,
 		Message: "OK",
 	}
 
-	return resp.FromRepo(users), nil
+	return resp.FromRepo(result.Users), nil
+}
+
+func (s *userService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	resp = &ImportUsersResponse{DryRun: req.DryRun}
+
+	if len(req.Content) == 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, "empty file")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	rows, err := parseGuestList(req.Format, req.Content)
+	if err != nil {
+		return resp, err
+	}
+	validateGuestList(rows)
+
+	var valid []*userRepository.User
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			resp.Rows = append(resp.Rows, ImportRowReport{
+				Row:     row.line,
+				Surname: row.user.Surname,
+				Name:    row.user.Name,
+				Errors:  row.errors,
+			})
+			continue
+		}
+		user := row.user
+		user.EventID = event.ID
+		valid = append(valid, &user)
+	}
+
+	resp.Total = uint32(len(rows))
+	resp.Valid = uint32(len(valid))
+
+	if !req.DryRun && len(valid) > 0 {
+		created, updated, err := s.repo.UpsertUsers(ctx, valid)
+		if err != nil {
+			return resp, err
+		}
+		resp.Created = uint32(created)
+		resp.Updated = uint32(updated)
+	}
+
+	resp.Status = &Status{
+		Status: true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return err
+	}
+
+	filter := userRepository.Filter{
+		EventID: event.ID,
+		Checkin: req.Checkin,
+		Company: req.Company,
+		Status:  req.Status,
+	}
+
+	return s.repo.IterateUsers(ctx, filter, func(u *userRepository.User) error {
+		user := UserFromRepo(u)
+		if req.WithTokens {
+			user.InviteToken = u.InviteToken
+		}
+		return send(user)
+	})
+}
+
+func (s *userService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return err
+	}
+
+	toChange := ChangeFromRepo
+	if req.WithTokens {
+		toChange = changeWithToken
+	}
+
+	// subscribe before reading the backlog, so no change falls in between
+	sub, last := s.feed.Subscribe(event.ID)
+	defer sub.Close()
+
+	if req.AfterSeq != 0 {
+		if err := s.sendBacklog(ctx, event.ID, req.AfterSeq, last, toChange, send); err != nil {
+			return err
+		}
+	}
+	if last < req.AfterSeq {
+		last = req.AfterSeq
+	}
+	if err := send(UserChange{Seq: last, Action: ChangeReady, EventId: event.ID}); err != nil {
+		return err
+	}
+
+	for {
+		c, err := sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// the client went away
+				return nil
+			}
+			if err == userRepository.ErrFeedClosed {
+				return errors.Wrap(ErrUnavailable, err.Error())
+			}
+			return err
+		}
+		if c.Seq <= last {
+			continue
+		}
+		if err := send(toChange(c)); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// sendBacklog sends changes of the event after the sequence number up to
+// and including last.
+func (s *userService) sendBacklog(ctx context.Context, eventID, after, last uint64, toChange func(*userRepository.Change) UserChange, send func(UserChange) error) error {
+	for after < last {
+		changes, err := s.repo.ListChanges(ctx, userRepository.ChangeQuery{EventID: eventID, After: after, Limit: watchPage})
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			if c.Seq > last {
+				return nil
+			}
+			if err := send(toChange(c)); err != nil {
+				return err
+			}
+			after = c.Seq
+		}
+		if len(changes) < watchPage {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *userService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	resp = &CreateUserResponse{}
+	ctx = auditContext(ctx)
+
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "no guest data")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user := UserToRepo(req.Data)
+	user.ID = 0
+	user.EventID = event.ID
+
+	if errs := validateGuest(&user); len(errs) > 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, strings.Join(errs, ", "))
+	}
+	if err := setCovidPass(&user, req.Data.CovidPass); err != nil {
+		return resp, err
+	}
+	if err := validateCovidPass(event, &user); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.CreateUser(ctx, &user); err != nil {
+		return resp, err
+	}
+
+	data := UserFromRepo(&user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	resp = &GetUserResponse{}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	resp = &PatchUserResponse{}
+	ctx = auditContext(ctx)
+
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "nothing to change")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	columns, err := req.Data.apply(user)
+	if err != nil {
+		return resp, err
+	}
+
+	if errs := validateColumns(user, columns); len(errs) > 0 {
+		return resp, errors.Wrap(ErrInvalidArgument, strings.Join(errs, ", "))
+	}
+	if req.Data.CovidPass != nil {
+		if err := validateCovidPass(event, user); err != nil {
+			return resp, err
+		}
+	}
+	if req.Data.MaxCompanions != nil && len(user.Companions) > user.MaxCompanions {
+		return resp, errors.Wrapf(ErrInvalidArgument, "the guest already has %d companions", len(user.Companions))
+	}
+
+	if err := s.repo.PatchUser(ctx, user, columns); err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	resp = &BulkUpdateUsersResponse{}
+	ctx = auditContext(ctx)
+
+	if req.Data == nil || (req.Data.Checkin == nil && req.Data.CovidPass == nil) {
+		return resp, errors.Wrap(ErrInvalidArgument, "nothing to change")
+	}
+	q, err := bulkQuery(req)
+	if err != nil {
+		return resp, err
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+	q.EventID = event.ID
+
+	data := req.Data.patchData()
+	results, err := s.repo.PatchUsers(ctx, q, req.AllOrNothing, func(u *userRepository.User) ([]string, error) {
+		columns, err := data.apply(u)
+		if err != nil {
+			return nil, err
+		}
+		if data.CovidPass != nil {
+			if err := validateCovidPass(event, u); err != nil {
+				return nil, err
+			}
+		}
+		return columns, nil
+	})
+	if errors.Is(err, userRepository.ErrBulkTooLarge) {
+		return resp, errors.Wrapf(ErrInvalidArgument, "more than %d guests match the filter", bulkLimit)
+	}
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	for _, r := range results {
+		res := BulkResultFromRepo(r)
+		if res.Ok {
+			resp.Updated++
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	resp.Status = bulkStatus(resp.Results, resp.Updated)
+
+	return resp, nil
+}
+
+func (s *userService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	resp = &AddCompanionResponse{}
+	ctx = auditContext(ctx)
+
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "no companion data")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	companion := userRepository.Companion{UserID: req.UserId, EventID: event.ID}
+	if _, err := req.Data.apply(&companion); err != nil {
+		return resp, err
+	}
+	if err := validateCompanion(event, &companion, req.Data); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.AddCompanion(ctx, &companion); err != nil {
+		return resp, repoError(err)
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.UserId)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	resp = &PatchCompanionResponse{}
+	ctx = auditContext(ctx)
+
+	if req.Data == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "nothing to change")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.UserId)
+	if err != nil {
+		return resp, repoError(err)
+	}
+	companion, err := findCompanion(user, req.Id)
+	if err != nil {
+		return resp, err
+	}
+
+	columns, err := req.Data.apply(companion)
+	if err != nil {
+		return resp, err
+	}
+	if err := validateCompanion(event, companion, req.Data); err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.PatchCompanion(ctx, companion, columns); err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	resp = &DeleteCompanionResponse{}
+	ctx = auditContext(ctx)
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.DeleteCompanion(ctx, event.ID, req.UserId, req.Id); err != nil {
+		return resp, repoError(err)
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.UserId)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	resp = &DeleteUserResponse{}
+	ctx = auditContext(ctx)
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := s.repo.DeleteUser(ctx, event.ID, req.Id); err != nil {
+		return resp, repoError(err)
+	}
+
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	resp = &GetUserHistoryResponse{}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	records, err := s.repo.GetUserHistory(ctx, event.ID, req.Id)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, r := range records {
+		resp.Data = append(resp.Data, AuditRecord{
+			Id:        r.ID,
+			UserId:    r.UserID,
+			Action:    r.Action,
+			Actor:     r.Actor,
+			Transport: r.Transport,
+			OldValues: r.OldValues,
+			NewValues: r.NewValues,
+			CreatedAt: r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	resp = &GetUserQRResponse{}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	image, contentType, err := renderQR(user.InviteToken, req.Format, req.Scale)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Token = user.InviteToken
+	resp.ContentType = contentType
+	resp.Image = image
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+// CheckinByToken checks in the guest holding a scanned invitation. A repeated
+// scan is not an error: the guest is returned with the time of the first one.
+func (s *userService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	resp = &CheckinByTokenResponse{}
+	ctx = auditContext(ctx)
+
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return resp, errors.Wrap(ErrInvalidArgument, "no token")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.CheckinByToken(ctx, event.ID, token, strings.TrimSpace(req.Entrance))
+	if err != nil && !errors.Is(err, userRepository.ErrAlreadyCheckedIn) {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	if user.CheckinAt != nil {
+		resp.CheckedInAt = user.CheckinAt.Format(time.RFC3339)
+	}
+
+	if err != nil {
+		resp.AlreadyCheckedIn = true
+		resp.Status = &Status{
+			Status:  false,
+			Message: "already checked in",
+		}
+		if resp.CheckedInAt != "" {
+			resp.Status.Message += " at " + resp.CheckedInAt
+		}
+		return resp, nil
+	}
+
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+func (s *userService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	resp = &VerifyPassResponse{}
+	ctx = auditContext(ctx)
+
+	if strings.TrimSpace(req.Payload) == "" {
+		return resp, errors.Wrap(ErrInvalidArgument, "no certificate")
+	}
+	if s.verifier == nil {
+		return resp, errors.Wrap(ErrInvalidArgument, "certificate checks are not configured")
+	}
+
+	event, err := s.getEvent(ctx, req.EventId)
+	if err != nil {
+		return resp, err
+	}
+
+	user, err := s.repo.GetUser(ctx, event.ID, req.Id)
+	if err != nil {
+		return resp, repoError(err)
+	}
+
+	result, err := s.verifier.Verify(ctx, req.Payload)
+	if err != nil {
+		return resp, passError(err)
+	}
+
+	columns := applyPassCheck(event, user, result, time.Now())
+	user.CovidPassCheck.CheckedBy = userRepository.ActorFromContext(ctx).Name
+	if err := s.repo.PatchUser(ctx, user, columns); err != nil {
+		return resp, repoError(err)
+	}
+
+	data := UserFromRepo(user)
+	resp.Data = &data
+	resp.Check = data.PassCheck
+	if resp.Check.Status != userRepository.CheckValid {
+		resp.Status = &Status{
+			Status:  false,
+			Message: resp.Check.Reason,
+		}
+		return resp, nil
+	}
+
+	resp.Status = &Status{
+		Status:  true,
+		Message: "OK",
+	}
+
+	return resp, nil
+}
+
+// auditContext tags repository changes with the logged in operator, or the
+// caller address without one, and the transport. Changes an edge sends for
+// a door operator name both, the door one is not checked here.
+func auditContext(ctx context.Context) context.Context {
+	actor := userRepository.ActorFromContext(ctx)
+	if op, ok := auth.OperatorFromContext(ctx); ok && actor.Name == "" {
+		actor.Name = op.Login
+		if acting := auth.ActingFromContext(ctx); acting != "" {
+			actor.Name = acting + " via " + op.Login
+		}
+	}
+	if info, ok := ctx.Value(ContextHTTPKey{}).(HTTPInfo); ok {
+		actor.Transport = "http"
+		if actor.Name == "" {
+			actor.Name = info.From
+		}
+	}
+	if info, ok := ctx.Value(ContextGRPCKey{}).(GRPCInfo); ok {
+		actor.Transport = "grpc"
+		if actor.Name == "" {
+			actor.Name = info.From
+		}
+	}
+	return userRepository.WithActor(ctx, actor)
+}
+
+// repoError maps repository errors onto service errors.
+func repoError(err error) error {
+	if errors.Is(err, userRepository.ErrNotFound) {
+		return errors.Wrap(ErrNotFound, err.Error())
+	}
+	if errors.Is(err, userRepository.ErrPartyFull) {
+		return errors.Wrap(ErrInvalidArgument, err.Error())
+	}
+	var conflict *userRepository.ConflictError
+	if errors.As(err, &conflict) {
+		current := UserFromRepo(conflict.Current)
+		return &ConflictError{Current: &current}
+	}
+	return err
+}
+
+// apply copies set fields onto u and returns the changed columns.
+func (d *PatchData) apply(u *userRepository.User) ([]string, error) {
+	var columns []string
+	set := func(column string, dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+			columns = append(columns, column)
+		}
+	}
+
+	set("status", &u.Status, d.Status)
+	set("company", &u.Company, d.Company)
+	set("surname", &u.Surname, d.Surname)
+	set("name", &u.Name, d.Name)
+	set("guest", &u.Guest, d.Guest)
+	set("rank", &u.Rank, d.Rank)
+	set("contact_phone", &u.ContactPhone, d.ContactPhone)
+	set("contact_mail", &u.ContactMail, d.ContactMail)
+	set("entrance", &u.Entrance, d.Entrance)
+	if d.Checkin != nil {
+		u.Checkin = *d.Checkin
+		columns = append(columns, "checkin")
+	}
+	if d.MaxCompanions != nil {
+		u.MaxCompanions = int(*d.MaxCompanions)
+		columns = append(columns, "max_companions")
+	}
+	if d.CovidPass != nil {
+		if err := setCovidPass(u, d.CovidPass); err != nil {
+			return nil, err
+		}
+		u.CovidPassCheck = nil
+		columns = append(columns, "covid_pass", "covid_pass_test_date", "covid_pass_expires_at", "covid_pass_number", "covid_pass_check")
+	}
+
+	return columns, nil
 }
 
 func (pp *SearchUserResponse) FromRepo(in []*userRepository.User) *SearchUserResponse {
@@ -63,20 +812,80 @@ func (pp *SearchUserResponse) FromRepo(in []*userRepository.User) *SearchUserRes
 	}
 
 	for _, i := range in {
-		pp.Data = append(pp.Data, User{
-			Id:           i.ID,
-			Status:       i.Status,
-			Company:      i.Company,
-			Surname:      i.Surname,
-			Name:         i.Name,
-			Guest:        i.Guest,
-			CovidPass:    i.CovidPass,
-			Rank:         i.Rank,
-			ContactPhone: i.ContactPhone,
-			ContactMail:  i.ContactMail,
-			Checkin:      i.Checkin,
-		})
+		pp.Data = append(pp.Data, UserFromRepo(i))
 	}
 
 	return pp
 }
+
+func UserFromRepo(i *userRepository.User) User {
+	return User{
+		Id:            i.ID,
+		Status:        i.Status,
+		Company:       i.Company,
+		Surname:       i.Surname,
+		Name:          i.Name,
+		Guest:         i.Guest,
+		CovidPass:     CovidPassFromRepo(i),
+		Rank:          i.Rank,
+		ContactPhone:  i.ContactPhone,
+		ContactMail:   i.ContactMail,
+		Checkin:       i.Checkin,
+		EventId:       i.EventID,
+		CheckinRecord: CheckinRecordFromRepo(i),
+		PassCheck:     PassCheckFromRepo(i.CovidPassCheck),
+		Version:       i.Version,
+		MaxCompanions: uint32(i.MaxCompanions),
+		Companions:    companionsFromRepo(i.Companions),
+	}
+}
+
+func companionsFromRepo(in []*userRepository.Companion) []Companion {
+	var out []Companion
+	for _, c := range in {
+		out = append(out, CompanionFromRepo(c))
+	}
+	return out
+}
+
+// CheckinRecordFromRepo returns the check-in record of a guest, nil when
+// the guest has never been checked in.
+func CheckinRecordFromRepo(i *userRepository.User) *CheckinRecord {
+	if i.CheckinAt == nil {
+		return nil
+	}
+
+	record := CheckinRecord{
+		CheckedInAt: i.CheckinAt.Format(time.RFC3339),
+		CheckedInBy: i.CheckinBy,
+		Entrance:    i.Entrance,
+	}
+	if i.CheckoutAt != nil {
+		record.CheckedOutAt = i.CheckoutAt.Format(time.RFC3339)
+		record.CheckedOutBy = i.CheckoutBy
+	}
+	return &record
+}
+
+func UserToRepo(i *User) userRepository.User {
+	var entrance string
+	if i.CheckinRecord != nil {
+		entrance = strings.TrimSpace(i.CheckinRecord.Entrance)
+	}
+
+	return userRepository.User{
+		ID:            i.Id,
+		EventID:       i.EventId,
+		Status:        strings.TrimSpace(i.Status),
+		Company:       strings.TrimSpace(i.Company),
+		Surname:       strings.TrimSpace(i.Surname),
+		Name:          strings.TrimSpace(i.Name),
+		Guest:         strings.TrimSpace(i.Guest),
+		Rank:          strings.TrimSpace(i.Rank),
+		ContactPhone:  strings.TrimSpace(i.ContactPhone),
+		ContactMail:   strings.TrimSpace(i.ContactMail),
+		Checkin:       i.Checkin,
+		Entrance:      entrance,
+		MaxCompanions: int(i.MaxCompanions),
+	}
+}
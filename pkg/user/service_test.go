@@ -0,0 +1,48 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchDataApply(t *testing.T) {
+	u := userRepository.User{Surname: "Иванов", Name: "Иван", Checkin: true}
+
+	name, phone, checkin := " Пётр ", "8 916 123 45 67", false
+	columns, err := (&PatchData{Name: &name, ContactPhone: &phone, Checkin: &checkin}).apply(&u)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"name", "contact_phone", "checkin"}, columns)
+	assert.Equal(t, "Иванов", u.Surname)
+	assert.Equal(t, "Пётр", u.Name)
+	assert.False(t, u.Checkin)
+
+	assert.Empty(t, validateColumns(&u, columns))
+	assert.Equal(t, "89161234567", u.ContactPhone)
+
+	empty := ""
+	columns, _ = (&PatchData{Surname: &empty}).apply(&u)
+	assert.Equal(t, []string{"missing surname"}, validateColumns(&u, columns))
+
+	// a stored field the patch leaves alone does not block it
+	u.Surname, u.ContactMail = "Иванов", "not an email"
+	columns, _ = (&PatchData{Name: &name}).apply(&u)
+	assert.Empty(t, validateColumns(&u, columns))
+	assert.Equal(t, []string{`malformed email "not an email"`}, validateGuest(&u))
+}
+
+func TestAuditContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ContextGRPCKey{}, GRPCInfo{From: "10.0.0.1:5000"})
+	assert.Equal(t, userRepository.Actor{Name: "10.0.0.1:5000", Transport: "grpc"}, userRepository.ActorFromContext(auditContext(ctx)))
+
+	ctx = auth.WithOperator(ctx, auth.Operator{Login: "edge1", Role: "coordinator"})
+	assert.Equal(t, "edge1", userRepository.ActorFromContext(auditContext(ctx)).Name)
+
+	// a change made at the door and sent by the edge names both
+	ctx = auth.WithActing(ctx, "anna")
+	assert.Equal(t, "anna via edge1", userRepository.ActorFromContext(auditContext(ctx)).Name)
+}
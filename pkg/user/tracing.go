@@ -29,3 +29,93 @@ func (s *tracingService) SearchUser(ctx context.Context, req *SearchUserRequest)
 	defer span.Finish()
 	return s.Service.SearchUser(ctx, req)
 }
+
+func (s *tracingService) ImportUsers(ctx context.Context, req *ImportUsersRequest) (resp *ImportUsersResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "ImportUsers")
+	defer span.Finish()
+	return s.Service.ImportUsers(ctx, req)
+}
+
+func (s *tracingService) ExportUsers(ctx context.Context, req *ExportUsersRequest, send func(User) error) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "ExportUsers")
+	defer span.Finish()
+	return s.Service.ExportUsers(ctx, req, send)
+}
+
+func (s *tracingService) WatchUsers(ctx context.Context, req *WatchUsersRequest, send func(UserChange) error) error {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "WatchUsers")
+	defer span.Finish()
+	return s.Service.WatchUsers(ctx, req, send)
+}
+
+func (s *tracingService) CreateUser(ctx context.Context, req *CreateUserRequest) (resp *CreateUserResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "CreateUser")
+	defer span.Finish()
+	return s.Service.CreateUser(ctx, req)
+}
+
+func (s *tracingService) GetUser(ctx context.Context, req *GetUserRequest) (resp *GetUserResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "GetUser")
+	defer span.Finish()
+	return s.Service.GetUser(ctx, req)
+}
+
+func (s *tracingService) PatchUser(ctx context.Context, req *PatchUserRequest) (resp *PatchUserResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "PatchUser")
+	defer span.Finish()
+	return s.Service.PatchUser(ctx, req)
+}
+
+func (s *tracingService) DeleteUser(ctx context.Context, req *DeleteUserRequest) (resp *DeleteUserResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "DeleteUser")
+	defer span.Finish()
+	return s.Service.DeleteUser(ctx, req)
+}
+
+func (s *tracingService) GetUserHistory(ctx context.Context, req *GetUserHistoryRequest) (resp *GetUserHistoryResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "GetUserHistory")
+	defer span.Finish()
+	return s.Service.GetUserHistory(ctx, req)
+}
+
+func (s *tracingService) GetUserQR(ctx context.Context, req *GetUserQRRequest) (resp *GetUserQRResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "GetUserQR")
+	defer span.Finish()
+	return s.Service.GetUserQR(ctx, req)
+}
+
+func (s *tracingService) CheckinByToken(ctx context.Context, req *CheckinByTokenRequest) (resp *CheckinByTokenResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "CheckinByToken")
+	defer span.Finish()
+	return s.Service.CheckinByToken(ctx, req)
+}
+
+func (s *tracingService) VerifyPass(ctx context.Context, req *VerifyPassRequest) (resp *VerifyPassResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "VerifyPass")
+	defer span.Finish()
+	return s.Service.VerifyPass(ctx, req)
+}
+
+func (s *tracingService) BulkUpdateUsers(ctx context.Context, req *BulkUpdateUsersRequest) (resp *BulkUpdateUsersResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "BulkUpdateUsers")
+	defer span.Finish()
+	return s.Service.BulkUpdateUsers(ctx, req)
+}
+
+func (s *tracingService) AddCompanion(ctx context.Context, req *AddCompanionRequest) (resp *AddCompanionResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "AddCompanion")
+	defer span.Finish()
+	return s.Service.AddCompanion(ctx, req)
+}
+
+func (s *tracingService) PatchCompanion(ctx context.Context, req *PatchCompanionRequest) (resp *PatchCompanionResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "PatchCompanion")
+	defer span.Finish()
+	return s.Service.PatchCompanion(ctx, req)
+}
+
+func (s *tracingService) DeleteCompanion(ctx context.Context, req *DeleteCompanionRequest) (resp *DeleteCompanionResponse, err error) {
+	span, ctx := opentracing.StartSpanFromContextWithTracer(ctx, s.tracer, "DeleteCompanion")
+	defer span.Finish()
+	return s.Service.DeleteCompanion(ctx, req)
+}
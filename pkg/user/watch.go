@@ -0,0 +1,41 @@
+package user
+
+import (
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+)
+
+// ChangeReady is the action of the change that follows the backlog of a
+// WatchUsers stream, its Seq is the last change already sent.
+const ChangeReady = "ready"
+
+// watchPage is how many backlog changes are read at once.
+const watchPage = 500
+
+// ChangeFromRepo returns a change as sent to watching clients.
+func ChangeFromRepo(c *userRepository.Change) UserChange {
+	change := UserChange{
+		Seq:       c.Seq,
+		Action:    c.Action,
+		UserId:    c.UserID,
+		EventId:   c.EventID,
+		Actor:     c.Actor,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.User != nil && c.Action != userRepository.ActionDelete {
+		data := UserFromRepo(c.User)
+		change.Data = &data
+	}
+	return change
+}
+
+// changeWithToken is ChangeFromRepo with the invitation token of the guest,
+// for edge sync accounts.
+func changeWithToken(c *userRepository.Change) UserChange {
+	change := ChangeFromRepo(c)
+	if change.Data != nil {
+		change.Data.InviteToken = c.User.InviteToken
+	}
+	return change
+}
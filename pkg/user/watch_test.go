@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nakiner/guestcovider/internal/userRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeFromRepo(t *testing.T) {
+	at := time.Date(2021, 9, 14, 19, 0, 0, 0, time.UTC)
+	u := &userRepository.User{ID: 7, EventID: 1, Surname: "Иванов"}
+
+	c := ChangeFromRepo(&userRepository.Change{Seq: 42, EventID: 1, UserID: 7, Action: "checkin", Actor: "door1", CreatedAt: at, User: u})
+	assert.Equal(t, uint64(42), c.Seq)
+	assert.Equal(t, "2021-09-14T19:00:00Z", c.CreatedAt)
+	if assert.NotNil(t, c.Data) {
+		assert.Equal(t, "Иванов", c.Data.Surname)
+	}
+
+	c = ChangeFromRepo(&userRepository.Change{Seq: 43, EventID: 1, UserID: 7, Action: "delete", CreatedAt: at, User: u})
+	assert.Nil(t, c.Data)
+}
+
+func TestEventStream(t *testing.T) {
+	w := httptest.NewRecorder()
+	stream := &eventStream{w: w, flusher: w}
+	assert.False(t, stream.Started())
+
+	require.NoError(t, stream.Send(UserChange{Seq: 5, Action: ChangeReady, EventId: 1}))
+	require.NoError(t, stream.Send(UserChange{Seq: 6, Action: "patch", UserId: 7, EventId: 1, Data: &User{Id: 7, Surname: "Иванов"}}))
+	require.NoError(t, stream.write(": ping\n\n"))
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "id: 6\nevent: change\ndata: {")
+
+	var got []UserChange
+	require.NoError(t, readEvents(w.Body, func(data []byte) error {
+		var c UserChange
+		require.NoError(t, json.Unmarshal(data, &c))
+		got = append(got, c)
+		return nil
+	}))
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, ChangeReady, got[0].Action)
+		assert.Equal(t, "Иванов", got[1].Data.Surname)
+	}
+}
+
+func TestDecodeWatchUsersRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/user/events?eventId=2&afterSeq=10&access_token=secret", nil)
+	req, err := decodeGETWatchUsersRequest(context.Background(), r)
+	require.NoError(t, err)
+	assert.Equal(t, WatchUsersRequest{EventId: 2, AfterSeq: 10}, req)
+
+	r.Header.Set("Last-Event-ID", "15")
+	req, err = decodeGETWatchUsersRequest(context.Background(), r)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), req.(WatchUsersRequest).AfterSeq)
+
+	r.Header.Set("Last-Event-ID", "x")
+	_, err = decodeGETWatchUsersRequest(context.Background(), r)
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+//go:build integration && !unit
+// +build integration,!unit
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nakiner/guestcovider/pkg/event"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCEventServiceCreateEvent(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := event.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.CreateEvent(grpcContext(t, conn), &event.CreateEventRequest{
+		Name:     "gRPC event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateEvent(grpcDoorContext(t, conn), &event.CreateEventRequest{
+		Name:     "gRPC event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	assert.Error(t, err)
+}
+
+func TestGRPCEventServiceListEvents(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := event.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.ListEvents(grpcDoorContext(t, conn), &event.ListEventsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestGRPCEventServiceUpdateEvent(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := event.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	ctx := grpcContext(t, conn)
+	created, err := client.CreateEvent(ctx, &event.CreateEventRequest{
+		Name:     "gRPC event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	venue := "Манеж"
+	_, err = client.UpdateEvent(ctx, &event.UpdateEventRequest{
+		Id:    created.Event.Id,
+		Venue: &venue,
+	})
+	assert.NoError(t, err)
+}
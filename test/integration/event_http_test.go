@@ -0,0 +1,58 @@
+//go:build integration && !unit
+// +build integration,!unit
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nakiner/guestcovider/pkg/event"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPEventServiceCreateEvent(t *testing.T) {
+	client, err := event.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.CreateEvent(httpContext(t), &event.CreateEventRequest{
+		Name:     "HTTP event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateEvent(httpDoorContext(t), &event.CreateEventRequest{
+		Name:     "HTTP event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	assert.Error(t, err)
+}
+
+func TestHTTPEventServiceListEvents(t *testing.T) {
+	client, err := event.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.ListEvents(httpDoorContext(t), &event.ListEventsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestHTTPEventServiceUpdateEvent(t *testing.T) {
+	client, err := event.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	ctx := httpContext(t)
+	created, err := client.CreateEvent(ctx, &event.CreateEventRequest{
+		Name:     "HTTP event",
+		StartsAt: "2021-10-01T18:00:00+03:00",
+		EndsAt:   "2021-10-01T23:00:00+03:00",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	types := []string{"qr"}
+	_, err = client.UpdateEvent(ctx, &event.UpdateEventRequest{
+		Id:             created.Event.Id,
+		CovidPassTypes: &types,
+	})
+	assert.NoError(t, err)
+}
@@ -39,9 +39,12 @@ func TestGRPCHealthServiceReadiness(t *testing.T) {
 	defer conn.Close()
 
 	client := health.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
-	_, err = client.Readiness(context.Background(), &health.ReadinessRequest{})
+	resp, err := client.Readiness(context.Background(), &health.ReadinessRequest{})
 
-	assert.NoError(t, err)
+	if assert.NoError(t, err) {
+		assert.Equal(t, health.StatusOK, resp.Status)
+		assert.NotEmpty(t, resp.Components)
+	}
 }
 
 func TestGRPCHealthServiceVersion(t *testing.T) {
@@ -25,8 +25,11 @@ func TestHTTPHealthServiceLiveness(t *testing.T) {
 func TestHTTPHealthServiceReadiness(t *testing.T) {
 	client, err := health.NewHTTPClient(htttAddrhealth, opentracing.GlobalTracer(), log.NewNopLogger())
 	assert.NoError(t, err)
-	_, err = client.Readiness(context.Background(), &health.ReadinessRequest{})
-	assert.NoError(t, err)
+	resp, err := client.Readiness(context.Background(), &health.ReadinessRequest{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, health.StatusOK, resp.Status)
+		assert.NotEmpty(t, resp.Components)
+	}
 }
 
 func TestHTTPHealthServiceVersion(t *testing.T) {
@@ -0,0 +1,154 @@
+//go:build integration && !unit
+// +build integration,!unit
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/pkg/operator"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const grpcAddroperator = "localhost:9194"
+
+// grpcContext logs the admin in and returns a context carrying the session token.
+func grpcContext(t *testing.T, conn *grpc.ClientConn) context.Context {
+	return grpcLogin(t, conn, operatorLogin, operatorPassword)
+}
+
+// grpcDoorContext creates a door operator and returns a context with their session.
+func grpcDoorContext(t *testing.T, conn *grpc.ClientConn) context.Context {
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	login := fmt.Sprintf("door-grpc-%d", time.Now().UnixNano())
+	_, err := client.CreateOperator(grpcContext(t, conn), &operator.CreateOperatorRequest{
+		Login:    login,
+		Password: "door staff password",
+		Role:     "door",
+	})
+	require.NoError(t, err)
+	return grpcLogin(t, conn, login, "door staff password")
+}
+
+func grpcLogin(t *testing.T, conn *grpc.ClientConn, login, password string) context.Context {
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	resp, err := client.Login(context.Background(), &operator.LoginRequest{
+		Login:    login,
+		Password: password,
+	})
+	require.NoError(t, err)
+	return auth.WithToken(context.Background(), resp.Token)
+}
+
+func TestGRPCOperatorServiceLogin(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.Login(context.Background(), &operator.LoginRequest{
+		Login:    operatorLogin,
+		Password: operatorPassword,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Login(context.Background(), &operator.LoginRequest{
+		Login:    operatorLogin,
+		Password: "wrong password",
+	})
+	assert.Error(t, err)
+}
+
+func TestGRPCOperatorServiceLogout(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	ctx := grpcContext(t, conn)
+	_, err = client.Logout(ctx, &operator.LogoutRequest{})
+	assert.NoError(t, err)
+
+	_, err = client.Me(ctx, &operator.MeRequest{})
+	assert.Error(t, err)
+}
+
+func TestGRPCOperatorServiceMe(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.Me(grpcContext(t, conn), &operator.MeRequest{})
+	assert.NoError(t, err)
+
+	_, err = client.Me(context.Background(), &operator.MeRequest{})
+	assert.Error(t, err)
+}
+
+func TestGRPCOperatorServiceCreateOperator(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.CreateOperator(grpcContext(t, conn), &operator.CreateOperatorRequest{
+		Login:    fmt.Sprintf("door-grpc-%d", time.Now().UnixNano()),
+		Password: "door staff password",
+		Role:     "door",
+	})
+	assert.NoError(t, err)
+}
+
+func TestGRPCOperatorServiceListOperators(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.ListOperators(grpcContext(t, conn), &operator.ListOperatorsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestGRPCOperatorServiceUpdateOperator(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddroperator, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := operator.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	ctx := grpcContext(t, conn)
+	created, err := client.CreateOperator(ctx, &operator.CreateOperatorRequest{
+		Login:    fmt.Sprintf("door-grpc-%d", time.Now().UnixNano()),
+		Password: "door staff password",
+		Role:     "door",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	disabled := true
+	_, err = client.UpdateOperator(ctx, &operator.UpdateOperatorRequest{
+		Id:       created.Operator.Id,
+		Disabled: &disabled,
+	})
+	assert.NoError(t, err)
+}
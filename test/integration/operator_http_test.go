@@ -0,0 +1,139 @@
+//go:build integration && !unit
+// +build integration,!unit
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/nakiner/guestcovider/internal/auth"
+	"github.com/nakiner/guestcovider/pkg/operator"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const htttAddroperator = "localhost:8081"
+
+// the admin created on start of the tested instance
+var (
+	operatorLogin    = envOr("GUESTCOVIDER_AUTH_ADMIN_LOGIN", "admin")
+	operatorPassword = envOr("GUESTCOVIDER_AUTH_ADMIN_PASSWORD", "changeme")
+)
+
+func envOr(key, value string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return value
+}
+
+// httpContext logs the admin in and returns a context carrying the session token.
+func httpContext(t *testing.T) context.Context {
+	return httpLogin(t, operatorLogin, operatorPassword)
+}
+
+// httpDoorContext creates a door operator and returns a context with their session.
+func httpDoorContext(t *testing.T) context.Context {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	require.NoError(t, err)
+	login := fmt.Sprintf("door-http-%d", time.Now().UnixNano())
+	_, err = client.CreateOperator(httpContext(t), &operator.CreateOperatorRequest{
+		Login:    login,
+		Password: "door staff password",
+		Role:     "door",
+	})
+	require.NoError(t, err)
+	return httpLogin(t, login, "door staff password")
+}
+
+func httpLogin(t *testing.T, login, password string) context.Context {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	require.NoError(t, err)
+	resp, err := client.Login(context.Background(), &operator.LoginRequest{
+		Login:    login,
+		Password: password,
+	})
+	require.NoError(t, err)
+	return auth.WithToken(context.Background(), resp.Token)
+}
+
+func TestHTTPOperatorServiceLogin(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.Login(context.Background(), &operator.LoginRequest{
+		Login:    operatorLogin,
+		Password: operatorPassword,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.Login(context.Background(), &operator.LoginRequest{
+		Login:    operatorLogin,
+		Password: "wrong password",
+	})
+	assert.Error(t, err)
+}
+
+func TestHTTPOperatorServiceLogout(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	ctx := httpContext(t)
+	_, err = client.Logout(ctx, &operator.LogoutRequest{})
+	assert.NoError(t, err)
+
+	_, err = client.Me(ctx, &operator.MeRequest{})
+	assert.Error(t, err)
+}
+
+func TestHTTPOperatorServiceMe(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.Me(httpContext(t), &operator.MeRequest{})
+	assert.NoError(t, err)
+
+	_, err = client.Me(context.Background(), &operator.MeRequest{})
+	assert.Error(t, err)
+}
+
+func TestHTTPOperatorServiceCreateOperator(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.CreateOperator(httpContext(t), &operator.CreateOperatorRequest{
+		Login:    fmt.Sprintf("door-http-%d", time.Now().UnixNano()),
+		Password: "door staff password",
+		Role:     "door",
+	})
+	assert.NoError(t, err)
+}
+
+func TestHTTPOperatorServiceListOperators(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.ListOperators(httpContext(t), &operator.ListOperatorsRequest{})
+	assert.NoError(t, err)
+}
+
+func TestHTTPOperatorServiceUpdateOperator(t *testing.T) {
+	client, err := operator.NewHTTPClient(htttAddroperator, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	ctx := httpContext(t)
+	created, err := client.CreateOperator(ctx, &operator.CreateOperatorRequest{
+		Login:    fmt.Sprintf("door-http-%d", time.Now().UnixNano()),
+		Password: "door staff password",
+		Role:     "door",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	disabled := true
+	_, err = client.UpdateOperator(ctx, &operator.UpdateOperatorRequest{
+		Id:       created.Operator.Id,
+		Disabled: &disabled,
+	})
+	assert.NoError(t, err)
+}
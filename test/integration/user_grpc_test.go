@@ -5,13 +5,19 @@ package integration
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/nakiner/guestcovider/pkg/user"
 	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const grpcAddruser = "localhost:9194"
@@ -25,9 +31,43 @@ func TestGRPCUserServiceUpdateUser(t *testing.T) {
 	defer conn.Close()
 
 	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
-	_, err = client.UpdateUser(context.Background(), &user.UpdateUserRequest{})
+	testUpdateUser(t, client, grpcContext(t, conn))
+}
 
-	assert.NoError(t, err)
+// testUpdateUser checks that an update based on the current version of a
+// guest succeeds and a stale one fails with the guest as it is now.
+func testUpdateUser(t *testing.T, client user.Service, ctx context.Context) {
+	created, err := client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	read := created.Data.Version
+
+	_, err = client.UpdateUser(ctx, &user.UpdateUserRequest{Id: created.Data.Id, Data: &user.UpdateData{Checkin: true}})
+	assert.Error(t, err, "the version is required")
+
+	updated, err := client.UpdateUser(ctx, &user.UpdateUserRequest{
+		Id:      created.Data.Id,
+		Version: read,
+		Data:    &user.UpdateData{Checkin: true, Entrance: "A"},
+	})
+	if assert.NoError(t, err) && assert.NotNil(t, updated.Data) {
+		assert.Equal(t, read+1, updated.Data.Version)
+		assert.True(t, updated.Data.Checkin)
+	}
+
+	_, err = client.UpdateUser(ctx, &user.UpdateUserRequest{
+		Id:      created.Data.Id,
+		Version: read,
+		Data:    &user.UpdateData{Checkin: false},
+	})
+	var conflict *user.ConflictError
+	if assert.True(t, errors.As(err, &conflict), "%v", err) && assert.NotNil(t, conflict.Current) {
+		assert.Equal(t, read+1, conflict.Current.Version)
+		assert.True(t, conflict.Current.Checkin)
+	}
 }
 
 func TestGRPCUserServiceSearchUser(t *testing.T) {
@@ -39,7 +79,459 @@ func TestGRPCUserServiceSearchUser(t *testing.T) {
 	defer conn.Close()
 
 	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
-	_, err = client.SearchUser(context.Background(), &user.SearchUserRequest{})
+	ctx := grpcContext(t, conn)
+	_, err = client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Щукина", Name: "Юлия"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := client.SearchUser(ctx, &user.SearchUserRequest{Query: "Shchukina", Limit: 1})
+	if assert.NoError(t, err) && assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, "Щукина", resp.Data[0].Surname)
+		assert.NotZero(t, resp.Total)
+	}
+	if err == nil && resp.NextCursor != "" {
+		_, err = client.SearchUser(ctx, &user.SearchUserRequest{Query: "Shchukina", Limit: 1, Cursor: resp.NextCursor})
+		assert.NoError(t, err)
+	}
+
+	_, err = client.SearchUser(ctx, &user.SearchUserRequest{Cursor: "!!"})
+	assert.Error(t, err)
+}
+
+func TestGRPCUserServiceImportUsers(t *testing.T) {
+
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.ImportUsers(grpcContext(t, conn), &user.ImportUsersRequest{
+		Format:  user.FormatCSV,
+		DryRun:  true,
+		Content: []byte("surname,name\nIvanov,Ivan\n"),
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestGRPCUserServiceExportUsers(t *testing.T) {
+
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	err = client.ExportUsers(grpcContext(t, conn), &user.ExportUsersRequest{}, func(user.User) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestGRPCUserServiceCreateUser(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	_, err = client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	assert.NoError(t, err)
+
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan", CovidPass: &user.CovidPass{Type: "ПЦР", CertificateNumber: "77-123"}},
+	})
+	if assert.NoError(t, err) && assert.NotNil(t, created.Data.CovidPass) {
+		assert.Equal(t, "pcr", created.Data.CovidPass.Type)
+		assert.Equal(t, "77-123", created.Data.CovidPass.CertificateNumber)
+	}
+
+	_, err = client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan", CovidPass: &user.CovidPass{Type: "qr", ExpiresAt: "2020-01-01", TestDate: "2019-06-01"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestGRPCUserServiceGetUser(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = client.GetUser(grpcContext(t, conn), &user.GetUserRequest{Id: created.Data.Id})
+	assert.NoError(t, err)
+}
+
+func TestGRPCUserServicePatchUser(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	name := "Petr"
+	_, err = client.PatchUser(grpcContext(t, conn), &user.PatchUserRequest{
+		Id:   created.Data.Id,
+		Data: &user.PatchData{Name: &name},
+	})
+	assert.NoError(t, err)
+}
+
+func TestGRPCUserServiceDeleteUser(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = client.DeleteUser(grpcContext(t, conn), &user.DeleteUserRequest{Id: created.Data.Id})
+	assert.NoError(t, err)
+}
+
+func TestGRPCUserServiceGetUserHistory(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.GetUserHistory(grpcContext(t, conn), &user.GetUserHistoryRequest{Id: created.Data.Id})
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, resp.Data)
+	}
+}
+
+func TestGRPCUserServiceGetUserQR(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.GetUserQR(grpcContext(t, conn), &user.GetUserQRRequest{Id: created.Data.Id})
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, resp.Token)
+		assert.Equal(t, "image/png", resp.ContentType)
+		assert.NotEmpty(t, resp.Image)
+	}
+}
+
+func TestGRPCUserServiceCheckinByToken(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	code, err := client.GetUserQR(grpcContext(t, conn), &user.GetUserQRRequest{Id: created.Data.Id})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.CheckinByToken(grpcDoorContext(t, conn), &user.CheckinByTokenRequest{Token: code.Token, Entrance: "A"})
+	if assert.NoError(t, err) {
+		assert.False(t, resp.AlreadyCheckedIn)
+		assert.True(t, resp.Data.Checkin)
+		if assert.NotNil(t, resp.Data.CheckinRecord) {
+			assert.Equal(t, "A", resp.Data.CheckinRecord.Entrance)
+			assert.True(t, strings.HasPrefix(resp.Data.CheckinRecord.CheckedInBy, "door-"))
+		}
+	}
+	resp, err = client.CheckinByToken(grpcDoorContext(t, conn), &user.CheckinByTokenRequest{Token: code.Token})
+	if assert.NoError(t, err) {
+		assert.True(t, resp.AlreadyCheckedIn)
+		assert.NotEmpty(t, resp.CheckedInAt)
+	}
+	_, err = client.CheckinByToken(grpcDoorContext(t, conn), &user.CheckinByTokenRequest{Token: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCUserServiceBulkUpdateUsers(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	testBulkUpdateUsers(t, client, grpcContext(t, conn), grpcDoorContext(t, conn))
+}
+
+// testBulkUpdateUsers checks in a company created with ctx by door staff,
+// first all or nothing with an unknown guest, then by the company filter.
+func testBulkUpdateUsers(t *testing.T, client user.Service, ctx, doorCtx context.Context) {
+	company := fmt.Sprintf("Bulk %d", time.Now().UnixNano())
+	var ids []uint64
+	for _, name := range []string{"Ivan", "Petr"} {
+		created, err := client.CreateUser(ctx, &user.CreateUserRequest{
+			Data: &user.User{Surname: "Ivanov", Name: name, Company: company},
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		ids = append(ids, created.Data.Id)
+	}
+	checkin, entrance := true, "B"
+	data := &user.BulkUpdateData{Checkin: &checkin, Entrance: &entrance}
+
+	resp, err := client.BulkUpdateUsers(doorCtx, &user.BulkUpdateUsersRequest{
+		Ids:          append(ids, 1<<62),
+		Data:         data,
+		AllOrNothing: true,
+	})
+	if assert.NoError(t, err) && assert.Len(t, resp.Results, 3) {
+		assert.False(t, resp.Status.Status)
+		assert.Zero(t, resp.Updated)
+		assert.False(t, resp.Results[0].Ok)
+		assert.NotEmpty(t, resp.Results[2].Error)
+	}
+
+	resp, err = client.BulkUpdateUsers(doorCtx, &user.BulkUpdateUsersRequest{
+		Filter: &user.BulkFilter{Company: strings.ToUpper(company)},
+		Data:   data,
+	})
+	if assert.NoError(t, err) && assert.Len(t, resp.Results, 2) {
+		assert.True(t, resp.Status.Status)
+		assert.Equal(t, uint32(2), resp.Updated)
+		for _, res := range resp.Results {
+			if assert.True(t, res.Ok) && assert.NotNil(t, res.Data.CheckinRecord) {
+				assert.True(t, res.Data.Checkin)
+				assert.Equal(t, "B", res.Data.CheckinRecord.Entrance)
+			}
+		}
+	}
+
+	_, err = client.BulkUpdateUsers(doorCtx, &user.BulkUpdateUsersRequest{Data: data})
+	assert.Error(t, err, "a filter or ids are required")
+}
+
+func TestGRPCUserServiceCompanions(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	testCompanions(t, client, grpcContext(t, conn), grpcDoorContext(t, conn))
+}
+
+// testCompanions fills the party of a guest invited with one companion and
+// checks the companion in at the door.
+func testCompanions(t *testing.T, client user.Service, ctx, doorCtx context.Context) {
+	created, err := client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan", MaxCompanions: 1},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	id := created.Data.Id
+
+	surname, name := "Ivanova", "Anna"
+	added, err := client.AddCompanion(doorCtx, &user.AddCompanionRequest{
+		UserId: id,
+		Data:   &user.CompanionData{Surname: &surname, Name: &name},
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, added.Data.Companions, 1) {
+		return
+	}
+	companion := added.Data.Companions[0]
+	assert.False(t, companion.Checkin)
+
+	_, err = client.AddCompanion(doorCtx, &user.AddCompanionRequest{
+		UserId: id,
+		Data:   &user.CompanionData{Surname: &surname},
+	})
+	assert.Error(t, err, "the party is full")
+
+	checkin, entrance := true, "B"
+	patched, err := client.PatchCompanion(doorCtx, &user.PatchCompanionRequest{
+		Id:     companion.Id,
+		UserId: id,
+		Data:   &user.CompanionData{Checkin: &checkin, Entrance: &entrance},
+	})
+	if assert.NoError(t, err) && assert.Len(t, patched.Data.Companions, 1) {
+		assert.True(t, patched.Data.Companions[0].Checkin)
+		assert.False(t, patched.Data.Checkin, "the guest is checked in separately")
+		if assert.NotNil(t, patched.Data.Companions[0].CheckinRecord) {
+			assert.Equal(t, "B", patched.Data.Companions[0].CheckinRecord.Entrance)
+		}
+	}
+
+	none := uint32(0)
+	_, err = client.PatchUser(ctx, &user.PatchUserRequest{Id: id, Data: &user.PatchData{MaxCompanions: &none}})
+	assert.Error(t, err, "the guest already has a companion")
+
+	deleted, err := client.DeleteCompanion(ctx, &user.DeleteCompanionRequest{Id: companion.Id, UserId: id})
+	if assert.NoError(t, err) {
+		assert.Empty(t, deleted.Data.Companions)
+	}
+}
+
+func TestGRPCUserServiceVerifyPass(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	created, err := client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, payload := range []string{"", "https://example.com/covid-cert/verify/1", "HC1:not base45"} {
+		_, err = client.VerifyPass(grpcDoorContext(t, conn), &user.VerifyPassRequest{Id: created.Data.Id, Payload: payload})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), payload)
+	}
+}
 
+func TestGRPCUserServiceWatchUsers(t *testing.T) {
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	testWatchUsers(t, client, grpcDoorContext(t, conn), func() (*user.CreateUserResponse, error) {
+		return client.CreateUser(grpcContext(t, conn), &user.CreateUserRequest{
+			Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+		})
+	})
+}
+
+// testWatchUsers checks that a guest created by create reaches a watching
+// client, then that the backlog of a new stream replays it.
+func testWatchUsers(t *testing.T, client user.Service, ctx context.Context, create func() (*user.CreateUserResponse, error)) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	watch := func(after uint64) (<-chan user.UserChange, <-chan error) {
+		changes, done := make(chan user.UserChange, 64), make(chan error, 1)
+		go func() {
+			done <- client.WatchUsers(ctx, &user.WatchUsersRequest{AfterSeq: after}, func(c user.UserChange) error {
+				select {
+				case changes <- c:
+				case <-ctx.Done():
+				}
+				return nil
+			})
+		}()
+		return changes, done
+	}
+	next := func(changes <-chan user.UserChange, done <-chan error) (user.UserChange, bool) {
+		select {
+		case c := <-changes:
+			return c, true
+		case err := <-done:
+			t.Errorf("stream ended: %v", err)
+		case <-ctx.Done():
+			t.Error("no change received")
+		}
+		return user.UserChange{}, false
+	}
+
+	changes, done := watch(0)
+	ready, ok := next(changes, done)
+	if !ok || !assert.Equal(t, user.ChangeReady, ready.Action) {
+		return
+	}
+
+	created, err := create()
+	if !assert.NoError(t, err) {
+		return
+	}
+	for {
+		c, ok := next(changes, done)
+		if !ok {
+			return
+		}
+		if c.UserId == created.Data.Id {
+			assert.Equal(t, "create", c.Action)
+			assert.Greater(t, c.Seq, ready.Seq)
+			break
+		}
+	}
+
+	changes, done = watch(ready.Seq)
+	for {
+		c, ok := next(changes, done)
+		if !ok || !assert.NotEqual(t, user.ChangeReady, c.Action, "backlog misses the guest") {
+			return
+		}
+		if c.UserId == created.Data.Id {
+			return
+		}
+	}
+}
+
+func TestGRPCUserServicePolicy(t *testing.T) {
+
+	conn, err := grpc.Dial(grpcAddruser, grpc.WithInsecure())
+	if err != nil {
+		t.Errorf("connection to grpc server: %s", err)
+	}
+	defer conn.Close()
+
+	client := user.NewGRPCClient(conn, opentracing.GlobalTracer(), log.NewNopLogger())
+	ctx := grpcDoorContext(t, conn)
+	_, err = client.SearchUser(ctx, &user.SearchUserRequest{Surname: "Ivanov"})
 	assert.NoError(t, err)
+	_, err = client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
 }
@@ -4,7 +4,7 @@
 package integration
 
 import (
-	"context"
+	"strings"
 	"testing"
 
 	"github.com/go-kit/kit/log"
@@ -18,13 +18,222 @@ const htttAddruser = "localhost:8081"
 func TestHTTPUserServiceUpdateUser(t *testing.T) {
 	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
 	assert.NoError(t, err)
-	_, err = client.UpdateUser(context.Background(), &user.UpdateUserRequest{})
-	assert.NoError(t, err)
+	testUpdateUser(t, client, httpContext(t))
 }
 
 func TestHTTPUserServiceSearchUser(t *testing.T) {
 	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
 	assert.NoError(t, err)
-	_, err = client.SearchUser(context.Background(), &user.SearchUserRequest{})
+	ctx := httpContext(t)
+	_, err = client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Щукина", Name: "Юлия"},
+	})
+	assert.NoError(t, err)
+
+	resp, err := client.SearchUser(ctx, &user.SearchUserRequest{Query: "Shchukina", Limit: 1})
+	if assert.NoError(t, err) && assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, "Щукина", resp.Data[0].Surname)
+		assert.NotZero(t, resp.Total)
+	}
+	if err == nil && resp.NextCursor != "" {
+		_, err = client.SearchUser(ctx, &user.SearchUserRequest{Query: "Shchukina", Limit: 1, Cursor: resp.NextCursor})
+		assert.NoError(t, err)
+	}
+
+	_, err = client.SearchUser(ctx, &user.SearchUserRequest{Cursor: "!!"})
+	assert.Error(t, err)
+}
+
+func TestHTTPUserServiceImportUsers(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.ImportUsers(httpContext(t), &user.ImportUsersRequest{
+		Format:  user.FormatCSV,
+		DryRun:  true,
+		Content: []byte("surname,name\nIvanov,Ivan\n"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestHTTPUserServiceExportUsers(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	err = client.ExportUsers(httpContext(t), &user.ExportUsersRequest{}, func(user.User) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestHTTPUserServiceCreateUser(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	_, err = client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	assert.NoError(t, err)
+
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan", CovidPass: &user.CovidPass{Type: "ПЦР", CertificateNumber: "77-123"}},
+	})
+	if assert.NoError(t, err) && assert.NotNil(t, created.Data.CovidPass) {
+		assert.Equal(t, "pcr", created.Data.CovidPass.Type)
+		assert.Equal(t, "77-123", created.Data.CovidPass.CertificateNumber)
+	}
+
+	_, err = client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan", CovidPass: &user.CovidPass{Type: "qr", ExpiresAt: "2020-01-01", TestDate: "2019-06-01"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestHTTPUserServiceGetUser(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = client.GetUser(httpContext(t), &user.GetUserRequest{Id: created.Data.Id})
+	assert.NoError(t, err)
+}
+
+func TestHTTPUserServicePatchUser(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	name := "Petr"
+	_, err = client.PatchUser(httpContext(t), &user.PatchUserRequest{
+		Id:   created.Data.Id,
+		Data: &user.PatchData{Name: &name},
+	})
+	assert.NoError(t, err)
+}
+
+func TestHTTPUserServiceDeleteUser(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = client.DeleteUser(httpContext(t), &user.DeleteUserRequest{Id: created.Data.Id})
+	assert.NoError(t, err)
+}
+
+func TestHTTPUserServiceGetUserHistory(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.GetUserHistory(httpContext(t), &user.GetUserHistoryRequest{Id: created.Data.Id})
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, resp.Data)
+	}
+}
+
+func TestHTTPUserServiceGetUserQR(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.GetUserQR(httpContext(t), &user.GetUserQRRequest{Id: created.Data.Id, Format: user.FormatSVG})
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, resp.Token)
+		assert.Equal(t, "image/svg+xml", resp.ContentType)
+	}
+}
+
+func TestHTTPUserServiceCheckinByToken(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	code, err := client.GetUserQR(httpContext(t), &user.GetUserQRRequest{Id: created.Data.Id})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := client.CheckinByToken(httpDoorContext(t), &user.CheckinByTokenRequest{Token: code.Token, Entrance: "A"})
+	if assert.NoError(t, err) {
+		assert.False(t, resp.AlreadyCheckedIn)
+		assert.True(t, resp.Data.Checkin)
+		if assert.NotNil(t, resp.Data.CheckinRecord) {
+			assert.Equal(t, "A", resp.Data.CheckinRecord.Entrance)
+			assert.True(t, strings.HasPrefix(resp.Data.CheckinRecord.CheckedInBy, "door-"))
+		}
+	}
+	resp, err = client.CheckinByToken(httpDoorContext(t), &user.CheckinByTokenRequest{Token: code.Token})
+	if assert.NoError(t, err) {
+		assert.True(t, resp.AlreadyCheckedIn)
+		assert.NotEmpty(t, resp.CheckedInAt)
+	}
+}
+
+func TestHTTPUserServiceVerifyPass(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	created, err := client.CreateUser(httpContext(t), &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, payload := range []string{"", "https://example.com/covid-cert/verify/1", "HC1:not base45"} {
+		_, err = client.VerifyPass(httpDoorContext(t), &user.VerifyPassRequest{Id: created.Data.Id, Payload: payload})
+		assert.Error(t, err, payload)
+	}
+}
+
+func TestHTTPUserServiceBulkUpdateUsers(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	testBulkUpdateUsers(t, client, httpContext(t), httpDoorContext(t))
+}
+
+func TestHTTPUserServiceCompanions(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	testCompanions(t, client, httpContext(t), httpDoorContext(t))
+}
+
+func TestHTTPUserServiceWatchUsers(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	testWatchUsers(t, client, httpDoorContext(t), func() (*user.CreateUserResponse, error) {
+		return client.CreateUser(httpContext(t), &user.CreateUserRequest{
+			Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+		})
+	})
+}
+
+func TestHTTPUserServicePolicy(t *testing.T) {
+	client, err := user.NewHTTPClient(htttAddruser, opentracing.GlobalTracer(), log.NewNopLogger())
+	assert.NoError(t, err)
+	ctx := httpDoorContext(t)
+	_, err = client.SearchUser(ctx, &user.SearchUserRequest{Surname: "Ivanov"})
 	assert.NoError(t, err)
+	_, err = client.CreateUser(ctx, &user.CreateUserRequest{
+		Data: &user.User{Surname: "Ivanov", Name: "Ivan"},
+	})
+	assert.EqualError(t, err, "403 Forbidden")
 }
@@ -0,0 +1,21 @@
+//go:build webui
+// +build webui
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed dist
+var dist embed.FS
+
+// FS returns the built web app.
+func FS() fs.FS {
+	app, err := fs.Sub(dist, "dist")
+	if err != nil {
+		panic(err)
+	}
+	return app
+}
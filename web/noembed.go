@@ -0,0 +1,14 @@
+//go:build !webui
+// +build !webui
+
+// Package web embeds the built web app into the binary. Build the app with
+// npm run build first, then build the binary with the webui tag; without
+// the tag FS returns nil.
+package web
+
+import "io/fs"
+
+// FS returns the built web app.
+func FS() fs.FS {
+	return nil
+}
@@ -1,7 +1,11 @@
 import axios from 'axios';
 
+// the Go server hands its config to the app it serves as window.guestcovider,
+// the dev server falls back to VUE_APP_API_URL
+const config = window.guestcovider || {};
+
 const axiosInst = axios.create({
-    baseURL: 'http://localhost:8080/',
+    baseURL: config.apiURL || process.env.VUE_APP_API_URL || 'http://localhost:8080/',
 });
 
 export default axiosInst;
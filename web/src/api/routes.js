@@ -1,2 +1,4 @@
 export const UPDATE_USER = () => `/user`;
 export const SEARCH_USERS = (surname) => `user/search?surname=${encodeURIComponent(surname)}`;
+export const WATCH_USERS = () => `user/events`;
+export const PATCH_COMPANION = (userId, id) => `user/${userId}/companions/${id}`;
@@ -2,7 +2,7 @@ import Vue from 'vue'
 import Vuex from 'vuex'
 import axiosInst from '../api';
 import axios from 'axios';
-import { SEARCH_USERS, UPDATE_USER } from '../api/routes';
+import { PATCH_COMPANION, SEARCH_USERS, UPDATE_USER, WATCH_USERS } from '../api/routes';
 
 const CancelToken = axios.CancelToken;
 const source = CancelToken.source();
@@ -29,7 +29,10 @@ export default new Vuex.Store({
       state.users = state.users.map((u) => {
         return u.id === user.id ? user : u;
       })
-    }
+    },
+    removeUser(state, id) {
+      state.users = state.users.filter((u) => u.id !== id);
+    },
   },
   actions: {
     async searchUsers({commit}, surname) {
@@ -50,18 +53,53 @@ export default new Vuex.Store({
         }
       }
     },
+    // keeps found guests up to date with changes made on other tablets,
+    // EventSource reconnects by itself and resumes after the last change
+    watchUsers({commit}) {
+      const events = new EventSource(axiosInst.defaults.baseURL + WATCH_USERS());
+      events.addEventListener('change', (e) => {
+        const change = JSON.parse(e.data);
+        if (change.data) {
+          commit('updateUser', change.data);
+        } else {
+          commit('removeUser', change.userId);
+        }
+      });
+      return events;
+    },
     async updateUserById({commit}, user) {
       try {
         commit('setIsUpdating', true);
-        await axiosInst.put(UPDATE_USER(), {
+        const res = await axiosInst.put(UPDATE_USER(), {
           id: user.id,
+          version: user.version,
           data: {
-            covidPass: user.covidPass,
+            covidPass: user.covidPass && user.covidPass.type ? user.covidPass : null,
             checkin: user.checkin,
           }
         });
-        commit('updateUser', user);
+        commit('updateUser', res.data.data);
         return true;
+      } catch(e) {
+        // the guest was changed on another tablet, show what it is now
+        if (e.response && e.response.status === 409) {
+          commit('updateUser', e.response.data.current);
+          return e.response.data.current;
+        }
+        console.error(e);
+      } finally {
+        commit('setIsUpdating', false);
+      }
+    },
+    // checks a companion in or out, returns the guest with the party
+    async checkinCompanion({commit}, {userId, companion}) {
+      try {
+        commit('setIsUpdating', true);
+        const res = await axiosInst.patch(PATCH_COMPANION(userId, companion.id), {
+          data: { checkin: companion.checkin },
+        });
+        commit('updateUser', res.data.data);
+        return res.data.data;
       } catch(e) {
         console.error(e);
       } finally {